@@ -78,10 +78,6 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	if cfg.WriteBlockSeconds > 0 {
 		blockSec = cfg.WriteBlockSeconds
 	}
-	if cfg.EmptyBlockInterval > 0 {
-		emptyBlockInterval = cfg.EmptyBlockInterval
-	}
-
 	pk, err := hex.DecodeString(minerPrivateKey)
 	if err != nil {
 		panic(err)
@@ -366,6 +362,14 @@ func (client *ParaClient) GetBlockOnMainBySeq(seq int64) (*types.BlockDetail, in
 	return blockDetails.Items[0], blockSeqs.Items[0].Type, nil
 }
 
+//emptyBlockInterval 可以通过UpdateConfig 在运行时修改
+func (client *ParaClient) getEmptyBlockInterval() int64 {
+	if interval := client.GetConfig().EmptyBlockInterval; interval > 0 {
+		return interval
+	}
+	return emptyBlockInterval
+}
+
 func (client *ParaClient) RequestTx(currSeq int64) ([]*types.Transaction, *types.Block, int64, error) {
 	plog.Debug("Para consensus RequestTx")
 
@@ -375,7 +379,7 @@ func (client *ParaClient) RequestTx(currSeq int64) ([]*types.Transaction, *types
 	}
 	plog.Info("RequestTx", "LastSeq", lastSeq, "CurrSeq", currSeq)
 	if lastSeq >= currSeq {
-		if lastSeq-currSeq > emptyBlockInterval {
+		if lastSeq-currSeq > client.getEmptyBlockInterval() {
 			client.isCatchingUp = true
 		} else {
 			client.isCatchingUp = false
@@ -466,7 +470,7 @@ func (client *ParaClient) CreateBlock() {
 			}
 		} else if seqTy == AddAct {
 			if len(txs) == 0 {
-				if blockOnMain.Height-savedBlockOnMain.Block.Height < emptyBlockInterval {
+				if blockOnMain.Height-savedBlockOnMain.Block.Height < client.getEmptyBlockInterval() {
 					incSeqFlag = true
 					continue
 				}
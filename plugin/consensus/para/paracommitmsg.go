@@ -144,6 +144,9 @@ out:
 				isSync = true
 			}
 
+			//共识高度之后的区块还要发送共识交易, 不能被裁剪
+			client.setPruneHold(consensusHeight + 1)
+
 			//未共识过的小于当前共识高度的区块，可以不参与共识
 			//如果是新节点，一直等到同步的区块达到了共识高度，才设置同步参与共识
 			if notification != nil && finishHeight < consensusHeight {
@@ -425,6 +428,15 @@ func (client *CommitMsgClient) getGenesisNodeStatus() (*pt.ParacrossNodeStatus,
 	return &status, nil
 }
 
+func (client *CommitMsgClient) setPruneHold(height int64) {
+	req := &types.ReqPruneHold{Owner: types.PruneHoldPara, Height: height}
+	msg := client.paraClient.GetQueueClient().NewMessage("blockchain", types.EventSetPruneHold, req)
+	err := client.paraClient.GetQueueClient().Send(msg, false)
+	if err != nil {
+		plog.Error("paracommitmsg set prune hold", "height", height, "err", err)
+	}
+}
+
 func (client *CommitMsgClient) onBlockAdded(height int64) error {
 	select {
 	case client.commitMsgNotify <- height:
@@ -365,18 +365,14 @@ func (client *Client) getCurrentTarget(blocktime int64, id string, modify []byte
 	return difficulty.CompactToBig(difficulty.BigToCompact(num))
 }
 
-// calcNextRequiredDifficulty calculates the required difficulty for the block
-// after the passed previous block node based on the difficulty retarget rules.
-// This function differs from the exported CalcNextRequiredDifficulty in that
-// the exported version uses the current best chain as the previous block node
-// while this function accepts any block node.
+// GetNextRequiredDifficulty 计算block 之后一个区块的难度和modify, 难度的计算见drivers.CalcNextRequiredDifficulty
 func (client *Client) GetNextRequiredDifficulty(block *types.Block, bits uint32) (uint32, []byte, error) {
 	// Genesis block.
 	if block == nil {
 		return types.GetP(0).PowLimitBits, defaultModify, nil
 	}
-	cfg := types.GetP(block.Height)
-	blocksPerRetarget := int64(cfg.TargetTimespan / cfg.TargetTimePerBlock)
+	cfg := client.GetRetargetConfig(block.Height)
+	blocksPerRetarget := cfg.BlocksPerRetarget()
 	// Return the previous block's difficulty requirements if this block
 	// is not at a difficulty retarget interval.
 	if (block.Height+1) <= blocksPerRetarget || (block.Height+1)%blocksPerRetarget != 0 {
@@ -403,45 +399,21 @@ func (client *Client) GetNextRequiredDifficulty(block *types.Block, bits uint32)
 	if err != nil {
 		return cfg.PowLimitBits, defaultModify, err
 	}
-	// Limit the amount of adjustment that can occur to the previous
-	// difficulty.
-	actualTimespan := block.BlockTime - firstBlock.BlockTime
-	adjustedTimespan := actualTimespan
-	targetTimespan := int64(cfg.TargetTimespan / time.Second)
-
-	minRetargetTimespan := targetTimespan / (cfg.RetargetAdjustmentFactor)
-	maxRetargetTimespan := targetTimespan * cfg.RetargetAdjustmentFactor
-	if actualTimespan < minRetargetTimespan {
-		adjustedTimespan = minRetargetTimespan
-	} else if actualTimespan > maxRetargetTimespan {
-		adjustedTimespan = maxRetargetTimespan
-	}
-
-	// Calculate new target difficulty as:
-	//  currentDifficulty * (adjustedTimespan / targetTimespan)
-	// The result uses integer division which means it will be slightly
-	// rounded down.  Bitcoind also uses integer division to calculate this
-	// result.
-	oldTarget := difficulty.CompactToBig(bits)
-	newTarget := new(big.Int).Mul(oldTarget, big.NewInt(adjustedTimespan))
-	newTarget.Div(newTarget, big.NewInt(targetTimespan))
-
-	// Limit new value to the proof of work limit.
-	powLimit := difficulty.CompactToBig(cfg.PowLimitBits)
-	if newTarget.Cmp(powLimit) > 0 {
-		newTarget.Set(powLimit)
+	parent := block.GetHeader()
+	parent.Difficulty = bits
+	newTargetBits, err := drivers.CalcNextRequiredDifficulty([]*types.Header{firstBlock.GetHeader(), parent}, cfg)
+	if err != nil {
+		return cfg.PowLimitBits, defaultModify, err
 	}
 
 	// Log new target difficulty and return it.  The new target logging is
 	// intentionally converting the bits back to a number instead of using
 	// newTarget since conversion to the compact representation loses
 	// precision.
-	newTargetBits := difficulty.BigToCompact(newTarget)
 	tlog.Info(fmt.Sprintf("Difficulty retarget at block height %d", block.Height+1))
-	tlog.Info(fmt.Sprintf("Old target %08x, (%064x)", bits, oldTarget))
+	tlog.Info(fmt.Sprintf("Old target %08x, (%064x)", bits, difficulty.CompactToBig(bits)))
 	tlog.Info(fmt.Sprintf("New target %08x, (%064x)", newTargetBits, difficulty.CompactToBig(newTargetBits)))
-	tlog.Info("Timespan", "Actual timespan", time.Duration(actualTimespan)*time.Second,
-		"adjusted timespan", time.Duration(adjustedTimespan)*time.Second,
+	tlog.Info("Timespan", "Actual timespan", time.Duration(block.BlockTime-firstBlock.BlockTime)*time.Second,
 		"target timespan", cfg.TargetTimespan)
 	prevmodify, err := client.getModify(block)
 	if err != nil {
@@ -650,7 +622,7 @@ func (client *Client) updateBlock(newblock *types.Block, txHashList [][]byte) (*
 
 func (client *Client) CreateBlock() {
 	for {
-		if !client.IsMining() || !(client.IsCaughtUp() || client.Cfg.ForceMining) {
+		if !client.IsMining() || !(client.IsCaughtUp() || client.GetConfig().ForceMining) {
 			tlog.Debug("createblock.ismining is disable or client is caughtup is false")
 			time.Sleep(time.Second)
 			continue
@@ -5,5 +5,29 @@
 package executor
 
 /*
-waiting for update
+localdb 索引
+
+所有的key 都在keys.go 中构造, exec_local 写入, exec_del_local 用同样的key 删除或者恢复.
+下面的LODB-lottery- 是默认执行器名字的前缀, 别名执行器使用LODB-lottery-{execName}:, 见calcLocalPrefix:
+
+	LODB-lottery-status:{status}:{createHeight}:{lotteryId}         按状态列出彩票
+	LODB-lottery-:{status}:{lotteryId}                              升级之前的状态索引, 不再写入, 状态变化和回滚时删除
+	LODB-lottery-buy:{lotteryId}:{addr}:{round}:{index}             购买记录, 主记录, 转让时和roundbuy 一起移到新的地址
+	LODB-lottery-roundbuy:{lotteryId}:{round}:{addr}:{index}        按轮次索引购买记录, 值为主记录的key
+	LODB-lottery-buytx:{lotteryId}:{txHash}                         按交易hash 索引购买记录
+	LODB-lottery-draw:{lotteryId}:{round}                           开奖号码
+	LODB-lottery-drawproof:{lotteryId}:{round}                      开奖号码的推导输入
+	LODB-lottery-winner:{lotteryId}:{round}:{addr}:{index}          中奖记录
+	LODB-lottery-round:{lotteryId}:{round}                          轮次信息
+	LODB-lottery-refund:{lotteryId}:{addr}:{round}                  退款记录
+	LODB-lottery-modify:{lotteryId}:{index}                         开奖地址的修改记录
+	LODB-lottery-transfer:{lotteryId}:{index}                       管理地址的移交记录
+	LODB-lottery-config:{lotteryId}:{height}:{index}                开奖地址, 管理地址和黑名单的修改记录, saveConfigHistory 打开时保存
+	LODB-lottery-heat:{lotteryId}:{round}:{number}                  每个号码的购买数量, 回滚到0时删除
+	LODB-lottery-pool:{lotteryId}:{round}                           每轮的购买数量减去退款
+	LODB-lottery-agent:{lotteryId}:{agentAddr}:{round}              代理的销售数量和佣金, round 为0 是所有轮次的累计
+	LODB-lottery-stats / statsbuyer / addrwon / addrspent           计数, 回滚时减回去, 回到初始值时删除key
+	LODB-lottery-board:{lotteryId}:{metric}                         排行榜, 只保存前maxBoardSize 个地址, 回滚时按计数重新排序
+	LODB-lottery-boardundo:{lotteryId}:{metric}:{txHash}            交易挤出排行榜的地址, 回滚时恢复并删除
+	LODB-lottery-payout:{lotteryId}:{addr}:{round}                  锁定的奖金和可以领取的高度, 领取之后标记claimed
 */
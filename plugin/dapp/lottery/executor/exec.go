@@ -28,3 +28,48 @@ func (l *Lottery) Exec_Close(payload *pty.LotteryClose, tx *types.Transaction, i
 	actiondb := NewLotteryAction(l, tx, index)
 	return actiondb.LotteryClose(payload)
 }
+
+func (l *Lottery) Exec_Commit(payload *pty.LotteryCommit, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryCommit(payload)
+}
+
+func (l *Lottery) Exec_Reveal(payload *pty.LotteryReveal, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryReveal(payload)
+}
+
+func (l *Lottery) Exec_RevealNumber(payload *pty.LotteryRevealNumber, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryRevealNumber(payload)
+}
+
+func (l *Lottery) Exec_Modify(payload *pty.LotteryModify, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryModify(payload)
+}
+
+func (l *Lottery) Exec_Transfer(payload *pty.LotteryTransfer, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryTransfer(payload)
+}
+
+func (l *Lottery) Exec_Reclaim(payload *pty.LotteryReclaim, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryReclaim(payload)
+}
+
+func (l *Lottery) Exec_Blacklist(payload *pty.LotteryBlacklist, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryBlacklist(payload)
+}
+
+func (l *Lottery) Exec_Claim(payload *pty.LotteryClaim, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryClaim(payload)
+}
+
+func (l *Lottery) Exec_TransferTicket(payload *pty.LotteryTransferTicket, tx *types.Transaction, index int) (*types.Receipt, error) {
+	actiondb := NewLotteryAction(l, tx, index)
+	return actiondb.LotteryTransferTicket(payload)
+}
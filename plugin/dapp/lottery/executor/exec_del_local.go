@@ -15,9 +15,11 @@ func (l *Lottery) execDelLocal(tx *types.Transaction, receiptData *types.Receipt
 	if receiptData.GetTy() != types.ExecOk {
 		return set, nil
 	}
-	for _, item := range receiptData.Logs {
+	//一笔交易可能包含多条状态变化的回执(开奖之后自动关闭), 回滚时按相反的顺序处理
+	for i := len(receiptData.Logs) - 1; i >= 0; i-- {
+		item := receiptData.Logs[i]
 		switch item.Ty {
-		case pty.TyLogLotteryCreate, pty.TyLogLotteryBuy, pty.TyLogLotteryDraw, pty.TyLogLotteryClose:
+		case pty.TyLogLotteryCreate, pty.TyLogLotteryBuy, pty.TyLogLotteryDraw, pty.TyLogLotteryClose, pty.TyLogLotteryCommit, pty.TyLogLotteryDrawEmpty:
 			var lotterylog pty.ReceiptLottery
 			err := types.Decode(item.Log, &lotterylog)
 			if err != nil {
@@ -29,12 +31,89 @@ func (l *Lottery) execDelLocal(tx *types.Transaction, receiptData *types.Receipt
 			if item.Ty == pty.TyLogLotteryBuy {
 				kv := l.deleteLotteryBuy(&lotterylog)
 				set.KV = append(set.KV, kv...)
+				kv = l.updateLotteryStatsBuy(&lotterylog, false)
+				set.KV = append(set.KV, kv...)
 			} else if item.Ty == pty.TyLogLotteryDraw {
 				kv := l.deleteLotteryDraw(&lotterylog)
 				set.KV = append(set.KV, kv...)
+				kv = l.updateLotteryStatsDraw(&lotterylog, false)
+				set.KV = append(set.KV, kv...)
+				kv = l.updateLotteryAddrWon(&lotterylog, false)
+				set.KV = append(set.KV, kv...)
 				kv = l.updateLotteryBuy(&lotterylog, false)
 				set.KV = append(set.KV, kv...)
+				kv = l.deleteLotteryRound(&lotterylog)
+				set.KV = append(set.KV, kv...)
+			} else if item.Ty == pty.TyLogLotteryClose || item.Ty == pty.TyLogLotteryDrawEmpty {
+				kv := l.deleteLotteryRound(&lotterylog)
+				set.KV = append(set.KV, kv...)
+			}
+		case pty.TyLogLotteryRevealNumber:
+			var lotterylog pty.ReceiptLottery
+			err := types.Decode(item.Log, &lotterylog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.updateLotteryReveal(&lotterylog, false)...)
+		case pty.TyLogLotteryRefund:
+			var refundlog pty.ReceiptLotteryRefund
+			err := types.Decode(item.Log, &refundlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.deleteLotteryRefund(&refundlog)...)
+			set.KV = append(set.KV, l.updateLotteryStatsRefund(&refundlog, false)...)
+		case pty.TyLogLotteryModify:
+			var modifylog pty.ReceiptLotteryModify
+			err := types.Decode(item.Log, &modifylog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.deleteLotteryModify(&modifylog)...)
+			set.KV = append(set.KV, l.deleteLotteryConfigChange(modifyConfigChange(&modifylog))...)
+		case pty.TyLogLotteryTransfer:
+			var transferlog pty.ReceiptLotteryTransfer
+			err := types.Decode(item.Log, &transferlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.deleteLotteryTransfer(&transferlog)...)
+			set.KV = append(set.KV, l.deleteLotteryConfigChange(transferConfigChange(&transferlog))...)
+		case pty.TyLogLotteryBlacklist:
+			var blacklistlog pty.ReceiptLotteryBlacklist
+			err := types.Decode(item.Log, &blacklistlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.deleteLotteryConfigChange(blacklistConfigChange(&blacklistlog))...)
+		case pty.TyLogLotteryReclaim:
+			var reclaimlog pty.ReceiptLotteryReclaim
+			err := types.Decode(item.Log, &reclaimlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.updateLotteryStatsReclaim(&reclaimlog, false)...)
+		case pty.TyLogLotteryPayoutLock:
+			var payoutlog pty.ReceiptLotteryPayout
+			err := types.Decode(item.Log, &payoutlog)
+			if err != nil {
+				return nil, err
 			}
+			set.KV = append(set.KV, l.deleteLotteryPayout(&payoutlog))
+		case pty.TyLogLotteryClaim:
+			var payoutlog pty.ReceiptLotteryPayout
+			err := types.Decode(item.Log, &payoutlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.saveLotteryPayout(&payoutlog, false))
+		case pty.TyLogLotteryTicketTransfer:
+			var transferlog pty.ReceiptLotteryTicketTransfer
+			err := types.Decode(item.Log, &transferlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.transferLotteryBuy(&transferlog, false)...)
 		}
 	}
 	return set, nil
@@ -42,17 +121,53 @@ func (l *Lottery) execDelLocal(tx *types.Transaction, receiptData *types.Receipt
 }
 
 func (l *Lottery) ExecDelLocal_Create(payload *pty.LotteryCreate, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	return nil, nil
+	return l.execDelLocal(tx, receiptData)
 }
 
 func (l *Lottery) ExecDelLocal_Buy(payload *pty.LotteryBuy, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	return nil, nil
+	return l.execDelLocal(tx, receiptData)
 }
 
 func (l *Lottery) ExecDelLocal_Draw(payload *pty.LotteryDraw, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	return nil, nil
+	return l.execDelLocal(tx, receiptData)
 }
 
 func (l *Lottery) ExecDelLocal_Close(payload *pty.LotteryClose, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	return nil, nil
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Commit(payload *pty.LotteryCommit, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Reveal(payload *pty.LotteryReveal, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_RevealNumber(payload *pty.LotteryRevealNumber, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Modify(payload *pty.LotteryModify, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Transfer(payload *pty.LotteryTransfer, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Reclaim(payload *pty.LotteryReclaim, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Blacklist(payload *pty.LotteryBlacklist, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_Claim(payload *pty.LotteryClaim, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecDelLocal_TransferTicket(payload *pty.LotteryTransferTicket, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execDelLocal(tx, receiptData)
 }
@@ -17,7 +17,7 @@ func (l *Lottery) execLocal(tx *types.Transaction, receipt *types.ReceiptData) (
 	}
 	for _, item := range receipt.Logs {
 		switch item.Ty {
-		case pty.TyLogLotteryCreate, pty.TyLogLotteryBuy, pty.TyLogLotteryDraw, pty.TyLogLotteryClose:
+		case pty.TyLogLotteryCreate, pty.TyLogLotteryBuy, pty.TyLogLotteryDraw, pty.TyLogLotteryClose, pty.TyLogLotteryCommit, pty.TyLogLotteryDrawEmpty:
 			var lotterylog pty.ReceiptLottery
 			err := types.Decode(item.Log, &lotterylog)
 			if err != nil {
@@ -29,12 +29,85 @@ func (l *Lottery) execLocal(tx *types.Transaction, receipt *types.ReceiptData) (
 			if item.Ty == pty.TyLogLotteryBuy {
 				kv := l.saveLotteryBuy(&lotterylog)
 				set.KV = append(set.KV, kv...)
+				kv = l.updateLotteryStatsBuy(&lotterylog, true)
+				set.KV = append(set.KV, kv...)
 			} else if item.Ty == pty.TyLogLotteryDraw {
 				kv := l.saveLotteryDraw(&lotterylog)
 				set.KV = append(set.KV, kv...)
+				kv = l.updateLotteryStatsDraw(&lotterylog, true)
+				set.KV = append(set.KV, kv...)
+				kv = l.updateLotteryAddrWon(&lotterylog, true)
+				set.KV = append(set.KV, kv...)
 				kv = l.updateLotteryBuy(&lotterylog, true)
 				set.KV = append(set.KV, kv...)
+				kv = l.saveLotteryRound(&lotterylog)
+				set.KV = append(set.KV, kv...)
+			} else if item.Ty == pty.TyLogLotteryClose {
+				kv := l.saveLotteryRound(&lotterylog)
+				set.KV = append(set.KV, kv...)
+			} else if item.Ty == pty.TyLogLotteryDrawEmpty {
+				kv := l.saveLotteryEmptyRound(&lotterylog)
+				set.KV = append(set.KV, kv...)
+			}
+		case pty.TyLogLotteryRevealNumber:
+			var lotterylog pty.ReceiptLottery
+			err := types.Decode(item.Log, &lotterylog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.updateLotteryReveal(&lotterylog, true)...)
+		case pty.TyLogLotteryRefund:
+			var refundlog pty.ReceiptLotteryRefund
+			err := types.Decode(item.Log, &refundlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.saveLotteryRefund(&refundlog)...)
+			set.KV = append(set.KV, l.updateLotteryStatsRefund(&refundlog, true)...)
+		case pty.TyLogLotteryModify:
+			var modifylog pty.ReceiptLotteryModify
+			err := types.Decode(item.Log, &modifylog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.saveLotteryModify(&modifylog)...)
+			set.KV = append(set.KV, l.saveLotteryConfigChange(modifyConfigChange(&modifylog))...)
+		case pty.TyLogLotteryTransfer:
+			var transferlog pty.ReceiptLotteryTransfer
+			err := types.Decode(item.Log, &transferlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.saveLotteryTransfer(&transferlog)...)
+			set.KV = append(set.KV, l.saveLotteryConfigChange(transferConfigChange(&transferlog))...)
+		case pty.TyLogLotteryBlacklist:
+			var blacklistlog pty.ReceiptLotteryBlacklist
+			err := types.Decode(item.Log, &blacklistlog)
+			if err != nil {
+				return nil, err
 			}
+			set.KV = append(set.KV, l.saveLotteryConfigChange(blacklistConfigChange(&blacklistlog))...)
+		case pty.TyLogLotteryReclaim:
+			var reclaimlog pty.ReceiptLotteryReclaim
+			err := types.Decode(item.Log, &reclaimlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.updateLotteryStatsReclaim(&reclaimlog, true)...)
+		case pty.TyLogLotteryPayoutLock, pty.TyLogLotteryClaim:
+			var payoutlog pty.ReceiptLotteryPayout
+			err := types.Decode(item.Log, &payoutlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.saveLotteryPayout(&payoutlog, item.Ty == pty.TyLogLotteryClaim))
+		case pty.TyLogLotteryTicketTransfer:
+			var transferlog pty.ReceiptLotteryTicketTransfer
+			err := types.Decode(item.Log, &transferlog)
+			if err != nil {
+				return nil, err
+			}
+			set.KV = append(set.KV, l.transferLotteryBuy(&transferlog, true)...)
 		}
 	}
 	return set, nil
@@ -55,3 +128,39 @@ func (l *Lottery) ExecLocal_Draw(payload *pty.LotteryDraw, tx *types.Transaction
 func (l *Lottery) ExecLocal_Close(payload *pty.LotteryClose, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	return l.execLocal(tx, receiptData)
 }
+
+func (l *Lottery) ExecLocal_Commit(payload *pty.LotteryCommit, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_Reveal(payload *pty.LotteryReveal, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_RevealNumber(payload *pty.LotteryRevealNumber, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_Modify(payload *pty.LotteryModify, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_Transfer(payload *pty.LotteryTransfer, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_Reclaim(payload *pty.LotteryReclaim, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_Blacklist(payload *pty.LotteryBlacklist, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_Claim(payload *pty.LotteryClaim, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
+
+func (l *Lottery) ExecLocal_TransferTicket(payload *pty.LotteryTransferTicket, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return l.execLocal(tx, receiptData)
+}
@@ -4,34 +4,223 @@
 
 package executor
 
-import "fmt"
+import (
+	"fmt"
 
-func calcLotteryBuyPrefix(lotteryId string, addr string) []byte {
-	key := fmt.Sprintf("LODB-lottery-buy:%s:%s", lotteryId, addr)
+	"github.com/33cn/chain33/types"
+)
+
+//localdb key 的前缀由执行器名字计算, 通过别名(user.lottery.xxx)执行的交易使用各自的前缀, 互相看不到对方的记录.
+//本链平行链的执行器去掉title, 和主链的key 保持一致, 默认的lottery 仍然是LODB-lottery-
+//框架只允许写入LODB-{真实执行器名字}- 开头的key, 别名放在这个前缀之后, 以':' 结束, 例如LODB-lottery-user.lottery.a:
+func calcLocalPrefix(execName string) string {
+	exec := types.GetParaExec([]byte(execName))
+	realExec := types.GetRealExecName(exec)
+	prefix := "LODB-" + string(realExec) + "-"
+	if string(exec) == string(realExec) {
+		return prefix
+	}
+	return prefix + string(exec) + ":"
+}
+
+func calcLotteryBuyPrefix(prefix string, lotteryId string, addr string) []byte {
+	key := fmt.Sprintf("%sbuy:%s:%s", prefix, lotteryId, addr)
+	return []byte(key)
+}
+
+func calcLotteryBuyRoundPrefix(prefix string, lotteryId string, addr string, round int64) []byte {
+	key := fmt.Sprintf("%sbuy:%s:%s:%10d", prefix, lotteryId, addr, round)
+	return []byte(key)
+}
+
+func calcLotteryBuyKey(prefix string, lotteryId string, addr string, round int64, index int64) []byte {
+	key := fmt.Sprintf("%sbuy:%s:%s:%10d:%18d", prefix, lotteryId, addr, round, index)
+	return []byte(key)
+}
+
+//按轮次索引购买记录, 开奖时标记本轮所有的购买记录
+func calcLotteryRoundBuyPrefix(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%sroundbuy:%s:%10d:", prefix, lotteryId, round)
 	return []byte(key)
 }
 
-func calcLotteryBuyRoundPrefix(lotteryId string, addr string, round int64) []byte {
-	key := fmt.Sprintf("LODB-lottery-buy:%s:%s:%10d", lotteryId, addr, round)
+func calcLotteryRoundBuyKey(prefix string, lotteryId string, round int64, addr string, index int64) []byte {
+	key := fmt.Sprintf("%sroundbuy:%s:%10d:%s:%18d", prefix, lotteryId, round, addr, index)
 	return []byte(key)
 }
 
-func calcLotteryBuyKey(lotteryId string, addr string, round int64, index int64) []byte {
-	key := fmt.Sprintf("LODB-lottery-buy:%s:%s:%10d:%18d", lotteryId, addr, round, index)
+func calcLotteryDrawPrefix(prefix string, lotteryId string) []byte {
+	key := fmt.Sprintf("%sdraw:%s", prefix, lotteryId)
 	return []byte(key)
 }
 
-func calcLotteryDrawPrefix(lotteryId string) []byte {
-	key := fmt.Sprintf("LODB-lottery-draw:%s", lotteryId)
+func calcLotteryDrawKey(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%sdraw:%s:%10d", prefix, lotteryId, round)
 	return []byte(key)
 }
 
-func calcLotteryDrawKey(lotteryId string, round int64) []byte {
-	key := fmt.Sprintf("LODB-lottery-draw:%s:%10d", lotteryId, round)
+//每一轮开奖号码的推导输入
+func calcLotteryDrawProofKey(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%sdrawproof:%s:%10d", prefix, lotteryId, round)
 	return []byte(key)
 }
 
-func calcLotteryKey(lotteryId string, status int32) []byte {
+func calcLotteryStatusPrefix(prefix string, status int32) []byte {
+	key := fmt.Sprintf("%sstatus:%d:", prefix, status)
+	return []byte(key)
+}
+
+//同一状态下按创建高度排序
+func calcLotteryStatusKey(prefix string, status int32, createHeight int64, lotteryId string) []byte {
+	key := fmt.Sprintf("%sstatus:%d:%18d:%s", prefix, status, createHeight, lotteryId)
+	return []byte(key)
+}
+
+//按状态查询的索引增加创建高度之前的key, 值为lotteryId, 只有默认的lottery 执行器有这种key.
+//localdb 中已有的key 不做迁移, 状态变化和回滚时删除, 查询时和新的索引一起返回
+func calcLotteryLegacyStatusPrefix(status int32) []byte {
+	key := fmt.Sprintf("LODB-lottery-:%d:", status)
+	return []byte(key)
+}
+
+func calcLotteryLegacyStatusKey(status int32, lotteryId string) []byte {
 	key := fmt.Sprintf("LODB-lottery-:%d:%s", status, lotteryId)
 	return []byte(key)
 }
+
+func calcLotteryWinnerRoundPrefix(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%swinner:%s:%10d", prefix, lotteryId, round)
+	return []byte(key)
+}
+
+func calcLotteryWinnerKey(prefix string, lotteryId string, round int64, addr string, index int64) []byte {
+	key := fmt.Sprintf("%swinner:%s:%10d:%s:%18d", prefix, lotteryId, round, addr, index)
+	return []byte(key)
+}
+
+func calcLotteryRoundPrefix(prefix string, lotteryId string) []byte {
+	key := fmt.Sprintf("%sround:%s", prefix, lotteryId)
+	return []byte(key)
+}
+
+func calcLotteryRoundKey(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%sround:%s:%10d", prefix, lotteryId, round)
+	return []byte(key)
+}
+
+func calcLotteryRefundPrefix(prefix string, lotteryId string, addr string) []byte {
+	key := fmt.Sprintf("%srefund:%s:%s", prefix, lotteryId, addr)
+	return []byte(key)
+}
+
+func calcLotteryRefundKey(prefix string, lotteryId string, addr string, round int64) []byte {
+	key := fmt.Sprintf("%srefund:%s:%s:%10d", prefix, lotteryId, addr, round)
+	return []byte(key)
+}
+
+//开奖地址的修改记录, 按交易顺序排列
+func calcLotteryModifyPrefix(prefix string, lotteryId string) []byte {
+	key := fmt.Sprintf("%smodify:%s:", prefix, lotteryId)
+	return []byte(key)
+}
+
+func calcLotteryModifyKey(prefix string, lotteryId string, index int64) []byte {
+	key := fmt.Sprintf("%smodify:%s:%18d", prefix, lotteryId, index)
+	return []byte(key)
+}
+
+//可变配置的修改记录, 按高度和交易顺序排列
+func calcLotteryConfigPrefix(prefix string, lotteryId string) []byte {
+	key := fmt.Sprintf("%sconfig:%s:", prefix, lotteryId)
+	return []byte(key)
+}
+
+func calcLotteryConfigKey(prefix string, lotteryId string, height int64, index int64) []byte {
+	key := fmt.Sprintf("%sconfig:%s:%18d:%18d", prefix, lotteryId, height, index)
+	return []byte(key)
+}
+
+//每一轮每个号码的购买数量, 只保存有人购买的号码
+func calcLotteryHeatPrefix(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%sheat:%s:%10d:", prefix, lotteryId, round)
+	return []byte(key)
+}
+
+func calcLotteryHeatKey(prefix string, lotteryId string, round int64, number int64) []byte {
+	key := fmt.Sprintf("%sheat:%s:%10d:%05d", prefix, lotteryId, round, number)
+	return []byte(key)
+}
+
+//每轮累计的购买数量, 退款时减去
+func calcLotteryRoundPoolKey(prefix string, lotteryId string, round int64) []byte {
+	key := fmt.Sprintf("%spool:%s:%10d", prefix, lotteryId, round)
+	return []byte(key)
+}
+
+//代理在每轮的销售数量, round 为0时是所有轮次的累计
+func calcLotteryAgentSalesKey(prefix string, lotteryId string, agentAddr string, round int64) []byte {
+	key := fmt.Sprintf("%sagent:%s:%s:%10d", prefix, lotteryId, agentAddr, round)
+	return []byte(key)
+}
+
+//管理地址的移交记录, 按交易顺序排列
+func calcLotteryTransferPrefix(prefix string, lotteryId string) []byte {
+	key := fmt.Sprintf("%stransfer:%s:", prefix, lotteryId)
+	return []byte(key)
+}
+
+func calcLotteryTransferKey(prefix string, lotteryId string, index int64) []byte {
+	key := fmt.Sprintf("%stransfer:%s:%18d", prefix, lotteryId, index)
+	return []byte(key)
+}
+
+func calcLotteryBuyTxKey(prefix string, lotteryId string, txHash string) []byte {
+	key := fmt.Sprintf("%sbuytx:%s:%s", prefix, lotteryId, txHash)
+	return []byte(key)
+}
+
+func calcLotteryStatsKey(prefix string, lotteryId string) []byte {
+	key := fmt.Sprintf("%sstats:%s", prefix, lotteryId)
+	return []byte(key)
+}
+
+//地址累计的中奖金额, 账户中的金额
+func calcLotteryAddrWonKey(prefix string, lotteryId string, addr string) []byte {
+	key := fmt.Sprintf("%saddrwon:%s:%s", prefix, lotteryId, addr)
+	return []byte(key)
+}
+
+//地址累计的购买数量, 和购买记录中的amount 单位相同
+func calcLotteryAddrSpentKey(prefix string, lotteryId string, addr string) []byte {
+	key := fmt.Sprintf("%saddrspent:%s:%s", prefix, lotteryId, addr)
+	return []byte(key)
+}
+
+//排行榜, 每个彩票每种排名只保存前maxBoardSize 个地址
+func calcLotteryBoardKey(prefix string, lotteryId string, metric int32) []byte {
+	key := fmt.Sprintf("%sboard:%s:%d", prefix, lotteryId, metric)
+	return []byte(key)
+}
+
+//交易把地址挤出排行榜时保存被挤出的地址, 回滚时恢复
+func calcLotteryBoardUndoKey(prefix string, lotteryId string, metric int32, txHash string) []byte {
+	key := fmt.Sprintf("%sboardundo:%s:%d:%s", prefix, lotteryId, metric, txHash)
+	return []byte(key)
+}
+
+//每个地址在该彩票中的购买交易数量, 用来精确统计不同的购买地址
+func calcLotteryStatsBuyerKey(prefix string, lotteryId string, addr string) []byte {
+	key := fmt.Sprintf("%sstatsbuyer:%s:%s", prefix, lotteryId, addr)
+	return []byte(key)
+}
+
+//中奖地址每一轮锁定的奖金和可以领取的高度, 按轮次排序
+func calcLotteryPayoutPrefix(prefix string, lotteryId string, addr string) []byte {
+	key := fmt.Sprintf("%spayout:%s:%s:", prefix, lotteryId, addr)
+	return []byte(key)
+}
+
+func calcLotteryPayoutKey(prefix string, lotteryId string, addr string, round int64) []byte {
+	key := fmt.Sprintf("%spayout:%s:%s:%10d", prefix, lotteryId, addr, round)
+	return []byte(key)
+}
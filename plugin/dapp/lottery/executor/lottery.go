@@ -5,8 +5,13 @@
 package executor
 
 import (
+	"bytes"
+	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/33cn/chain33/client"
+	dbm "github.com/33cn/chain33/common/db"
 	log "github.com/33cn/chain33/common/log/log15"
 	drivers "github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
@@ -23,6 +28,8 @@ func init() {
 
 type subConfig struct {
 	ParaRemoteGrpcClient string `json:"paraRemoteGrpcClient"`
+	//保存开奖地址, 管理地址和黑名单的修改记录, 用于查询配置的修改历史
+	SaveConfigHistory bool `json:"saveConfigHistory"`
 }
 
 var cfg subConfig
@@ -53,10 +60,25 @@ func newLottery() drivers.Driver {
 	return l
 }
 
+//NewLotteryWithDB 使用指定的状态数据库, localdb 和api 创建执行器, 不经过drivers 的注册.
+//用于在执行器外面直接调用Exec/ExecLocal/ExecDelLocal, 例如replay 中的交易重放测试
+func NewLotteryWithDB(stateDB dbm.KV, localDB dbm.KVDB, api client.QueueProtocolAPI) *Lottery {
+	l := newLottery().(*Lottery)
+	l.SetStateDB(stateDB)
+	l.SetLocalDB(localDB)
+	l.SetApi(api)
+	return l
+}
+
 func (l *Lottery) GetDriverName() string {
 	return pty.LotteryX
 }
 
+//执行交易时是交易的执行器名字, 查询时是查询请求的执行器名字
+func (l *Lottery) localPrefix() string {
+	return calcLocalPrefix(l.GetCurrentExecName())
+}
+
 func (lott *Lottery) findLotteryBuyRecords(key []byte) (*pty.LotteryBuyRecords, error) {
 
 	count := lott.GetLocalDB().PrefixCount(key)
@@ -118,112 +140,922 @@ func (lott *Lottery) findLotteryDrawRecord(key []byte) (*pty.LotteryDrawRecord,
 	return &record, nil
 }
 
+//按交易hash 索引到购买记录, 开奖和揭示时更新的是购买记录本身, 通过索引查询时总是最新的结果
 func (lott *Lottery) saveLotteryBuy(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
-	key := calcLotteryBuyKey(lotterylog.LotteryId, lotterylog.Addr, lotterylog.Round, lotterylog.Index)
-	kv := &types.KeyValue{}
-	record := &pty.LotteryBuyRecord{lotterylog.Number, lotterylog.Amount, lotterylog.Round, 0, lotterylog.Way, lotterylog.Index, lotterylog.Time, lotterylog.TxHash}
-	kv = &types.KeyValue{key, types.Encode(record)}
-
-	kvs = append(kvs, kv)
+	index := &pty.LotteryBuyTxIndex{Addr: lotterylog.Addr, Round: lotterylog.Round}
+	var spent int64
+	for _, item := range buyItems(lotterylog) {
+		key := calcLotteryBuyKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Addr, lotterylog.Round, item.Index)
+		record := &pty.LotteryBuyRecord{Number: item.Number, Amount: item.Amount, Round: lotterylog.Round, Way: item.Way, Index: item.Index,
+			Time: lotterylog.Time, TxHash: lotterylog.TxHash, CommitHash: lotterylog.CommitHash}
+		//写入localdb 的缓存, 同一个区块中后面的转让交易能读到购买记录
+		kvs = append(kvs, lott.setLocal(key, types.Encode(record)))
+		kvs = append(kvs, lott.setLocal(calcLotteryRoundBuyKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round, lotterylog.Addr, item.Index), key))
+		index.Indexes = append(index.Indexes, item.Index)
+		spent += item.Amount
+		//盲选购买的号码在揭示时计入
+		if len(lotterylog.CommitHash) == 0 {
+			kvs = append(kvs, lott.updateNumberHeat(lotterylog.LotteryId, lotterylog.Round, item.Number, item.Amount, true))
+		}
+	}
+	kvs = append(kvs, &types.KeyValue{calcLotteryBuyTxKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.TxHash), types.Encode(index)})
+	kvs = append(kvs, lott.addLocalInt64(calcLotteryAddrSpentKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Addr), spent))
+	kvs = append(kvs, lott.addLocalInt64(calcLotteryRoundPoolKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round), spent))
+	kvs = append(kvs, lott.updateAgentSales(lotterylog, spent, true)...)
+	kvs = append(kvs, lott.updateLeaderboard(lotterylog.LotteryId, pty.LotteryBoardSpent, lotterylog.TxHash, []string{lotterylog.Addr}, true)...)
 	return kvs
 }
 
 func (lott *Lottery) deleteLotteryBuy(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
-	key := calcLotteryBuyKey(lotterylog.LotteryId, lotterylog.Addr, lotterylog.Round, lotterylog.Index)
+	var spent int64
+	for _, item := range buyItems(lotterylog) {
+		key := calcLotteryBuyKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Addr, lotterylog.Round, item.Index)
+		kv := &types.KeyValue{key, nil}
+		kvs = append(kvs, kv)
+		kvs = append(kvs, &types.KeyValue{calcLotteryRoundBuyKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round, lotterylog.Addr, item.Index), nil})
+		spent += item.Amount
+		if len(lotterylog.CommitHash) == 0 {
+			kvs = append(kvs, lott.updateNumberHeat(lotterylog.LotteryId, lotterylog.Round, item.Number, item.Amount, false))
+		}
+	}
+	kvs = append(kvs, &types.KeyValue{calcLotteryBuyTxKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.TxHash), nil})
+	kvs = append(kvs, lott.addLocalInt64(calcLotteryAddrSpentKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Addr), -spent))
+	kvs = append(kvs, lott.addLocalInt64(calcLotteryRoundPoolKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round), -spent))
+	kvs = append(kvs, lott.updateAgentSales(lotterylog, spent, false)...)
+	kvs = append(kvs, lott.updateLeaderboard(lotterylog.LotteryId, pty.LotteryBoardSpent, lotterylog.TxHash, []string{lotterylog.Addr}, false)...)
+	return kvs
+}
 
-	kv := &types.KeyValue{key, nil}
-	kvs = append(kvs, kv)
+//转让时购买记录从原来地址的key 移到新地址的key, 按轮次的索引同样移动, 回滚时移回去
+//按交易hash 的索引不变, 仍然指向购买地址
+func (lott *Lottery) transferLotteryBuy(transferlog *pty.ReceiptLotteryTicketTransfer, isAdd bool) (kvs []*types.KeyValue) {
+	from, to := transferlog.From, transferlog.To
+	if !isAdd {
+		from, to = to, from
+	}
+	fromKey := calcLotteryBuyKey(lott.localPrefix(), transferlog.LotteryId, from, transferlog.Round, transferlog.Index)
+	record, err := lott.findLotteryBuyRecord(fromKey)
+	if err != nil || record == nil {
+		llog.Error("transferLotteryBuy", "key", string(fromKey), "err", err)
+		return kvs
+	}
+	toKey := calcLotteryBuyKey(lott.localPrefix(), transferlog.LotteryId, to, transferlog.Round, transferlog.Index)
+	kvs = append(kvs, lott.setLocal(fromKey, nil))
+	kvs = append(kvs, lott.setLocal(toKey, types.Encode(record)))
+	kvs = append(kvs, lott.setLocal(calcLotteryRoundBuyKey(lott.localPrefix(), transferlog.LotteryId, transferlog.Round, from, transferlog.Index), nil))
+	kvs = append(kvs, lott.setLocal(calcLotteryRoundBuyKey(lott.localPrefix(), transferlog.LotteryId, transferlog.Round, to, transferlog.Index), toKey))
+	return kvs
+}
+
+func (lott *Lottery) findAgentSales(lotteryId string, agentAddr string, round int64) *pty.LotteryAgentSales {
+	sales := &pty.LotteryAgentSales{LotteryId: lotteryId, AgentAddr: agentAddr, Round: round}
+	value, err := lott.GetLocalDB().Get(calcLotteryAgentSalesKey(lott.localPrefix(), lotteryId, agentAddr, round))
+	if err != nil {
+		return sales
+	}
+	if err := types.Decode(value, sales); err != nil {
+		llog.Error("findAgentSales", "lotteryId", lotteryId, "agentAddr", agentAddr, "decode", err)
+	}
+	return sales
+}
+
+//通过代理的购买同时累计到本轮和所有轮次, 回滚时减回去
+func (lott *Lottery) updateAgentSales(lotterylog *pty.ReceiptLottery, amount int64, isAdd bool) (kvs []*types.KeyValue) {
+	if lotterylog.AgentAddr == "" {
+		return kvs
+	}
+	var sign int64 = 1
+	if !isAdd {
+		sign = -1
+	}
+	for _, round := range []int64{lotterylog.Round, 0} {
+		sales := lott.findAgentSales(lotterylog.LotteryId, lotterylog.AgentAddr, round)
+		sales.Amount += sign * amount
+		sales.Commission += sign * lotterylog.Commission
+		sales.TxNum += sign
+		initial := &pty.LotteryAgentSales{LotteryId: lotterylog.LotteryId, AgentAddr: lotterylog.AgentAddr, Round: round}
+		kvs = append(kvs, lott.setLocalCounter(calcLotteryAgentSalesKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.AgentAddr, round), sales, initial))
+	}
+	return kvs
+}
+
+//buyItems 旧的回执没有buyItems, 由单个号码字段构造
+func buyItems(lotterylog *pty.ReceiptLottery) []*pty.LotteryBuyItem {
+	if len(lotterylog.BuyItems) > 0 {
+		return lotterylog.BuyItems
+	}
+	return []*pty.LotteryBuyItem{{Number: lotterylog.Number, Amount: lotterylog.Amount, Way: lotterylog.Way, Index: lotterylog.Index}}
+}
+
+//退款的数量从该轮的累计购买数量中减去, 累积头奖的分成不在购买数量中
+func (lott *Lottery) saveLotteryRefund(refundlog *pty.ReceiptLotteryRefund) (kvs []*types.KeyValue) {
+	key := calcLotteryRefundKey(lott.localPrefix(), refundlog.LotteryId, refundlog.Addr, refundlog.Round)
+	kvs = append(kvs, &types.KeyValue{key, types.Encode(refundlog)})
+	kvs = append(kvs, lott.addLocalInt64(calcLotteryRoundPoolKey(lott.localPrefix(), refundlog.LotteryId, refundlog.Round), -refundlog.Amount))
+	return kvs
+}
+
+func (lott *Lottery) deleteLotteryRefund(refundlog *pty.ReceiptLotteryRefund) (kvs []*types.KeyValue) {
+	key := calcLotteryRefundKey(lott.localPrefix(), refundlog.LotteryId, refundlog.Addr, refundlog.Round)
+	kvs = append(kvs, &types.KeyValue{key, nil})
+	kvs = append(kvs, lott.addLocalInt64(calcLotteryRoundPoolKey(lott.localPrefix(), refundlog.LotteryId, refundlog.Round), refundlog.Amount))
+	return kvs
+}
+
+//锁定和领取奖金的回执使用同一个key, 领取之后标记claimed, 回滚领取时恢复为未领取
+func (lott *Lottery) saveLotteryPayout(payoutlog *pty.ReceiptLotteryPayout, claimed bool) *types.KeyValue {
+	payout := &pty.LotteryPayout{
+		LotteryId:       payoutlog.LotteryId,
+		Round:           payoutlog.Round,
+		Addr:            payoutlog.Addr,
+		Amount:          payoutlog.Amount,
+		ClaimableHeight: payoutlog.ClaimableHeight,
+		Claimed:         claimed,
+	}
+	if claimed {
+		payout.ClaimTxHash = payoutlog.TxHash
+	}
+	key := calcLotteryPayoutKey(lott.localPrefix(), payoutlog.LotteryId, payoutlog.Addr, payoutlog.Round)
+	return lott.setLocal(key, types.Encode(payout))
+}
+
+func (lott *Lottery) deleteLotteryPayout(payoutlog *pty.ReceiptLotteryPayout) *types.KeyValue {
+	key := calcLotteryPayoutKey(lott.localPrefix(), payoutlog.LotteryId, payoutlog.Addr, payoutlog.Round)
+	return lott.setLocal(key, nil)
+}
+
+func (lott *Lottery) saveLotteryModify(modifylog *pty.ReceiptLotteryModify) (kvs []*types.KeyValue) {
+	key := calcLotteryModifyKey(lott.localPrefix(), modifylog.LotteryId, modifylog.Index)
+	kvs = append(kvs, &types.KeyValue{key, types.Encode(modifylog)})
+	return kvs
+}
+
+func (lott *Lottery) deleteLotteryModify(modifylog *pty.ReceiptLotteryModify) (kvs []*types.KeyValue) {
+	key := calcLotteryModifyKey(lott.localPrefix(), modifylog.LotteryId, modifylog.Index)
+	kvs = append(kvs, &types.KeyValue{key, nil})
+	return kvs
+}
+
+func (lott *Lottery) saveLotteryTransfer(transferlog *pty.ReceiptLotteryTransfer) (kvs []*types.KeyValue) {
+	key := calcLotteryTransferKey(lott.localPrefix(), transferlog.LotteryId, transferlog.Index)
+	kvs = append(kvs, &types.KeyValue{key, types.Encode(transferlog)})
 	return kvs
 }
 
+func (lott *Lottery) deleteLotteryTransfer(transferlog *pty.ReceiptLotteryTransfer) (kvs []*types.KeyValue) {
+	key := calcLotteryTransferKey(lott.localPrefix(), transferlog.LotteryId, transferlog.Index)
+	kvs = append(kvs, &types.KeyValue{key, nil})
+	return kvs
+}
+
+//配置修改记录只在saveConfigHistory 打开时保存, 回滚时删除
+func (lott *Lottery) saveLotteryConfigChange(change *pty.LotteryConfigChange) (kvs []*types.KeyValue) {
+	if !cfg.SaveConfigHistory {
+		return nil
+	}
+	key := calcLotteryConfigKey(lott.localPrefix(), change.LotteryId, change.Height, change.Index)
+	kvs = append(kvs, &types.KeyValue{key, types.Encode(change)})
+	return kvs
+}
+
+func (lott *Lottery) deleteLotteryConfigChange(change *pty.LotteryConfigChange) (kvs []*types.KeyValue) {
+	if !cfg.SaveConfigHistory {
+		return nil
+	}
+	key := calcLotteryConfigKey(lott.localPrefix(), change.LotteryId, change.Height, change.Index)
+	kvs = append(kvs, &types.KeyValue{key, nil})
+	return kvs
+}
+
+func modifyConfigChange(modifylog *pty.ReceiptLotteryModify) *pty.LotteryConfigChange {
+	return &pty.LotteryConfigChange{
+		LotteryId: modifylog.LotteryId,
+		Height:    modifylog.Index / types.MaxTxsPerBlock,
+		Index:     modifylog.Index,
+		Round:     modifylog.Round,
+		Addr:      modifylog.Addr,
+		ActionTy:  pty.LotteryActionModify,
+		Fields:    []*pty.LotteryConfigField{{Name: "drawers", Remove: modifylog.RemoveDrawers, Add: modifylog.AddDrawers}},
+		Time:      modifylog.Time,
+		TxHash:    modifylog.TxHash,
+	}
+}
+
+func transferConfigChange(transferlog *pty.ReceiptLotteryTransfer) *pty.LotteryConfigChange {
+	return &pty.LotteryConfigChange{
+		LotteryId: transferlog.LotteryId,
+		Height:    transferlog.Index / types.MaxTxsPerBlock,
+		Index:     transferlog.Index,
+		Round:     transferlog.Round,
+		Addr:      transferlog.PrevAdmin,
+		ActionTy:  pty.LotteryActionTransfer,
+		Fields:    []*pty.LotteryConfigField{{Name: "admin", Remove: []string{transferlog.PrevAdmin}, Add: []string{transferlog.Admin}}},
+		Time:      transferlog.Time,
+		TxHash:    transferlog.TxHash,
+	}
+}
+
+func blacklistConfigChange(blacklistlog *pty.ReceiptLotteryBlacklist) *pty.LotteryConfigChange {
+	return &pty.LotteryConfigChange{
+		LotteryId: blacklistlog.LotteryId,
+		Height:    blacklistlog.Index / types.MaxTxsPerBlock,
+		Index:     blacklistlog.Index,
+		Round:     blacklistlog.Round,
+		Addr:      blacklistlog.Addr,
+		ActionTy:  pty.LotteryActionBlacklist,
+		Fields:    []*pty.LotteryConfigField{{Name: "blacklist", Remove: blacklistlog.Remove, Add: blacklistlog.Add}},
+		Time:      blacklistlog.Time,
+		TxHash:    blacklistlog.TxHash,
+	}
+}
+
+//开奖时标记本轮所有的购买记录, 中奖的记录写入奖级, 回滚时恢复为未开奖
+//旧的购买记录没有轮次索引, 只能更新回执中的中奖记录
 func (lott *Lottery) updateLotteryBuy(lotterylog *pty.ReceiptLottery, isAdd bool) (kvs []*types.KeyValue) {
+	wins := make(map[string]int64)
 	if lotterylog.UpdateInfo != nil {
-		llog.Debug("updateLotteryBuy")
-		buyInfo := lotterylog.UpdateInfo.BuyInfo
-		//sort for map
-		addrkeys := make([]string, len(buyInfo))
-		i := 0
-
-		for addr := range buyInfo {
-			addrkeys[i] = addr
-			i++
-		}
-		sort.Strings(addrkeys)
-		//update old record
-		for _, addr := range addrkeys {
-			for _, updateRec := range buyInfo[addr].Records {
-				//find addr, index
-				key := calcLotteryBuyKey(lotterylog.LotteryId, addr, lotterylog.Round, updateRec.Index)
-				record, err := lott.findLotteryBuyRecord(key)
-				if err != nil || record == nil {
-					return kvs
-				}
-				kv := &types.KeyValue{}
-
-				if isAdd {
-					llog.Debug("updateLotteryBuy update key")
-					record.Type = updateRec.Type
-				} else {
-					record.Type = 0
-				}
-
-				kv = &types.KeyValue{key, types.Encode(record)}
-				kvs = append(kvs, kv)
+		for addr, recs := range lotterylog.UpdateInfo.BuyInfo {
+			for _, updateRec := range recs.Records {
+				key := calcLotteryBuyKey(lott.localPrefix(), lotterylog.LotteryId, addr, lotterylog.Round, updateRec.Index)
+				wins[string(key)] = updateRec.Type
+			}
+		}
+	}
+	buyKeys := make(map[string]bool)
+	for key := range wins {
+		buyKeys[key] = true
+	}
+	for _, key := range lott.findLotteryRoundBuyKeys(lotterylog.LotteryId, lotterylog.Round) {
+		buyKeys[key] = true
+	}
+	//sort for map
+	keys := make([]string, 0, len(buyKeys))
+	for key := range buyKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record, err := lott.findLotteryBuyRecord([]byte(key))
+		if err != nil || record == nil {
+			continue
+		}
+		if isAdd {
+			record.Type = wins[key]
+			record.Result = pty.LotteryBuyLost
+			if record.Type > 0 {
+				record.Result = pty.LotteryBuyWon
 			}
+		} else {
+			record.Type = 0
+			record.Result = pty.LotteryBuyPending
 		}
+		kvs = append(kvs, &types.KeyValue{Key: []byte(key), Value: types.Encode(record)})
+	}
+	return kvs
+}
+
+//本轮所有购买记录的key
+func (lott *Lottery) findLotteryRoundBuyKeys(lotteryId string, round int64) []string {
+	prefix := calcLotteryRoundBuyPrefix(lott.localPrefix(), lotteryId, round)
+	count := lott.GetLocalDB().PrefixCount(prefix)
+	if count == 0 {
+		return nil
+	}
+	values, err := lott.GetLocalDB().List(prefix, nil, int32(count), ListASC)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		keys = append(keys, string(value))
+	}
+	return keys
+}
+
+//揭示之后更新盲选购买记录的号码, 回滚时恢复为未揭示
+func (lott *Lottery) updateLotteryReveal(lotterylog *pty.ReceiptLottery, isAdd bool) (kvs []*types.KeyValue) {
+	key := calcLotteryBuyKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Addr, lotterylog.Round, lotterylog.Index)
+	record, err := lott.findLotteryBuyRecord(key)
+	if err != nil || record == nil {
 		return kvs
 	}
+	if isAdd {
+		record.Number = lotterylog.Number
+		record.Revealed = true
+	} else {
+		record.Number = 0
+		record.Revealed = false
+	}
+	kvs = append(kvs, &types.KeyValue{key, types.Encode(record)})
+	kvs = append(kvs, lott.updateNumberHeat(lotterylog.LotteryId, lotterylog.Round, lotterylog.Number, lotterylog.Amount, isAdd))
 	return kvs
 }
 
+//同一个区块中可能有多笔购买同一个号码, 读写都经过localdb 的缓存, 回滚到0时删除key
+func (lott *Lottery) updateNumberHeat(lotteryId string, round int64, number int64, amount int64, isAdd bool) *types.KeyValue {
+	key := calcLotteryHeatKey(lott.localPrefix(), lotteryId, round, number)
+	heat := &pty.LotteryNumberHeat{Number: number}
+	if data, err := lott.GetLocalDB().Get(key); err == nil {
+		types.Decode(data, heat)
+	}
+	if isAdd {
+		heat.Purchases++
+		heat.Amount += amount
+	} else {
+		heat.Purchases--
+		heat.Amount -= amount
+	}
+	if heat.Purchases <= 0 {
+		return lott.setLocal(key, nil)
+	}
+	return lott.setLocal(key, types.Encode(heat))
+}
+
 func (lott *Lottery) saveLotteryDraw(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
-	key := calcLotteryDrawKey(lotterylog.LotteryId, lotterylog.Round)
+	key := calcLotteryDrawKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round)
 	kv := &types.KeyValue{}
 	record := &pty.LotteryDrawRecord{lotterylog.LuckyNumber, lotterylog.Round, lotterylog.Time, lotterylog.TxHash}
 	kv = &types.KeyValue{key, types.Encode(record)}
 	kvs = append(kvs, kv)
+	kvs = append(kvs, lott.saveLotteryWinners(lotterylog)...)
+	if lotterylog.DrawProof != nil {
+		key := calcLotteryDrawProofKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round)
+		kvs = append(kvs, &types.KeyValue{Key: key, Value: types.Encode(lotterylog.DrawProof)})
+	}
 	return kvs
 }
 
 func (lott *Lottery) deleteLotteryDraw(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
-	key := calcLotteryDrawKey(lotterylog.LotteryId, lotterylog.Round)
+	key := calcLotteryDrawKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round)
 	kv := &types.KeyValue{key, nil}
 	kvs = append(kvs, kv)
+	kvs = append(kvs, lott.deleteLotteryWinners(lotterylog)...)
+	if lotterylog.DrawProof != nil {
+		kvs = append(kvs, &types.KeyValue{Key: calcLotteryDrawProofKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round), Value: nil})
+	}
+	return kvs
+}
+
+//每一轮的中奖记录, 来自开奖回执中的updateInfo
+func (lott *Lottery) saveLotteryWinners(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
+	if lotterylog.UpdateInfo == nil {
+		return kvs
+	}
+	buyInfo := lotterylog.UpdateInfo.BuyInfo
+	for _, addr := range sortedAddrs(buyInfo) {
+		for _, rec := range buyInfo[addr].Records {
+			key := calcLotteryWinnerKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round, addr, rec.Index)
+			record := &pty.LotteryWinnerRecord{
+				Addr:   addr,
+				Round:  lotterylog.Round,
+				Index:  rec.Index,
+				Level:  rec.Type,
+				Amount: rec.Amount,
+			}
+			kvs = append(kvs, &types.KeyValue{Key: key, Value: types.Encode(record)})
+		}
+	}
+	return kvs
+}
+
+func (lott *Lottery) deleteLotteryWinners(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
+	if lotterylog.UpdateInfo == nil {
+		return kvs
+	}
+	buyInfo := lotterylog.UpdateInfo.BuyInfo
+	for _, addr := range sortedAddrs(buyInfo) {
+		for _, rec := range buyInfo[addr].Records {
+			key := calcLotteryWinnerKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round, addr, rec.Index)
+			kvs = append(kvs, &types.KeyValue{Key: key, Value: nil})
+		}
+	}
+	return kvs
+}
+
+func (lott *Lottery) findLotteryRoundWinners(lotteryId string, round int64) (*pty.ReplyLotteryRoundWinners, error) {
+	reply := &pty.ReplyLotteryRoundWinners{Round: round}
+	prefix := calcLotteryWinnerRoundPrefix(lott.localPrefix(), lotteryId, round)
+	count := lott.GetLocalDB().PrefixCount(prefix)
+	if count == 0 {
+		return reply, nil
+	}
+	values, err := lott.GetLocalDB().List(prefix, nil, int32(count), ListASC)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	for _, value := range values {
+		var record pty.LotteryWinnerRecord
+		err := types.Decode(value, &record)
+		if err != nil {
+			continue
+		}
+		reply.Records = append(reply.Records, &record)
+		reply.TotalPayout += record.Amount
+	}
+	return reply, nil
+}
+
+//最近一次开奖的轮次信息, 还没有开奖时返回nil
+func (lott *Lottery) findLastDrawRound(lotteryId string) (*pty.LotteryRoundInfo, error) {
+	values, err := lott.GetLocalDB().List(calcLotteryDrawPrefix(lott.localPrefix(), lotteryId), nil, 1, ListDESC)
+	if err == types.ErrNotFound || len(values) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var draw pty.LotteryDrawRecord
+	if err := types.Decode(values[0], &draw); err != nil {
+		return nil, err
+	}
+	value, err := lott.GetLocalDB().Get(calcLotteryRoundKey(lott.localPrefix(), lotteryId, draw.Round))
+	if err != nil {
+		//没有轮次信息的旧彩票, 只返回开奖号码
+		return &pty.LotteryRoundInfo{Round: draw.Round, Status: pty.LotteryDrawed, LuckyNumber: draw.Number, Time: draw.Time, TxHash: draw.TxHash}, nil
+	}
+	var info pty.LotteryRoundInfo
+	if err := types.Decode(value, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+//地址在一轮中的购买记录来自localdb, 购买数量在进行中的轮次从状态数据中读取, 中奖金额来自中奖记录
+func (lott *Lottery) findAddrRoundInfo(lottery *LotteryDB, addr string, round int64, inRound bool) (*pty.LotteryAddrRoundInfo, error) {
+	info := &pty.LotteryAddrRoundInfo{Addr: addr, Round: round}
+	records, err := lott.findLotteryBuyRecords(calcLotteryBuyRoundPrefix(lott.localPrefix(), lottery.LotteryId, addr, round))
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	if records != nil {
+		info.Records = records.Records
+	}
+	for _, record := range info.Records {
+		info.TicketNum++
+		info.Amount += record.Amount
+		if record.Result == pty.LotteryBuyPending {
+			info.PendingNum++
+		}
+	}
+	if inRound {
+		if purchase, ok := lottery.Records[addr]; ok {
+			info.Amount = purchase.AmountOneRound
+		}
+	}
+	winners, err := lott.findLotteryRoundWinners(lottery.LotteryId, round)
+	if err != nil {
+		return nil, err
+	}
+	for _, winner := range winners.Records {
+		if winner.Addr == addr {
+			info.Winnings += winner.Amount
+		}
+	}
+	return info, nil
+}
+
+//本轮参与开奖的购买号码, 地址从购买记录的key 中取出. 没有揭示的盲选号码开奖时已经退款, 不参与开奖
+func (lott *Lottery) findLotteryRoundEntries(lotteryId string, round int64) []DrawEntry {
+	var entries []DrawEntry
+	prefix := string(calcLotteryBuyPrefix(lott.localPrefix(), lotteryId, ""))
+	for _, key := range lott.findLotteryRoundBuyKeys(lotteryId, round) {
+		record, err := lott.findLotteryBuyRecord([]byte(key))
+		if err != nil || record == nil {
+			continue
+		}
+		if len(record.CommitHash) > 0 && !record.Revealed {
+			continue
+		}
+		addr := strings.SplitN(strings.TrimPrefix(key, prefix), ":", 2)[0]
+		entries = append(entries, DrawEntry{Addr: addr, Index: record.Index, Number: record.Number, Way: record.Way, Amount: record.Amount})
+	}
+	return entries
+}
+
+//用轮次信息中记录的seed 重新选出中奖号码, 和localdb 中的中奖记录比较地址, 购买序号和奖级
+func (lott *Lottery) verifyLotteryDraw(lotteryId string, round int64) (*pty.ReplyLotteryVerifyDraw, error) {
+	value, err := lott.GetLocalDB().Get(calcLotteryRoundKey(lott.localPrefix(), lotteryId, round))
+	if err != nil {
+		return nil, err
+	}
+	var info pty.LotteryRoundInfo
+	if err := types.Decode(value, &info); err != nil {
+		return nil, err
+	}
+	if len(info.Seed) == 0 {
+		return nil, pty.ErrLotteryDrawSeed
+	}
+	if info.AlgoVersion != pty.LotteryDrawAlgoVersion {
+		llog.Error("verifyLotteryDraw", "algoVersion", info.AlgoVersion)
+		return nil, pty.ErrLotteryAlgoVersion
+	}
+	reply := &pty.ReplyLotteryVerifyDraw{
+		LotteryId:   lotteryId,
+		Round:       round,
+		Seed:        info.Seed,
+		AlgoVersion: info.AlgoVersion,
+		LuckyNumber: LuckyNumFromSeed(info.Seed),
+	}
+	winners, err := lott.findLotteryRoundWinners(lotteryId, round)
+	if err != nil {
+		return nil, err
+	}
+	recorded := make(map[string]*pty.LotteryWinnerRecord)
+	for _, record := range winners.Records {
+		recorded[fmt.Sprintf("%s:%d", record.Addr, record.Index)] = record
+	}
+	lottery, err := findLottery(lott.GetStateDB(), lotteryId)
+	if err != nil {
+		return nil, err
+	}
+	expected := SelectWinners(lott.findLotteryRoundEntries(lotteryId, round), info.Seed, 0, lottery.Weighted)
+	for _, winner := range expected {
+		key := fmt.Sprintf("%s:%d", winner.Addr, winner.Index)
+		if record, ok := recorded[key]; !ok || record.Level != winner.Level {
+			reply.Mismatches = append(reply.Mismatches, &pty.LotteryWinnerRecord{Addr: winner.Addr, Round: round, Index: winner.Index, Level: winner.Level})
+		}
+		delete(recorded, key)
+	}
+	//记录中多出来的中奖号码
+	for _, record := range winners.Records {
+		if _, ok := recorded[fmt.Sprintf("%s:%d", record.Addr, record.Index)]; ok {
+			reply.Mismatches = append(reply.Mismatches, record)
+		}
+	}
+	reply.ExpectedNum = int32(len(expected))
+	reply.RecordedNum = int32(len(winners.Records))
+	reply.Verified = reply.LuckyNumber == info.LuckyNumber && len(reply.Mismatches) == 0
+	return reply, nil
+}
+
+//每一轮的汇总信息, 开奖或者在购买期间关闭时写入
+func (lott *Lottery) saveLotteryRound(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
+	if !hasRoundInfo(lotterylog) {
+		return kvs
+	}
+	var payout int64
+	if lotterylog.UpdateInfo != nil {
+		for _, recs := range lotterylog.UpdateInfo.BuyInfo {
+			for _, rec := range recs.Records {
+				payout += rec.Amount
+			}
+		}
+	}
+	key := calcLotteryRoundKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round)
+	record := &pty.LotteryRoundInfo{
+		Round:       lotterylog.Round,
+		Status:      lotterylog.Status,
+		TotalSales:  lotterylog.Amount,
+		LuckyNumber: lotterylog.LuckyNumber,
+		TotalPayout: payout,
+		Time:        lotterylog.Time,
+		TxHash:      lotterylog.TxHash,
+		Rollover:    lotterylog.Rollover,
+	}
+	if lotterylog.DrawProof != nil {
+		record.Seed = lotterylog.DrawProof.Seed
+		record.AlgoVersion = lotterylog.DrawProof.AlgoVersion
+	}
+	kvs = append(kvs, &types.KeyValue{Key: key, Value: types.Encode(record)})
 	return kvs
 }
 
+//没有购买的轮次, 状态为LotteryRoundEmpty, 回滚时和普通轮次一样删除
+func (lott *Lottery) saveLotteryEmptyRound(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
+	key := calcLotteryRoundKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round)
+	record := &pty.LotteryRoundInfo{
+		Round:    lotterylog.Round,
+		Status:   pty.LotteryRoundEmpty,
+		Time:     lotterylog.Time,
+		TxHash:   lotterylog.TxHash,
+		Rollover: lotterylog.Rollover,
+	}
+	kvs = append(kvs, &types.KeyValue{Key: key, Value: types.Encode(record)})
+	return kvs
+}
+
+func (lott *Lottery) deleteLotteryRound(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
+	if !hasRoundInfo(lotterylog) {
+		return kvs
+	}
+	key := calcLotteryRoundKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Round)
+	kvs = append(kvs, &types.KeyValue{Key: key, Value: nil})
+	return kvs
+}
+
+//关闭之前没有进行中的轮次时, 关闭回执不产生轮次记录
+func hasRoundInfo(lotterylog *pty.ReceiptLottery) bool {
+	if lotterylog.Status != pty.LotteryClosed {
+		return true
+	}
+	return lotterylog.PrevStatus == pty.LotteryPurchase || lotterylog.PrevStatus == pty.LotteryCommitted
+}
+
+func sortedAddrs(buyInfo map[string]*pty.LotteryUpdateRecs) []string {
+	addrkeys := make([]string, 0, len(buyInfo))
+	for addr := range buyInfo {
+		addrkeys = append(addrkeys, addr)
+	}
+	sort.Strings(addrkeys)
+	return addrkeys
+}
+
+//状态变化时把彩票从原来状态的索引移到新的状态下, 删除和添加在同一个LocalDBSet 中
+//一笔交易有多次状态变化时(开奖之后自动关闭)依次处理, 最后只保留在最终的状态下
 func (lott *Lottery) saveLottery(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
 	if lotterylog.PrevStatus > 0 {
-		kv := dellottery(lotterylog.LotteryId, lotterylog.PrevStatus)
+		kv := dellottery(lott.localPrefix(), lotterylog, lotterylog.PrevStatus)
 		kvs = append(kvs, kv)
+		kvs = append(kvs, lott.delLegacyLottery(lotterylog.LotteryId, lotterylog.PrevStatus)...)
 	}
-	kvs = append(kvs, addlottery(lotterylog.LotteryId, lotterylog.Status))
+	kvs = append(kvs, addlottery(lott.localPrefix(), lotterylog, lotterylog.Status))
 	return kvs
 }
 
 func (lott *Lottery) deleteLottery(lotterylog *pty.ReceiptLottery) (kvs []*types.KeyValue) {
+	//升级之前的收据没有创建高度, 执行时写入的是旧的索引, 按旧的格式回滚
+	if lotterylog.CreateHeight == 0 && lott.hasLegacyIndex() {
+		kvs = append(kvs, &types.KeyValue{Key: calcLotteryLegacyStatusKey(lotterylog.Status, lotterylog.LotteryId), Value: nil})
+		if lotterylog.PrevStatus > 0 {
+			kv := &types.KeyValue{Key: calcLotteryLegacyStatusKey(lotterylog.PrevStatus, lotterylog.LotteryId), Value: []byte(lotterylog.LotteryId)}
+			kvs = append(kvs, kv)
+		}
+		return kvs
+	}
+	kvs = append(kvs, dellottery(lott.localPrefix(), lotterylog, lotterylog.Status))
+	kvs = append(kvs, lott.delLegacyLottery(lotterylog.LotteryId, lotterylog.Status)...)
 	if lotterylog.PrevStatus > 0 {
-		kv := addlottery(lotterylog.LotteryId, lotterylog.PrevStatus)
+		kv := addlottery(lott.localPrefix(), lotterylog, lotterylog.PrevStatus)
 		kvs = append(kvs, kv)
 	}
-	kvs = append(kvs, dellottery(lotterylog.LotteryId, lotterylog.Status))
 	return kvs
 }
 
-func addlottery(lotteryId string, status int32) *types.KeyValue {
+//升级之前默认的lottery 执行器写入的状态索引, 见calcLotteryLegacyStatusKey
+func (lott *Lottery) hasLegacyIndex() bool {
+	return lott.localPrefix() == calcLocalPrefix(pty.LotteryX)
+}
+
+//彩票离开这个状态时, 如果还有旧格式的索引就一起删除
+func (lott *Lottery) delLegacyLottery(lotteryId string, status int32) []*types.KeyValue {
+	if !lott.hasLegacyIndex() {
+		return nil
+	}
+	key := calcLotteryLegacyStatusKey(status, lotteryId)
+	if _, err := lott.GetLocalDB().Get(key); err != nil {
+		return nil
+	}
+	return []*types.KeyValue{{Key: key, Value: nil}}
+}
+
+//索引中只保存不会变化的字段, 其他字段查询时从状态数据中读取
+func addlottery(prefix string, lotterylog *pty.ReceiptLottery, status int32) *types.KeyValue {
 	kv := &types.KeyValue{}
-	kv.Key = calcLotteryKey(lotteryId, status)
-	kv.Value = []byte(lotteryId)
+	kv.Key = calcLotteryStatusKey(prefix, status, lotterylog.CreateHeight, lotterylog.LotteryId)
+	kv.Value = types.Encode(&pty.LotteryListItem{LotteryId: lotterylog.LotteryId, CreateHeight: lotterylog.CreateHeight})
 	return kv
 }
 
-func dellottery(lotteryId string, status int32) *types.KeyValue {
+func dellottery(prefix string, lotterylog *pty.ReceiptLottery, status int32) *types.KeyValue {
 	kv := &types.KeyValue{}
-	kv.Key = calcLotteryKey(lotteryId, status)
+	kv.Key = calcLotteryStatusKey(prefix, status, lotterylog.CreateHeight, lotterylog.LotteryId)
 	kv.Value = nil
 	return kv
 }
 
+//统计数据在同一个区块中会被多笔交易更新, 写入localdb 的缓存让后面的交易读到最新的值
+func (lott *Lottery) setLocal(key []byte, value []byte) *types.KeyValue {
+	lott.GetLocalDB().Set(key, value)
+	return &types.KeyValue{Key: key, Value: value}
+}
+
+func (lott *Lottery) findLocalInt64(key []byte) int64 {
+	var value types.Int64
+	if data, err := lott.GetLocalDB().Get(key); err == nil {
+		types.Decode(data, &value)
+	}
+	return value.Data
+}
+
+//累加localdb 中的计数, 同一个区块中后面的交易能读到前面交易的结果
+func (lott *Lottery) addLocalInt64(key []byte, delta int64) *types.KeyValue {
+	return lott.setLocalCounter(key, &types.Int64{Data: lott.findLocalInt64(key) + delta}, nil)
+}
+
+//计数回到初始值时删除key, 回滚之后localdb 和执行之前完全相同, 而不是留下一个全部为0的记录
+func (lott *Lottery) setLocalCounter(key []byte, counter types.Message, initial types.Message) *types.KeyValue {
+	value := types.Encode(counter)
+	var zero []byte
+	if initial != nil {
+		zero = types.Encode(initial)
+	}
+	if bytes.Equal(value, zero) {
+		return lott.setLocal(key, nil)
+	}
+	return lott.setLocal(key, value)
+}
+
+func (lott *Lottery) findLotteryStats(lotteryId string) *pty.LotteryStats {
+	stats := &pty.LotteryStats{LotteryId: lotteryId}
+	value, err := lott.GetLocalDB().Get(calcLotteryStatsKey(lott.localPrefix(), lotteryId))
+	if err != nil {
+		return stats
+	}
+	if err := types.Decode(value, stats); err != nil {
+		llog.Error("findLotteryStats", "lotteryId", lotteryId, "decode", err)
+	}
+	return stats
+}
+
+func (lott *Lottery) saveLotteryStats(stats *pty.LotteryStats) *types.KeyValue {
+	key := calcLotteryStatsKey(lott.localPrefix(), stats.LotteryId)
+	return lott.setLocalCounter(key, stats, &pty.LotteryStats{LotteryId: stats.LotteryId})
+}
+
+//购买时累计号码数量和金额, 地址第一次购买时增加购买地址数量, 回滚时相反
+func (lott *Lottery) updateLotteryStatsBuy(lotterylog *pty.ReceiptLottery, isAdd bool) (kvs []*types.KeyValue) {
+	var sign int64 = 1
+	if !isAdd {
+		sign = -1
+	}
+	stats := lott.findLotteryStats(lotterylog.LotteryId)
+	for _, item := range buyItems(lotterylog) {
+		stats.TicketsSold += sign
+		stats.TotalAmount += sign * item.Amount
+	}
+
+	buyerKey := calcLotteryStatsBuyerKey(lott.localPrefix(), lotterylog.LotteryId, lotterylog.Addr)
+	var buyTxs types.Int64
+	if value, err := lott.GetLocalDB().Get(buyerKey); err == nil {
+		types.Decode(value, &buyTxs)
+	}
+	if isAdd && buyTxs.Data == 0 {
+		stats.UniqueBuyers++
+	}
+	buyTxs.Data += sign
+	if !isAdd && buyTxs.Data == 0 {
+		stats.UniqueBuyers--
+	}
+	kvs = append(kvs, lott.setLocalCounter(buyerKey, &buyTxs, nil))
+	kvs = append(kvs, lott.saveLotteryStats(stats))
+	return kvs
+}
+
+//开奖时累计轮数和奖金, 奖金和轮次信息一样来自回执中的updateInfo
+func (lott *Lottery) updateLotteryStatsDraw(lotterylog *pty.ReceiptLottery, isAdd bool) (kvs []*types.KeyValue) {
+	var sign int64 = 1
+	if !isAdd {
+		sign = -1
+	}
+	stats := lott.findLotteryStats(lotterylog.LotteryId)
+	stats.Rounds += sign
+	if lotterylog.UpdateInfo != nil {
+		for _, recs := range lotterylog.UpdateInfo.BuyInfo {
+			for _, rec := range recs.Records {
+				stats.TotalPayout += sign * rec.Amount
+			}
+		}
+	}
+	kvs = append(kvs, lott.saveLotteryStats(stats))
+	return kvs
+}
+
+//开奖时累计每个中奖地址的奖金, 回滚时扣除
+func (lott *Lottery) updateLotteryAddrWon(lotterylog *pty.ReceiptLottery, isAdd bool) (kvs []*types.KeyValue) {
+	if lotterylog.UpdateInfo == nil {
+		return kvs
+	}
+	buyInfo := lotterylog.UpdateInfo.BuyInfo
+	for _, addr := range sortedAddrs(buyInfo) {
+		var won int64
+		for _, rec := range buyInfo[addr].Records {
+			won += rec.Amount
+		}
+		if !isAdd {
+			won = -won
+		}
+		kvs = append(kvs, lott.addLocalInt64(calcLotteryAddrWonKey(lott.localPrefix(), lotterylog.LotteryId, addr), won))
+	}
+	kvs = append(kvs, lott.updateLeaderboard(lotterylog.LotteryId, pty.LotteryBoardWinnings, lotterylog.TxHash, sortedAddrs(buyInfo), isAdd)...)
+	return kvs
+}
+
+//排行榜的分数就是地址的累计计数, 更新时先更新计数再更新排行榜
+func (lott *Lottery) findBoardScore(lotteryId string, metric int32, addr string) int64 {
+	if metric == pty.LotteryBoardWinnings {
+		return lott.findLocalInt64(calcLotteryAddrWonKey(lott.localPrefix(), lotteryId, addr))
+	}
+	return lott.findLocalInt64(calcLotteryAddrSpentKey(lott.localPrefix(), lotteryId, addr))
+}
+
+func (lott *Lottery) findLeaderboard(key []byte) *pty.LotteryLeaderboard {
+	var board pty.LotteryLeaderboard
+	if data, err := lott.GetLocalDB().Get(key); err == nil {
+		types.Decode(data, &board)
+	}
+	return &board
+}
+
+//分数从高到低, 分数相同时按地址排序, 保证每个节点的排行榜一致
+func sortBoardEntries(entries []*pty.LotteryBoardEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Addr < entries[j].Addr
+	})
+}
+
+//updateLeaderboard 地址的计数变化之后更新排行榜
+//排行榜只保存前maxBoardSize 个地址, 被挤出的地址保存在按交易索引的undo 记录中.
+//回滚时把原来的地址和被挤出的地址按回滚后的计数重新排序, 因为地址只会因为被挤出而离开排行榜,
+//这两部分包含了交易之前排行榜中的全部地址, 重新取前maxBoardSize 个就是交易之前的排行榜
+func (lott *Lottery) updateLeaderboard(lotteryId string, metric int32, txHash string, addrs []string, isAdd bool) (kvs []*types.KeyValue) {
+	boardKey := calcLotteryBoardKey(lott.localPrefix(), lotteryId, metric)
+	undoKey := calcLotteryBoardUndoKey(lott.localPrefix(), lotteryId, metric, txHash)
+	candidates := lott.findLeaderboard(boardKey).Entries
+	prevBoard := make(map[string]bool)
+	inBoard := make(map[string]bool)
+	for _, entry := range candidates {
+		prevBoard[entry.Addr] = true
+		inBoard[entry.Addr] = true
+	}
+	var undo []*pty.LotteryBoardEntry
+	if isAdd {
+		for _, addr := range addrs {
+			if !inBoard[addr] {
+				candidates = append(candidates, &pty.LotteryBoardEntry{Addr: addr})
+				inBoard[addr] = true
+			}
+		}
+	} else {
+		undo = lott.findLeaderboard(undoKey).Entries
+		for _, entry := range undo {
+			if !inBoard[entry.Addr] {
+				candidates = append(candidates, entry)
+				inBoard[entry.Addr] = true
+			}
+		}
+	}
+
+	changed := make(map[string]bool)
+	for _, addr := range addrs {
+		changed[addr] = true
+	}
+	entries := make([]*pty.LotteryBoardEntry, 0, len(candidates))
+	for _, entry := range candidates {
+		if changed[entry.Addr] {
+			entry = &pty.LotteryBoardEntry{Addr: entry.Addr, Score: lott.findBoardScore(lotteryId, metric, entry.Addr)}
+		}
+		if entry.Score > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	sortBoardEntries(entries)
+
+	var evicted []*pty.LotteryBoardEntry
+	if len(entries) > maxBoardSize {
+		for _, entry := range entries[maxBoardSize:] {
+			//交易之前不在排行榜中的地址没有被挤出
+			if prevBoard[entry.Addr] {
+				evicted = append(evicted, entry)
+			}
+		}
+		entries = entries[:maxBoardSize]
+	}
+	kvs = append(kvs, lott.setLocalCounter(boardKey, &pty.LotteryLeaderboard{Entries: entries}, nil))
+	if len(undo) > 0 {
+		kvs = append(kvs, lott.setLocal(undoKey, nil))
+	}
+	if isAdd && len(evicted) > 0 {
+		kvs = append(kvs, lott.setLocal(undoKey, types.Encode(&pty.LotteryLeaderboard{Entries: evicted})))
+	}
+	return kvs
+}
+
+func (lott *Lottery) updateLotteryStatsReclaim(reclaimlog *pty.ReceiptLotteryReclaim, isAdd bool) (kvs []*types.KeyValue) {
+	stats := lott.findLotteryStats(reclaimlog.LotteryId)
+	if isAdd {
+		stats.TotalReclaim += reclaimlog.Amount
+	} else {
+		stats.TotalReclaim -= reclaimlog.Amount
+	}
+	kvs = append(kvs, lott.saveLotteryStats(stats))
+	return kvs
+}
+
+//关闭和开奖前未揭示的退款
+func (lott *Lottery) updateLotteryStatsRefund(refundlog *pty.ReceiptLotteryRefund, isAdd bool) (kvs []*types.KeyValue) {
+	stats := lott.findLotteryStats(refundlog.LotteryId)
+	if isAdd {
+		stats.TotalRefund += refundlog.Amount
+	} else {
+		stats.TotalRefund -= refundlog.Amount
+	}
+	kvs = append(kvs, lott.saveLotteryStats(stats))
+	return kvs
+}
+
 func (lott *Lottery) GetPayloadValue() types.Message {
 	return &pty.LotteryAction{}
 }
@@ -0,0 +1,3267 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	tickettypes "github.com/33cn/plugin/plugin/dapp/ticket/types"
+	tokenty "github.com/33cn/plugin/plugin/dapp/token/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	PrivKeyA = "0x6da92a632ab7deb67d38c0f6560bcfed28167998f6496db64c258d5e8393a81b" // 1KSBd17H7ZK8iT37aJztFB22XGwsPTdwE4
+	PrivKeyB = "0x19c069234f9d3e61135fefbeb7791b149cdf6af536f26bebb310d4cd22c3fee4" // 1JRNjdEqp4LJ5fqycUBm9ayCKSeeskgMKR
+	PrivKeyC = "0x7a80a1f75d7360c6123c32a78ecf978c1ac55636f87892df38d8b85a9aeff115" // 1NLHPEcbTWWxxU3dGUZBhayjrCHD3psX7k
+	PrivKeyD = "0xcacb1f5d51700aea07fca2246ab43b0917d70405c65edea9b5063d72eb5c6b71" // 1MCftFynyvG2F4ED5mdHYgziDxx6vDrScs
+
+	testCreator = "1NLHPEcbTWWxxU3dGUZBhayjrCHD3psX7k"
+	testThird   = "1MCftFynyvG2F4ED5mdHYgziDxx6vDrScs"
+	testBalance = int64(10000 * decimal)
+)
+
+//local 的fork 高度都为0, 测试中的交易按升级之后的规则执行
+func init() {
+	types.Init("local", nil)
+}
+
+//执行环境: 状态数据库中有彩票创建者权限和各地址在合约中的余额, 开奖需要的区块由mock api 返回
+type execEnv struct {
+	t         *testing.T
+	l         *Lottery
+	stateDB   dbm.KV
+	height    int64
+	blocktime int64
+	//执行成功的交易和对应的localdb 修改, 用于检查回滚
+	history []*execRecord
+}
+
+type execRecord struct {
+	tx      *types.Transaction
+	receipt *types.ReceiptData
+	local   *types.LocalDBSet
+}
+
+//和执行器中的StateDB 一样, 找不到数据时返回types.ErrNotFound
+type testStateDB struct {
+	dbm.KV
+}
+
+func (db *testStateDB) Get(key []byte) ([]byte, error) {
+	value, err := db.KV.Get(key)
+	if err == dbm.ErrNotFoundInDb {
+		return nil, types.ErrNotFound
+	}
+	return value, err
+}
+
+func newExecEnv(t *testing.T) *execEnv {
+	l := newTestLottery(t)
+	db, err := dbm.NewGoMemDB("lotterystate", "lotterystate", 128)
+	assert.Nil(t, err)
+	stateDB := &testStateDB{dbm.NewKVDB(db)}
+	l.SetStateDB(stateDB)
+
+	item := &types.ConfigItem{
+		Key:   creatorKey,
+		Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: []string{testCreator}}},
+	}
+	assert.Nil(t, stateDB.Set([]byte(types.ManageKey(creatorKey)), types.Encode(item)))
+	coins := account.NewCoinsAccount()
+	coins.SetDB(stateDB)
+	execaddr := address.ExecAddress(pty.LotteryX)
+	for _, addr := range []string{testBuyer, testOther, testThird, testCreator} {
+		coins.SaveExecAccount(execaddr, &types.Account{Addr: addr, Balance: testBalance})
+	}
+
+	miner := &tickettypes.TicketAction{
+		Ty:    tickettypes.TicketActionMiner,
+		Value: &tickettypes.TicketAction_Miner{Miner: &tickettypes.TicketMiner{Bits: 1, TicketId: "ticket", Modify: []byte("modify")}},
+	}
+	block := &types.Block{Height: 1, BlockTime: 1, Txs: []*types.Transaction{{Execer: []byte("ticket"), Payload: types.Encode(miner)}}}
+	api := new(mocks.QueueProtocolAPI)
+	api.On("GetBlocks", mock.Anything).Return(&types.BlockDetails{Items: []*types.BlockDetail{{Block: block}}}, nil)
+	api.On("GetBlockHash", mock.Anything).Return(&types.ReplyHash{Hash: []byte("blockhash")}, nil)
+	l.SetApi(api)
+	return &execEnv{t: t, l: l, stateDB: stateDB, height: 100, blocktime: 1539918074}
+}
+
+func signTx(tx *types.Transaction, hexPrivKey string) (*types.Transaction, error) {
+	signType := types.SECP256K1
+	c, err := crypto.New(types.GetSignName(pty.LotteryX, signType))
+	if err != nil {
+		return tx, err
+	}
+	bytes, err := common.FromHex(hexPrivKey)
+	if err != nil {
+		return tx, err
+	}
+	privKey, err := c.PrivKeyFromBytes(bytes)
+	if err != nil {
+		return tx, err
+	}
+	tx.Sign(int32(signType), privKey)
+	return tx, nil
+}
+
+//执行交易, 成功时把结果写入状态数据库和localdb
+func (env *execEnv) exec(tx *types.Transaction, priv string) (*types.Receipt, error) {
+	tx, err := signTx(tx, priv)
+	assert.Nil(env.t, err)
+	env.height++
+	env.blocktime++
+	env.l.SetEnv(env.height, env.blocktime, 0)
+	receipt, err := env.l.Exec(tx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range receipt.KV {
+		assert.Nil(env.t, env.stateDB.Set(kv.Key, kv.Value))
+	}
+	receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+	set, err := env.l.ExecLocal(tx, receiptData, 0)
+	assert.Nil(env.t, err)
+	setLocalKVs(env.t, env.l, set.KV)
+	env.history = append(env.history, &execRecord{tx: tx, receipt: receiptData, local: set})
+	return receipt, nil
+}
+
+func (env *execEnv) create(create *pty.LotteryCreateTx) (string, error) {
+	tx, err := pty.CreateRawLotteryCreateTx(create)
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, PrivKeyC)
+	if err != nil {
+		return "", err
+	}
+	return common.ToHex(tx.Hash()), nil
+}
+
+func (env *execEnv) buy(priv string, lotteryId string, amount int64, number int64) error {
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Amount: amount, Number: number, Way: FiveStar})
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, priv)
+	return err
+}
+
+//开奖前需要等待drawBlockNum 个区块
+func (env *execEnv) draw(lotteryId string) (*types.Receipt, error) {
+	lottery, err := findLottery(env.stateDB, lotteryId)
+	assert.Nil(env.t, err)
+	if height := lottery.LastTransToPurState + lottery.DrawBlockNum; height > env.height {
+		env.height = height
+	}
+	tx, err := pty.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	return env.exec(tx, PrivKeyC)
+}
+
+func (env *execEnv) lottery(lotteryId string) *pty.Lottery {
+	lottery, err := findLottery(env.stateDB, lotteryId)
+	assert.Nil(env.t, err)
+	return lottery
+}
+
+func TestLotteryPurchaseLimit(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, OpPurchaseLimit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), env.lottery(lotteryId).OpPurchaseLimit)
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 4, 1))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 6, 2))
+	//刚好达到上限之后不能再买
+	assert.Equal(t, pty.ErrLotteryPurchaseLimit, env.buy(PrivKeyA, lotteryId, 1, 3))
+	assert.Equal(t, pty.ErrLotteryPurchaseLimit, env.buy(PrivKeyB, lotteryId, 11, 3))
+	//上限按地址计算
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 10, 3))
+	assert.Equal(t, int64(20), env.lottery(lotteryId).Fund)
+
+	//开奖之后重新计数
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 4))
+	assert.Equal(t, int64(2), env.lottery(lotteryId).Round)
+	assert.Equal(t, pty.ErrLotteryPurchaseLimit, env.buy(PrivKeyA, lotteryId, 1, 5))
+}
+
+func TestLotteryPurchaseUnlimited(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, OpPurchaseLimit: -1})
+	assert.Equal(t, pty.ErrLotteryPurchaseLimit, err)
+
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	for i := int64(0); i < 5; i++ {
+		assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1000, i))
+	}
+}
+
+func (env *execEnv) execAccount(addr string) *types.Account {
+	coins := account.NewCoinsAccount()
+	coins.SetDB(env.stateDB)
+	return coins.LoadExecAccount(addr, address.ExecAddress(pty.LotteryX))
+}
+
+//奖池地址在合约中的余额
+func (env *execEnv) prizePool(lotteryId string) int64 {
+	msg, err := env.l.Query_GetPrizePool(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryPrizePool).Balance
+}
+
+func findLogs(receipt *types.Receipt, ty int32) []*types.ReceiptLog {
+	var logs []*types.ReceiptLog
+	for _, log := range receipt.Logs {
+		if log.Ty == ty {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+func TestLotteryCreatorFee(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CreatorFeeRatio: 10})
+	assert.Nil(t, err)
+	//每个尾数都买, 必然只有一张一星中奖
+	for i := int64(0); i < 10; i++ {
+		tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Amount: 10, Number: i, Way: OneStar})
+		assert.Nil(t, err)
+		_, err = env.exec(tx, PrivKeyA)
+		assert.Nil(t, err)
+	}
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+
+	//销售额100, 分成10, 奖池剩90; 中奖50超过奖池的一半, 按45发放
+	feeLogs := findLogs(receipt, pty.TyLogLotteryFee)
+	assert.Equal(t, 1, len(feeLogs))
+	var feeLog pty.ReceiptLotteryCreatorFee
+	assert.Nil(t, types.Decode(feeLogs[0].Log, &feeLog))
+	assert.Equal(t, testCreator, feeLog.Addr)
+	assert.Equal(t, int64(1), feeLog.Round)
+	assert.Equal(t, int64(10*decimal), feeLog.Fee)
+
+	creator := env.execAccount(testCreator)
+	assert.Equal(t, testBalance+10*decimal, creator.Balance)
+	assert.Equal(t, int64(0), creator.Frozen)
+	assert.Equal(t, int64(45*decimal), env.prizePool(lotteryId))
+	buyer := env.execAccount(testBuyer)
+	assert.Equal(t, testBalance-100*decimal+45*decimal, buyer.Balance)
+	assert.Equal(t, int64(45), env.lottery(lotteryId).Fund)
+}
+
+func TestLotteryCreatorFeeRatio(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CreatorFeeRatio: -1})
+	assert.Equal(t, pty.ErrLotteryCreatorFeeRatio, err)
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CreatorFeeRatio: maxFeeRatio + 1})
+	assert.Equal(t, pty.ErrLotteryCreatorFeeRatio, err)
+
+	//不设置分成时开奖没有分成日志
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 1))
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(findLogs(receipt, pty.TyLogLotteryFee)))
+	assert.Equal(t, testBalance, env.execAccount(testCreator).Balance)
+}
+
+//开奖号码只和本轮购买交易数量以及开奖高度有关, 在购买之前预先算出来
+func (env *execEnv) predictLuckyNum(txNum int64, drawBlockNum int64) int64 {
+	start := env.height + 1
+	lott := &LotteryDB{pty.Lottery{TotalPurchasedTxNum: txNum, LastTransToPurState: start}}
+	action := &Action{api: env.l.GetApi(), height: start + drawBlockNum + 1}
+	return action.findLuckyNum(false, lott)
+}
+
+func (env *execEnv) buyWay(priv string, lotteryId string, amount int64, number int64, way int64) {
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Amount: amount, Number: number, Way: way})
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, priv)
+	assert.Nil(env.t, err)
+}
+
+func TestLotteryPrizeRatio(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50, 30, 10}})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{50, 30, 10}, env.lottery(lotteryId).PrizeRatio)
+
+	lucky := env.predictLuckyNum(6, 40)
+	env.buyWay(PrivKeyA, lotteryId, 2, lucky, FiveStar)
+	env.buyWay(PrivKeyB, lotteryId, 6, lucky, FiveStar)
+	env.buyWay(PrivKeyA, lotteryId, 5, lucky, ThreeStar)
+	env.buyWay(PrivKeyB, lotteryId, 5, lucky, TwoStar)
+	//一星没有配置比例, 不算中奖
+	env.buyWay(PrivKeyA, lotteryId, 5, lucky, OneStar)
+	env.buyWay(PrivKeyB, lotteryId, 2, (lucky+1)%luckyNumMol, FiveStar)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+
+	//奖池25: 五星12按2:6分, 三星7, 二星2, 剩余4留在奖池
+	assert.Equal(t, testBalance-12*decimal+(3+7)*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance-13*decimal+(9+2)*decimal, env.execAccount(testOther).Balance)
+	assert.Equal(t, int64(4*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, int64(4), env.lottery(lotteryId).Fund)
+
+	msg, err := env.l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	winners := msg.(*pty.ReplyLotteryRoundWinners)
+	assert.Equal(t, 4, len(winners.Records))
+	assert.Equal(t, int64(21*decimal), winners.TotalPayout)
+	for _, record := range winners.Records {
+		assert.NotEqual(t, int64(OneStar), record.Level)
+	}
+}
+
+func TestLotteryPrizeRatioInvalid(t *testing.T) {
+	env := newExecEnv(t)
+	for _, ratio := range [][]int64{{50, 30, 21}, {-1, 50}, {10, 10, 10, 10, 10}} {
+		_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: ratio})
+		assert.Equal(t, pty.ErrLotteryPrizeRatio, err)
+	}
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{60, 30, 10}})
+	assert.Nil(t, err)
+}
+
+func (env *execEnv) close(lotteryId string) error {
+	tx, err := pty.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, PrivKeyC)
+	return err
+}
+
+func TestLotteryTransition(t *testing.T) {
+	allowed := map[int32][]int32{
+		pty.LotteryActionBuy:       {pty.LotteryCreated, pty.LotteryPurchase, pty.LotteryDrawed},
+		pty.LotteryActionDraw:      {pty.LotteryCreated, pty.LotteryPurchase, pty.LotteryDrawed, pty.LotteryCommitted},
+		pty.LotteryActionClose:     {pty.LotteryCreated, pty.LotteryPurchase, pty.LotteryDrawed, pty.LotteryCommitted},
+		pty.LotteryActionCommit:    {pty.LotteryPurchase},
+		pty.LotteryActionReveal:    {pty.LotteryCommitted},
+		pty.LotteryActionTransfer:  {pty.LotteryCreated, pty.LotteryPurchase, pty.LotteryDrawed, pty.LotteryCommitted},
+		pty.LotteryActionReclaim:   {pty.LotteryClosed},
+		pty.LotteryActionBlacklist: {pty.LotteryCreated, pty.LotteryPurchase, pty.LotteryDrawed, pty.LotteryCommitted},
+		pty.LotteryActionClaim:     {pty.LotteryPurchase, pty.LotteryDrawed, pty.LotteryClosed, pty.LotteryCommitted},
+	}
+	for actionTy, states := range allowed {
+		for status := int32(pty.LotteryCreated); status <= pty.LotteryCommitted; status++ {
+			expect := pty.ErrLotteryInvalidState
+			for _, s := range states {
+				if s == status {
+					expect = nil
+				}
+			}
+			assert.Equal(t, expect, checkLotteryTransition(status, int32(actionTy)), "action %d status %d", actionTy, status)
+		}
+	}
+}
+
+//ForkLotteryState 之前的区块按原来的规则执行: 只检查购买, 开奖和关闭, 错误为ErrLotteryStatus
+func TestLotteryLegacyTransition(t *testing.T) {
+	types.Init("chain33", nil)
+	defer types.Init("local", nil)
+	action := &Action{height: 100}
+	assert.Equal(t, pty.ErrLotteryStatus, action.checkTransition(pty.LotteryCreated, pty.LotteryActionDraw))
+	assert.Equal(t, pty.ErrLotteryStatus, action.checkTransition(pty.LotteryDrawed, pty.LotteryActionDraw))
+	assert.Equal(t, pty.ErrLotteryStatus, action.checkTransition(pty.LotteryClosed, pty.LotteryActionBuy))
+	assert.Equal(t, pty.ErrLotteryStatus, action.checkTransition(pty.LotteryClosed, pty.LotteryActionClose))
+	assert.Nil(t, action.checkTransition(pty.LotteryPurchase, pty.LotteryActionDraw))
+	assert.Nil(t, action.checkTransition(pty.LotteryDrawed, pty.LotteryActionBuy))
+	//新增的操作没有原来的规则
+	assert.Equal(t, pty.ErrLotteryInvalidState, action.checkTransition(pty.LotteryCreated, pty.LotteryActionCommit))
+
+	types.Init("local", nil)
+	assert.Nil(t, action.checkTransition(pty.LotteryCreated, pty.LotteryActionDraw))
+	assert.Equal(t, pty.ErrLotteryInvalidState, action.checkTransition(pty.LotteryClosed, pty.LotteryActionBuy))
+}
+
+func TestLotteryInvalidState(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	//没有购买的轮次要等到开奖高度才能跳过
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	//不能紧接着再开奖
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+
+	assert.Nil(t, env.close(lotteryId))
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+	//关闭之后不能购买, 开奖, 再次关闭
+	assert.Equal(t, pty.ErrLotteryInvalidState, env.buy(PrivKeyA, lotteryId, 1, 1))
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryInvalidState, err)
+	assert.Equal(t, pty.ErrLotteryInvalidState, env.close(lotteryId))
+}
+
+func (env *execEnv) buyItems(priv string, lotteryId string, items []*pty.LotteryBuyItem) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Items: items})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryBuyItems(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	//一笔交易买20个号码, 其中一个五星和一个三星中奖
+	lucky := env.predictLuckyNum(1, 40)
+	var items []*pty.LotteryBuyItem
+	for i := int64(0); i < 20; i++ {
+		items = append(items, &pty.LotteryBuyItem{Number: (lucky + 1 + i) % luckyNumMol, Amount: 1, Way: FiveStar})
+	}
+	items[3].Number = lucky
+	items[11] = &pty.LotteryBuyItem{Number: lucky, Amount: 2, Way: ThreeStar}
+	receipt, err := env.buyItems(PrivKeyA, lotteryId, items)
+	assert.Nil(t, err)
+	assert.Equal(t, testBalance-21*decimal, env.execAccount(testBuyer).Balance)
+
+	logs := findLogs(receipt, pty.TyLogLotteryBuy)
+	assert.Equal(t, 1, len(logs))
+	var buyLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(logs[0].Log, &buyLog))
+	assert.Equal(t, 20, len(buyLog.BuyItems))
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(21), lott.Fund)
+	assert.Equal(t, int64(1), lott.TotalPurchasedTxNum)
+	assert.Equal(t, 20, len(lott.Records[testBuyer].Record))
+
+	msg, err := env.l.Query_GetBuyRecordsByAddr(&pty.ReqLotteryBuyRecordsByAddr{LotteryId: lotteryId, Addr: testBuyer, Count: 100, Direction: ListASC})
+	assert.Nil(t, err)
+	records := msg.(*pty.ReplyLotteryBuyRecordsByAddr).Records
+	assert.Equal(t, 20, len(records))
+	for i, record := range records {
+		assert.Equal(t, items[i].Number, record.Number)
+		assert.Equal(t, buyLog.BuyItems[i].Index, record.Index)
+	}
+
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	msg, err = env.l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	winners := msg.(*pty.ReplyLotteryRoundWinners).Records
+	assert.Equal(t, 2, len(winners))
+	levels := make(map[int64]int64)
+	for _, winner := range winners {
+		levels[winner.Index] = winner.Level
+	}
+	assert.Equal(t, int64(FiveStar), levels[buyLog.BuyItems[3].Index])
+	assert.Equal(t, int64(ThreeStar), levels[buyLog.BuyItems[11].Index])
+}
+
+func TestLotteryBuyItemsInvalid(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	//任何一个号码不合法, 整笔交易失败
+	items := []*pty.LotteryBuyItem{{Number: 1, Amount: 1, Way: FiveStar}, {Number: luckyNumMol, Amount: 1, Way: FiveStar}}
+	_, err = env.buyItems(PrivKeyA, lotteryId, items)
+	assert.Equal(t, pty.ErrLotteryBuyNumber, err)
+	items[1] = &pty.LotteryBuyItem{Number: 2, Amount: 0, Way: FiveStar}
+	_, err = env.buyItems(PrivKeyA, lotteryId, items)
+	assert.Equal(t, pty.ErrLotteryBuyAmount, err)
+	items = make([]*pty.LotteryBuyItem, maxBuyItems+1)
+	for i := range items {
+		items[i] = &pty.LotteryBuyItem{Number: int64(i), Amount: 1, Way: FiveStar}
+	}
+	_, err = env.buyItems(PrivKeyA, lotteryId, items)
+	assert.Equal(t, pty.ErrLotteryBuyItems, err)
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, int32(pty.LotteryCreated), env.lottery(lotteryId).Status)
+
+	//旧的单个号码字段仍然有效
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Equal(t, 1, len(env.lottery(lotteryId).Records[testBuyer].Record))
+	assert.Equal(t, testBalance-3*decimal, env.execAccount(testBuyer).Balance)
+}
+
+func (env *execEnv) statusIndexed(lotteryId string, status int32) bool {
+	_, err := env.l.GetLocalDB().Get(calcLotteryStatusKey(env.l.localPrefix(), status, env.lottery(lotteryId).CreateHeight, lotteryId))
+	return err == nil
+}
+
+func TestLotteryMaxRounds(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MaxRounds: -1})
+	assert.Equal(t, pty.ErrLotteryMaxRounds, err)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MaxRounds: 2})
+	assert.Nil(t, err)
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+
+	//最后一轮开奖之后自动关闭
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 1, 2))
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int32(pty.LotteryClosed), lott.Status)
+	assert.Equal(t, int64(2), lott.Round)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryDraw)))
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryClose)))
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryClosed))
+	assert.False(t, env.statusIndexed(lotteryId, pty.LotteryDrawed))
+	msg, err := env.l.Query_GetRoundsInfo(&pty.ReqLotteryRoundsInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(msg.(*pty.ReplyLotteryRoundsInfo).Rounds))
+
+	//再多一轮不能购买, 也不能开奖
+	assert.Equal(t, pty.ErrLotteryInvalidState, env.buy(PrivKeyA, lotteryId, 1, 1))
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryInvalidState, err)
+
+	//回滚最后一轮开奖, 状态索引恢复为购买中
+	set, err := env.l.execDelLocal(nil, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs})
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryPurchase))
+	assert.False(t, env.statusIndexed(lotteryId, pty.LotteryDrawed))
+	assert.False(t, env.statusIndexed(lotteryId, pty.LotteryClosed))
+}
+
+func TestLotteryDrawBeyondMaxRounds(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+
+	//轮数已经超过限制的彩票不能再开奖
+	lott := &LotteryDB{*env.lottery(lotteryId)}
+	lott.MaxRounds = 1
+	lott.Round = 2
+	lott.Save(env.stateDB)
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryMaxRounds, err)
+}
+
+func TestLotteryCloseRefund(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 2))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 3))
+	assert.Nil(t, env.buy(PrivKeyD, lotteryId, 7, 4))
+	assert.Equal(t, int64(15*decimal), env.prizePool(lotteryId))
+
+	tx, err := pty.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	receipt, err := env.exec(tx, PrivKeyC)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance, env.execAccount(testOther).Balance)
+	assert.Equal(t, testBalance, env.execAccount(testThird).Balance)
+	assert.Equal(t, int64(0), env.prizePool(lotteryId))
+
+	refunds := make(map[string]int64)
+	for _, log := range findLogs(receipt, pty.TyLogLotteryRefund) {
+		var refund pty.ReceiptLotteryRefund
+		assert.Nil(t, types.Decode(log.Log, &refund))
+		assert.Equal(t, int64(1), refund.Round)
+		refunds[refund.Addr] = refund.Amount
+	}
+	assert.Equal(t, map[string]int64{testBuyer: 3, testOther: 5, testThird: 7}, refunds)
+
+	msg, err := env.l.Query_GetRefundRecords(&pty.ReqLotteryRefundRecords{LotteryId: lotteryId, Addr: testOther})
+	assert.Nil(t, err)
+	records := msg.(*pty.ReplyLotteryRefundRecords).Records
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, int64(5), records[0].Amount)
+	assert.Equal(t, common.ToHex(tx.Hash()), records[0].TxHash)
+
+	//不能重复关闭退款
+	assert.Equal(t, pty.ErrLotteryInvalidState, env.close(lotteryId))
+}
+
+func TestLotteryCloseRefundInBatches(t *testing.T) {
+	defer func(max int) { maxRefundsPerClose = max }(maxRefundsPerClose)
+	maxRefundsPerClose = 2
+
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 3))
+	assert.Nil(t, env.buy(PrivKeyD, lotteryId, 7, 4))
+
+	//第一次关闭只退款两个地址, 彩票处于关闭中, 不能再购买和开奖
+	assert.Nil(t, env.close(lotteryId))
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int32(pty.LotteryPurchase), lott.Status)
+	assert.True(t, lott.Closing)
+	assert.Equal(t, int64(7*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, pty.ErrLotteryInvalidState, env.buy(PrivKeyA, lotteryId, 1, 1))
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryInvalidState, err)
+
+	//第二次关闭只处理剩下的地址
+	tx, err := pty.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	receipt, err := env.exec(tx, PrivKeyC)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryRefund)))
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryClose)))
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+	for _, addr := range []string{testBuyer, testOther, testThird} {
+		assert.Equal(t, testBalance, env.execAccount(addr).Balance)
+	}
+	assert.Equal(t, int64(0), env.prizePool(lotteryId))
+
+	msg, err := env.l.Query_GetRoundsInfo(&pty.ReqLotteryRoundsInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	rounds := msg.(*pty.ReplyLotteryRoundsInfo).Rounds
+	assert.Equal(t, 1, len(rounds))
+	assert.Equal(t, int64(15), rounds[0].TotalSales)
+}
+
+//提交之前同样需要等待drawBlockNum 个区块
+func (env *execEnv) commit(priv string, lotteryId string, secret []byte) error {
+	lottery := env.lottery(lotteryId)
+	if height := lottery.LastTransToPurState + lottery.DrawBlockNum; height > env.height {
+		env.height = height
+	}
+	tx, err := pty.CreateRawLotteryCommitTx(&pty.LotteryCommitTx{LotteryId: lotteryId, Hash: common.ToHex(common.Sha256(secret))})
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, priv)
+	return err
+}
+
+func (env *execEnv) reveal(priv string, lotteryId string, secret []byte) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryRevealTx(&pty.LotteryRevealTx{LotteryId: lotteryId, Secret: common.ToHex(secret)})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryRevealParam(t *testing.T) {
+	env := newExecEnv(t)
+	for _, create := range []*pty.LotteryCreateTx{
+		{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: -1},
+		{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 1, RevealTimeout: 10},
+		{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 3},
+	} {
+		_, err := env.create(create)
+		assert.Equal(t, pty.ErrLotteryRevealParam, err)
+	}
+
+	//没有开启两步开奖的彩票不能提交
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	assert.Equal(t, pty.ErrLotteryCommitDisabled, env.commit(PrivKeyC, lotteryId, []byte("secret")))
+}
+
+func TestLotteryCommitReveal(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 10})
+	assert.Nil(t, err)
+
+	//mock 的区块hash 固定, 开奖号码只由secret 决定
+	secret := []byte("secret")
+	proof, err := (&Action{api: env.l.GetApi()}).revealDrawProof(&LotteryDB{}, secret)
+	assert.Nil(t, err)
+	lucky := proof.LuckyNumber
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, lucky))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 2, (lucky+1)%luckyNumMol))
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryCommitRequired, err)
+
+	assert.Nil(t, env.commit(PrivKeyC, lotteryId, secret))
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int32(pty.LotteryCommitted), lott.Status)
+	assert.Equal(t, testCreator, lott.CommitAddr)
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryCommitted))
+	assert.Equal(t, pty.ErrLotteryInvalidState, env.buy(PrivKeyA, lotteryId, 1, 1))
+
+	//等待的区块数不够
+	_, err = env.reveal(PrivKeyC, lotteryId, secret)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+	env.height += 3
+	_, err = env.reveal(PrivKeyC, lotteryId, []byte("wrong"))
+	assert.Equal(t, pty.ErrLotteryRevealHash, err)
+	_, err = env.reveal(PrivKeyA, lotteryId, secret)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+	receipt, err := env.reveal(PrivKeyC, lotteryId, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryDraw)))
+
+	lott = env.lottery(lotteryId)
+	assert.Equal(t, int32(pty.LotteryDrawed), lott.Status)
+	assert.Equal(t, lucky, lott.LuckyNumber)
+	assert.Nil(t, lott.CommitHash)
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryDrawed))
+	assert.False(t, env.statusIndexed(lotteryId, pty.LotteryCommitted))
+	//奖池4, 中奖超过一半按比例分配
+	assert.Equal(t, testBalance-2*decimal+2*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance-2*decimal, env.execAccount(testOther).Balance)
+}
+
+func TestLotteryRevealTimeout(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 10})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	assert.Nil(t, env.commit(PrivKeyA, lotteryId, []byte("secret")))
+
+	//还没有超时不能开奖
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+	env.height += 10
+	_, err = env.reveal(PrivKeyA, lotteryId, []byte("secret"))
+	assert.Equal(t, pty.ErrLotteryRevealTimeout, err)
+
+	//超时之后使用原来的方式开奖
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryDraw)))
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+}
+
+func TestLotteryRevealTimeoutRefund(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 10, TimeoutRefund: true})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 2))
+	assert.Nil(t, env.commit(PrivKeyA, lotteryId, []byte("secret")))
+
+	//超时之后关闭彩票并退款
+	env.height += 11
+	tx, err := pty.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	receipt, err := env.exec(tx, PrivKeyB)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(findLogs(receipt, pty.TyLogLotteryRefund)))
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance, env.execAccount(testOther).Balance)
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryClosed))
+	assert.False(t, env.statusIndexed(lotteryId, pty.LotteryCommitted))
+}
+
+func TestLotteryRollover(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50, 30}})
+	assert.Nil(t, err)
+
+	//第一轮没有人中头奖, 头奖5滚入下一轮
+	lucky := env.predictLuckyNum(1, 40)
+	env.buyWay(PrivKeyA, lotteryId, 10, (lucky+1)%luckyNumMol, FiveStar)
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	var drawLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(findLogs(receipt, pty.TyLogLotteryDraw)[0].Log, &drawLog))
+	assert.Equal(t, int64(5), drawLog.Rollover)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(5), lott.RolloverPool)
+	assert.Equal(t, int64(10), lott.Fund)
+
+	//第二轮的头奖为 (14-5)*50% + 5
+	lucky = env.predictLuckyNum(1, 40)
+	env.buyWay(PrivKeyB, lotteryId, 4, lucky, FiveStar)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	lott = env.lottery(lotteryId)
+	assert.Equal(t, lucky, lott.LuckyNumber)
+	assert.Equal(t, int64(0), lott.RolloverPool)
+	assert.Equal(t, int64(5), lott.Fund)
+	assert.Equal(t, testBalance-4*decimal+9*decimal, env.execAccount(testOther).Balance)
+
+	msg, err := env.l.Query_GetRoundsInfo(&pty.ReqLotteryRoundsInfo{LotteryId: lotteryId, Direction: ListASC})
+	assert.Nil(t, err)
+	rounds := msg.(*pty.ReplyLotteryRoundsInfo).Rounds
+	assert.Equal(t, 2, len(rounds))
+	assert.Equal(t, int64(5), rounds[0].Rollover)
+	assert.Equal(t, int64(0), rounds[1].Rollover)
+	assert.Equal(t, int64(9*decimal), rounds[1].TotalPayout)
+}
+
+func TestLotteryCloseRollover(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50}})
+	assert.Nil(t, err)
+	lucky := env.predictLuckyNum(1, 40)
+	env.buyWay(PrivKeyD, lotteryId, 10, (lucky+1)%luckyNumMol, FiveStar)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+
+	//开奖之后关闭, 累积的头奖退还给创建者
+	creator := env.execAccount(testCreator)
+	pool := env.prizePool(lotteryId)
+	tx, err := pty.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	receipt, err := env.exec(tx, PrivKeyC)
+	assert.Nil(t, err)
+	var closeLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(findLogs(receipt, pty.TyLogLotteryClose)[0].Log, &closeLog))
+	assert.Equal(t, int64(5), closeLog.Rollover)
+	assert.Equal(t, creator.Balance+5*decimal, env.execAccount(testCreator).Balance)
+	assert.Equal(t, pool-5*decimal, env.prizePool(lotteryId))
+	assert.Equal(t, int64(0), env.lottery(lotteryId).RolloverPool)
+}
+
+func TestLotteryCloseRolloverToBuyers(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50}, RolloverToBuyers: true})
+	assert.Nil(t, err)
+	lucky := env.predictLuckyNum(1, 40)
+	env.buyWay(PrivKeyD, lotteryId, 10, (lucky+1)%luckyNumMol, FiveStar)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+
+	//购买中关闭, 累积的头奖5按3:7分给购买者, 余下的1退还给创建者
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 7, 2))
+	creator := env.execAccount(testCreator)
+	pool := env.prizePool(lotteryId)
+	tx, err := pty.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	receipt, err := env.exec(tx, PrivKeyC)
+	assert.Nil(t, err)
+	assert.Equal(t, testBalance+1*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance+3*decimal, env.execAccount(testOther).Balance)
+	assert.Equal(t, creator.Balance+1*decimal, env.execAccount(testCreator).Balance)
+	assert.Equal(t, pool-15*decimal, env.prizePool(lotteryId))
+
+	shares := make(map[string]int64)
+	for _, log := range findLogs(receipt, pty.TyLogLotteryRefund) {
+		var refund pty.ReceiptLotteryRefund
+		assert.Nil(t, types.Decode(log.Log, &refund))
+		shares[refund.Addr] = refund.Rollover
+	}
+	assert.Equal(t, map[string]int64{testBuyer: 1, testOther: 3}, shares)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(0), lott.RolloverPool)
+	assert.Equal(t, int64(5), lott.Fund)
+}
+
+//模拟token 执行器: 保存已经创建的token 信息, 并给各地址在彩票合约中充值
+func (env *execEnv) setupToken(symbol string) {
+	token := &tokenty.Token{Symbol: symbol, Owner: testCreator, Status: tokenty.TokenStatusCreated}
+	assert.Nil(env.t, env.stateDB.Set([]byte(tokenKeyPrefix+symbol), types.Encode(token)))
+	accDB, err := account.NewAccountDB(tokenX, symbol, env.stateDB)
+	assert.Nil(env.t, err)
+	execaddr := address.ExecAddress(pty.LotteryX)
+	for _, addr := range []string{testBuyer, testOther, testThird, testCreator} {
+		accDB.SaveExecAccount(execaddr, &types.Account{Addr: addr, Balance: testBalance})
+	}
+}
+
+func (env *execEnv) tokenAccount(symbol string, addr string) *types.Account {
+	accDB, err := account.NewAccountDB(tokenX, symbol, env.stateDB)
+	assert.Nil(env.t, err)
+	return accDB.LoadExecAccount(addr, address.ExecAddress(pty.LotteryX))
+}
+
+func TestLotteryTokenNotExist(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, TokenSymbol: "TEST"})
+	assert.Equal(t, pty.ErrLotteryTokenNotExist, err)
+
+	//token 还在预创建状态
+	token := &tokenty.Token{Symbol: "TEST", Status: tokenty.TokenStatusPreCreated}
+	assert.Nil(t, env.stateDB.Set([]byte(tokenKeyPrefix+"TEST"), types.Encode(token)))
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, TokenSymbol: "TEST"})
+	assert.Equal(t, pty.ErrLotteryTokenNotExist, err)
+}
+
+func TestLotteryToken(t *testing.T) {
+	env := newExecEnv(t)
+	env.setupToken("TEST")
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, TokenSymbol: "TEST"})
+	assert.Nil(t, err)
+	assert.Equal(t, "TEST", env.lottery(lotteryId).TokenSymbol)
+	msg, err := env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, "TEST", msg.(*pty.ReplyLotteryNormalInfo).TokenSymbol)
+
+	lucky := env.predictLuckyNum(2, 40)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, lucky))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 6, (lucky+1)%luckyNumMol))
+	assert.Equal(t, testBalance-2*decimal, env.tokenAccount("TEST", testBuyer).Balance)
+	assert.Equal(t, int64(8*decimal), env.prizePool(lotteryId))
+	//coins 不受影响
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, int64(0), env.execAccount(testCreator).Frozen)
+
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	//五星中奖超过奖池的一半, 按奖池的一半发放
+	assert.Equal(t, testBalance+2*decimal, env.tokenAccount("TEST", testBuyer).Balance)
+	assert.Equal(t, int64(4*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+
+	msg, err = env.l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	winners := msg.(*pty.ReplyLotteryRoundWinners)
+	assert.Equal(t, "TEST", winners.TokenSymbol)
+	assert.Equal(t, int64(4*decimal), winners.TotalPayout)
+	msg, err = env.l.Query_GetRoundsInfo(&pty.ReqLotteryRoundsInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, "TEST", msg.(*pty.ReplyLotteryRoundsInfo).TokenSymbol)
+}
+
+func TestLotteryTokenCloseRefund(t *testing.T) {
+	env := newExecEnv(t)
+	env.setupToken("TEST")
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, TokenSymbol: "TEST"})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 2))
+	assert.Equal(t, int64(8*decimal), env.prizePool(lotteryId))
+
+	assert.Nil(t, env.close(lotteryId))
+	assert.Equal(t, testBalance, env.tokenAccount("TEST", testBuyer).Balance)
+	assert.Equal(t, testBalance, env.tokenAccount("TEST", testOther).Balance)
+	assert.Equal(t, int64(0), env.prizePool(lotteryId))
+
+	msg, err := env.l.Query_GetRefundRecords(&pty.ReqLotteryRefundRecords{LotteryId: lotteryId, Addr: testOther})
+	assert.Nil(t, err)
+	records := msg.(*pty.ReplyLotteryRefundRecords)
+	assert.Equal(t, "TEST", records.TokenSymbol)
+	assert.Equal(t, 1, len(records.Records))
+	assert.Equal(t, int64(5), records.Records[0].Amount)
+}
+
+//模拟平行链上跨链过来的主链资产: paracross 执行器中各地址在彩票合约中的余额
+func (env *execEnv) setupParaAsset(symbol string) *account.DB {
+	accDB, err := account.NewAccountDB(paraX, symbol, env.stateDB)
+	assert.Nil(env.t, err)
+	execaddr := address.ExecAddress(pty.LotteryX)
+	for _, addr := range []string{testBuyer, testOther, testThird, testCreator} {
+		accDB.SaveExecAccount(execaddr, &types.Account{Addr: addr, Balance: testBalance})
+	}
+	return accDB
+}
+
+func TestLotteryAssetExec(t *testing.T) {
+	env := newExecEnv(t)
+	env.setupToken("TEST")
+	for _, create := range []*pty.LotteryCreateTx{
+		{AssetExec: "coins", TokenSymbol: "bty"},
+		{AssetExec: tokenX},
+		{AssetExec: paraX},
+		{AssetExec: paraX, TokenSymbol: "bty"},
+		{AssetExec: paraX, TokenSymbol: "coins."},
+		{AssetExec: paraX, TokenSymbol: "trade.TEST"},
+	} {
+		create.PurBlockNum, create.DrawBlockNum = 30, 40
+		_, err := env.create(create)
+		assert.Equal(t, pty.ErrLotteryAssetExec, err, create.AssetExec+" "+create.TokenSymbol)
+	}
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, AssetExec: tokenX, TokenSymbol: "NONE"})
+	assert.Equal(t, pty.ErrLotteryTokenNotExist, err)
+
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, AssetExec: tokenX, TokenSymbol: "TEST"})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	assert.Equal(t, testBalance-2*decimal, env.tokenAccount("TEST", testBuyer).Balance)
+}
+
+func TestLotteryParaAsset(t *testing.T) {
+	env := newExecEnv(t)
+	accDB := env.setupParaAsset("coins.bty")
+	execaddr := address.ExecAddress(pty.LotteryX)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, AssetExec: paraX, TokenSymbol: "coins.bty"})
+	assert.Nil(t, err)
+	msg, err := env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, paraX, msg.(*pty.ReplyLotteryNormalInfo).AssetExec)
+	assert.Equal(t, "coins.bty", msg.(*pty.ReplyLotteryNormalInfo).TokenSymbol)
+
+	lucky := env.predictLuckyNum(2, 40)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, lucky))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 6, (lucky+1)%luckyNumMol))
+	assert.Equal(t, testBalance-2*decimal, accDB.LoadExecAccount(testBuyer, execaddr).Balance)
+	assert.Equal(t, testBalance-6*decimal, accDB.LoadExecAccount(testOther, execaddr).Balance)
+	assert.Equal(t, int64(8*decimal), env.prizePool(lotteryId))
+	//本链的coins 不受影响
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	assert.Equal(t, testBalance+2*decimal, accDB.LoadExecAccount(testBuyer, execaddr).Balance)
+	assert.Equal(t, int64(4*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+
+	//关闭时按跨链资产退款
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 3, 1))
+	assert.Nil(t, env.close(lotteryId))
+	assert.Equal(t, testBalance-6*decimal, accDB.LoadExecAccount(testOther, execaddr).Balance)
+}
+
+func TestLotteryPrizePool(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CreatorFeeRatio: 10})
+	assert.Nil(t, err)
+	otherId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, escrowAddress(lotteryId), env.lottery(lotteryId).EscrowAddr)
+	assert.NotEqual(t, env.lottery(lotteryId).EscrowAddr, env.lottery(otherId).EscrowAddr)
+
+	for i := int64(0); i < 10; i++ {
+		env.buyWay(PrivKeyA, lotteryId, 10, i, OneStar)
+	}
+	assert.Nil(t, env.buy(PrivKeyB, otherId, 7, 1))
+	assert.Equal(t, int64(100*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, int64(7*decimal), env.prizePool(otherId))
+	//资金不再经过创建者的账户
+	assert.Equal(t, int64(0), env.execAccount(testCreator).Frozen)
+
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	payouts := env.execAccount(testBuyer).Balance - (testBalance - 100*decimal)
+	payouts += env.execAccount(testCreator).Balance - testBalance
+	assert.True(t, payouts > 0)
+	assert.Equal(t, 100*decimal-payouts, env.prizePool(lotteryId))
+	assert.Equal(t, int64(7*decimal), env.prizePool(otherId))
+
+	msg, err := env.l.Query_GetPrizePool(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	pool := msg.(*pty.ReplyLotteryPrizePool)
+	assert.Equal(t, lotteryId, pool.LotteryId)
+	assert.Equal(t, escrowAddress(lotteryId), pool.EscrowAddr)
+}
+
+//没有奖池地址的旧彩票, 资金仍然冻结在创建者的合约账户中
+func TestLotteryLegacyPool(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	lott := &LotteryDB{*env.lottery(lotteryId)}
+	lott.EscrowAddr = ""
+	lott.Save(env.stateDB)
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 2))
+	assert.Equal(t, int64(8*decimal), env.execAccount(testCreator).Frozen)
+	assert.Equal(t, int64(8*decimal), env.prizePool(lotteryId))
+
+	assert.Nil(t, env.close(lotteryId))
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance, env.execAccount(testOther).Balance)
+	assert.Equal(t, int64(0), env.execAccount(testCreator).Frozen)
+}
+
+func (env *execEnv) blindBuy(priv string, lotteryId string, amount int64, number int64, nonce []byte) error {
+	commitHash := common.ToHex(pty.CalcBuyCommitHash(number, nonce))
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Amount: amount, Way: FiveStar, CommitHash: commitHash})
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, priv)
+	return err
+}
+
+func (env *execEnv) revealNumber(priv string, lotteryId string, number int64, nonce []byte) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryRevealNumberTx(&pty.LotteryRevealNumberTx{LotteryId: lotteryId, Number: number, Nonce: common.ToHex(nonce)})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func (env *execEnv) buyRecords(lotteryId string, addr string, round int64) []*pty.LotteryBuyRecord {
+	records, err := env.l.findLotteryBuyRecords(calcLotteryBuyRoundPrefix(env.l.localPrefix(), lotteryId, addr, round))
+	assert.Nil(env.t, err)
+	return records.Records
+}
+
+func TestLotteryBlindBuyReveal(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	//揭示交易不计入购买交易数量, 不影响开奖号码
+	lucky := env.predictLuckyNum(2, 40)
+	nonce := []byte("nonce")
+	assert.Nil(t, env.blindBuy(PrivKeyA, lotteryId, 2, lucky, nonce))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 2, (lucky+1)%luckyNumMol))
+	records := env.buyRecords(lotteryId, testBuyer, 1)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, int64(0), records[0].Number)
+	assert.Equal(t, pty.CalcBuyCommitHash(lucky, nonce), records[0].CommitHash)
+	assert.False(t, records[0].Revealed)
+
+	receipt, err := env.revealNumber(PrivKeyA, lotteryId, lucky, nonce)
+	assert.Nil(t, err)
+	logs := findLogs(receipt, pty.TyLogLotteryRevealNumber)
+	assert.Equal(t, 1, len(logs))
+	var revealLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(logs[0].Log, &revealLog))
+	assert.Equal(t, lucky, revealLog.Number)
+	assert.Equal(t, testBuyer, revealLog.Addr)
+	records = env.buyRecords(lotteryId, testBuyer, 1)
+	assert.Equal(t, lucky, records[0].Number)
+	assert.True(t, records[0].Revealed)
+	rec := env.lottery(lotteryId).Records[testBuyer].Record[0]
+	assert.Equal(t, lucky, rec.Number)
+	assert.True(t, rec.Revealed)
+
+	receipt, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(findLogs(receipt, pty.TyLogLotteryRefund)))
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	//奖池4, 中奖超过一半按比例分配
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance-2*decimal, env.execAccount(testOther).Balance)
+	assert.Equal(t, int64(FiveStar), env.buyRecords(lotteryId, testBuyer, 1)[0].Type)
+
+	//回滚揭示之后localdb 中恢复为未揭示
+	set, err := env.l.ExecDelLocal_RevealNumber(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: []*types.ReceiptLog{logs[0]}}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	records = env.buyRecords(lotteryId, testBuyer, 1)
+	assert.Equal(t, int64(0), records[0].Number)
+	assert.False(t, records[0].Revealed)
+}
+
+func TestLotteryRevealNumberMismatch(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	//盲选购买不能同时指定号码
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Amount: 1, Number: 1, Way: FiveStar,
+		CommitHash: common.ToHex(pty.CalcBuyCommitHash(1, []byte("nonce")))})
+	assert.Nil(t, err)
+	_, err = env.exec(tx, PrivKeyA)
+	assert.Equal(t, pty.ErrLotteryBuyCommit, err)
+	tx, err = pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Amount: 1, Way: FiveStar, CommitHash: "0x1234"})
+	assert.Nil(t, err)
+	_, err = env.exec(tx, PrivKeyA)
+	assert.Equal(t, pty.ErrLotteryBuyCommit, err)
+
+	nonce := []byte("nonce")
+	assert.Nil(t, env.blindBuy(PrivKeyA, lotteryId, 1, 12345, nonce))
+	_, err = env.revealNumber(PrivKeyA, lotteryId, 12345, []byte("wrong"))
+	assert.Equal(t, pty.ErrLotteryRevealNumber, err)
+	_, err = env.revealNumber(PrivKeyA, lotteryId, 12346, nonce)
+	assert.Equal(t, pty.ErrLotteryRevealNumber, err)
+	//只有购买的地址可以揭示
+	_, err = env.revealNumber(PrivKeyB, lotteryId, 12345, nonce)
+	assert.Equal(t, pty.ErrLotteryRevealNumber, err)
+	_, err = env.revealNumber(PrivKeyA, lotteryId, luckyNumMol, nonce)
+	assert.Equal(t, pty.ErrLotteryBuyNumber, err)
+	assert.False(t, env.lottery(lotteryId).Records[testBuyer].Record[0].Revealed)
+
+	_, err = env.revealNumber(PrivKeyA, lotteryId, 12345, nonce)
+	assert.Nil(t, err)
+	//不能重复揭示
+	_, err = env.revealNumber(PrivKeyA, lotteryId, 12345, nonce)
+	assert.Equal(t, pty.ErrLotteryRevealNumber, err)
+}
+
+func TestLotteryUnrevealedRefund(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	lucky := env.predictLuckyNum(3, 40)
+	miss := (lucky + 1) % luckyNumMol
+	assert.Nil(t, env.blindBuy(PrivKeyA, lotteryId, 3, miss, []byte("nonce")))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, miss))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, miss))
+	assert.Equal(t, int64(10*decimal), env.prizePool(lotteryId))
+
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	refundLogs := findLogs(receipt, pty.TyLogLotteryRefund)
+	assert.Equal(t, 1, len(refundLogs))
+	var refund pty.ReceiptLotteryRefund
+	assert.Nil(t, types.Decode(refundLogs[0].Log, &refund))
+	assert.Equal(t, testBuyer, refund.Addr)
+	assert.Equal(t, int64(3), refund.Amount)
+
+	//没有揭示的部分不计入本轮销售额
+	var drawLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(findLogs(receipt, pty.TyLogLotteryDraw)[0].Log, &drawLog))
+	assert.Equal(t, int64(7), drawLog.Amount)
+	assert.Equal(t, lucky, drawLog.LuckyNumber)
+
+	assert.Equal(t, testBalance-2*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance-5*decimal, env.execAccount(testOther).Balance)
+	assert.Equal(t, int64(7*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, int64(7), env.lottery(lotteryId).Fund)
+
+	msg, err := env.l.Query_GetRefundRecords(&pty.ReqLotteryRefundRecords{LotteryId: lotteryId, Addr: testBuyer})
+	assert.Nil(t, err)
+	records := msg.(*pty.ReplyLotteryRefundRecords).Records
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, int64(3), records[0].Amount)
+}
+
+func (env *execEnv) list(status int32, count int32, direction int32, primaryKey string) *pty.ReplyLotteryList {
+	msg, err := env.l.Query_ListLotteries(&pty.ReqLotteryList{Status: status, Count: count, Direction: direction, PrimaryKey: primaryKey})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryList)
+}
+
+func listedIds(reply *pty.ReplyLotteryList) []string {
+	var ids []string
+	for _, item := range reply.Lotteries {
+		ids = append(ids, item.LotteryId)
+	}
+	return ids
+}
+
+func TestLotteryListByStatus(t *testing.T) {
+	env := newExecEnv(t)
+	var ids []string
+	for i := 0; i < 4; i++ {
+		lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+		assert.Nil(t, err)
+		ids = append(ids, lotteryId)
+	}
+	assert.Equal(t, ids, listedIds(env.list(pty.LotteryCreated, 0, ListASC, "")))
+
+	assert.Nil(t, env.buy(PrivKeyA, ids[0], 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, ids[0], 4, 2))
+	assert.Nil(t, env.buy(PrivKeyA, ids[1], 5, 1))
+	_, err := env.draw(ids[1])
+	assert.Nil(t, err)
+	assert.Nil(t, env.close(ids[2]))
+
+	assert.Equal(t, []string{ids[3]}, listedIds(env.list(pty.LotteryCreated, 0, ListASC, "")))
+	assert.Equal(t, []string{ids[1]}, listedIds(env.list(pty.LotteryDrawed, 0, ListASC, "")))
+	assert.Equal(t, []string{ids[2]}, listedIds(env.list(pty.LotteryClosed, 0, ListASC, "")))
+	purchase := env.list(pty.LotteryPurchase, 0, ListASC, "")
+	assert.Equal(t, []string{ids[0]}, listedIds(purchase))
+	item := purchase.Lotteries[0]
+	assert.Equal(t, testCreator, item.CreateAddr)
+	assert.Equal(t, int32(pty.LotteryPurchase), item.Status)
+	assert.Equal(t, int64(1), item.Round)
+	assert.Equal(t, int64(30), item.PurBlockNum)
+	assert.Equal(t, int64(40), item.DrawBlockNum)
+	assert.Equal(t, int64(7), item.RoundSales)
+	assert.Equal(t, int64(7), item.TotalSales)
+
+	//开奖之后的下一轮购买, 从开奖状态移到购买状态
+	assert.Nil(t, env.buy(PrivKeyB, ids[1], 2, 1))
+	assert.Equal(t, 0, len(env.list(pty.LotteryDrawed, 0, ListASC, "").Lotteries))
+	purchase = env.list(pty.LotteryPurchase, 0, ListDESC, "")
+	assert.Equal(t, []string{ids[1], ids[0]}, listedIds(purchase))
+	assert.Equal(t, int64(2), purchase.Lotteries[0].Round)
+	assert.Equal(t, int64(2), purchase.Lotteries[0].RoundSales)
+	assert.Equal(t, int64(7), purchase.Lotteries[0].TotalSales)
+
+	//分页
+	page := env.list(pty.LotteryPurchase, 1, ListASC, "")
+	assert.Equal(t, []string{ids[0]}, listedIds(page))
+	assert.NotEqual(t, "", page.PrimaryKey)
+	page = env.list(pty.LotteryPurchase, 1, ListASC, page.PrimaryKey)
+	assert.Equal(t, []string{ids[1]}, listedIds(page))
+	page = env.list(pty.LotteryPurchase, 1, ListASC, page.PrimaryKey)
+	assert.Equal(t, 0, len(page.Lotteries))
+	assert.Equal(t, "", page.PrimaryKey)
+
+	_, err = env.l.Query_ListLotteries(&pty.ReqLotteryList{Status: 0})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = env.l.Query_ListLotteries(&pty.ReqLotteryList{Status: pty.LotteryPurchase, PrimaryKey: string(calcLotteryStatusPrefix(env.l.localPrefix(), pty.LotteryClosed))})
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
+
+//开奖之后自动关闭时, 一笔交易中的两次状态变化在同一个LocalDBSet 中处理, 回滚时回到购买状态
+func TestLotteryListDrawRollback(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MaxRounds: 1})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 4, 2))
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{lotteryId}, listedIds(env.list(pty.LotteryClosed, 0, ListASC, "")))
+	assert.Equal(t, 0, len(env.list(pty.LotteryDrawed, 0, ListASC, "").Lotteries))
+	assert.Equal(t, 0, len(env.list(pty.LotteryPurchase, 0, ListASC, "").Lotteries))
+
+	set, err := env.l.ExecDelLocal_Draw(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, 0, len(env.list(pty.LotteryClosed, 0, ListASC, "").Lotteries))
+	assert.Equal(t, 0, len(env.list(pty.LotteryDrawed, 0, ListASC, "").Lotteries))
+	assert.Equal(t, []string{lotteryId}, listedIds(env.list(pty.LotteryPurchase, 0, ListASC, "")))
+}
+
+//升级之前写入的状态索引: 查询时排在新的索引之后, 状态变化时删除, 按旧的收据回滚时恢复旧的格式
+func TestLotteryListLegacyIndex(t *testing.T) {
+	env := newExecEnv(t)
+	var ids []string
+	for i := 0; i < 2; i++ {
+		lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+		assert.Nil(t, err)
+		ids = append(ids, lotteryId)
+	}
+	legacyKey := calcLotteryLegacyStatusKey(pty.LotteryCreated, ids[0])
+	setLocalKVs(t, env.l, []*types.KeyValue{
+		{Key: calcLotteryStatusKey(env.l.localPrefix(), pty.LotteryCreated, env.lottery(ids[0]).CreateHeight, ids[0]), Value: nil},
+		{Key: legacyKey, Value: []byte(ids[0])},
+	})
+	assert.Equal(t, []string{ids[1], ids[0]}, listedIds(env.list(pty.LotteryCreated, 0, ListDESC, "")))
+	assert.Equal(t, []string{ids[0], ids[1]}, listedIds(env.list(pty.LotteryCreated, 0, ListASC, "")))
+	//分页时从新的索引接着取旧的索引
+	page := env.list(pty.LotteryCreated, 1, ListDESC, "")
+	assert.Equal(t, []string{ids[1]}, listedIds(page))
+	page = env.list(pty.LotteryCreated, 1, ListDESC, page.PrimaryKey)
+	assert.Equal(t, []string{ids[0]}, listedIds(page))
+	assert.Equal(t, string(legacyKey), page.PrimaryKey)
+	page = env.list(pty.LotteryCreated, 1, ListDESC, page.PrimaryKey)
+	assert.Equal(t, 0, len(page.Lotteries))
+
+	assert.Nil(t, env.buy(PrivKeyA, ids[0], 3, 1))
+	_, err := env.l.GetLocalDB().Get(legacyKey)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{ids[1]}, listedIds(env.list(pty.LotteryCreated, 0, ListASC, "")))
+	assert.Equal(t, []string{ids[0]}, listedIds(env.list(pty.LotteryPurchase, 0, ListASC, "")))
+
+	//升级之前的收据没有创建高度, 回滚时删除和恢复的都是旧的索引
+	kvs := env.l.deleteLottery(&pty.ReceiptLottery{LotteryId: ids[1], Status: pty.LotteryPurchase, PrevStatus: pty.LotteryCreated})
+	assert.Equal(t, []*types.KeyValue{
+		{Key: calcLotteryLegacyStatusKey(pty.LotteryPurchase, ids[1]), Value: nil},
+		{Key: calcLotteryLegacyStatusKey(pty.LotteryCreated, ids[1]), Value: []byte(ids[1])},
+	}, kvs)
+}
+
+func (env *execEnv) buyByTxHash(lotteryId string, txHash string) (*pty.ReplyLotteryBuyByTxHash, error) {
+	msg, err := env.l.Query_GetBuyByTxHash(&pty.ReqLotteryBuyByTxHash{LotteryId: lotteryId, TxHash: txHash})
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*pty.ReplyLotteryBuyByTxHash), nil
+}
+
+func TestLotteryBuyByTxHash(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	lucky := env.predictLuckyNum(2, 40)
+	items := []*pty.LotteryBuyItem{{Number: lucky, Amount: 2, Way: FiveStar}, {Number: (lucky + 1) % luckyNumMol, Amount: 3, Way: FiveStar}}
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Items: items})
+	assert.Nil(t, err)
+	buyReceipt, err := env.exec(tx, PrivKeyA)
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, (lucky+2)%luckyNumMol))
+	txHash := common.ToHex(tx.Hash())
+
+	reply, err := env.buyByTxHash(lotteryId, txHash)
+	assert.Nil(t, err)
+	assert.Equal(t, testBuyer, reply.Addr)
+	assert.Equal(t, 2, len(reply.Records))
+	for i, entry := range reply.Records {
+		assert.Equal(t, items[i].Number, entry.Number)
+		assert.Equal(t, items[i].Amount, entry.Amount)
+		assert.Equal(t, int64(1), entry.Round)
+		assert.Equal(t, txHash, entry.TxHash)
+		assert.False(t, entry.Drawn)
+		assert.Equal(t, int64(0), entry.Type)
+	}
+	//没有0x前缀和大写的hash 同样可以查询
+	reply, err = env.buyByTxHash(lotteryId, strings.ToUpper(txHash[2:]))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(reply.Records))
+
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	reply, err = env.buyByTxHash(lotteryId, txHash)
+	assert.Nil(t, err)
+	assert.True(t, reply.Records[0].Drawn)
+	assert.Equal(t, int64(FiveStar), reply.Records[0].Type)
+	assert.True(t, reply.Records[1].Drawn)
+	assert.Equal(t, int64(0), reply.Records[1].Type)
+
+	//回滚购买之后索引被删除
+	set, err := env.l.ExecDelLocal_Buy(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: buyReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	_, err = env.buyByTxHash(lotteryId, txHash)
+	assert.NotNil(t, err)
+
+	_, err = env.buyByTxHash(lotteryId, "")
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = env.buyByTxHash(lotteryId, "0xzz")
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
+
+//在本轮开始之后第elapsed 个区块开奖
+func (env *execEnv) drawAt(priv string, lotteryId string, elapsed int64) (*types.Receipt, error) {
+	env.height = env.lottery(lotteryId).LastTransToPurState + elapsed - 1
+	tx, err := pty.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryDrawDeadlineParam(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, DrawDeadlineBlocks: -1})
+	assert.Equal(t, pty.ErrLotteryDrawDeadline, err)
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, DrawDeadlineBlocks: 39})
+	assert.Equal(t, pty.ErrLotteryDrawDeadline, err)
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, DrawDeadlineBlocks: 60, DrawRewardRatio: maxDrawReward + 1})
+	assert.Equal(t, pty.ErrLotteryDrawRewardRatio, err)
+	//没有开奖期限时不能设置奖励
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, DrawRewardRatio: 1})
+	assert.Equal(t, pty.ErrLotteryDrawRewardRatio, err)
+
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, DrawDeadlineBlocks: 60, DrawRewardRatio: 5})
+	assert.Nil(t, err)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(60), lott.DrawDeadlineBlocks)
+	assert.Equal(t, int64(5), lott.DrawRewardRatio)
+}
+
+func TestLotteryDrawDeadline(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, DrawDeadlineBlocks: 60, DrawRewardRatio: 5})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 100, 1))
+
+	//期限之前只有创建者和购买者可以开奖
+	_, err = env.drawAt(PrivKeyD, lotteryId, 40)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+	_, err = env.drawAt(PrivKeyD, lotteryId, 60)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+
+	receipt, err := env.drawAt(PrivKeyD, lotteryId, 61)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+	rewardLogs := findLogs(receipt, pty.TyLogLotteryDrawReward)
+	assert.Equal(t, 1, len(rewardLogs))
+	var rewardLog pty.ReceiptLotteryDrawReward
+	assert.Nil(t, types.Decode(rewardLogs[0].Log, &rewardLog))
+	assert.Equal(t, testThird, rewardLog.Addr)
+	assert.Equal(t, int64(1), rewardLog.Round)
+	assert.Equal(t, int64(5*decimal), rewardLog.Reward)
+	assert.Equal(t, testBalance+5*decimal, env.execAccount(testThird).Balance)
+
+	//创建者在期限之前开奖没有奖励
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 100, 1))
+	receipt, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), env.lottery(lotteryId).Round)
+	assert.Equal(t, 0, len(findLogs(receipt, pty.TyLogLotteryDrawReward)))
+}
+
+func TestLotteryDrawDeadlineSkipsCommit(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 10, DrawDeadlineBlocks: 60})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 1))
+
+	_, err = env.drawAt(PrivKeyD, lotteryId, 60)
+	assert.Equal(t, pty.ErrLotteryCommitRequired, err)
+	//创建者超过期限没有提交, 任何地址都可以直接开奖
+	receipt, err := env.drawAt(PrivKeyD, lotteryId, 61)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+	assert.Equal(t, 0, len(findLogs(receipt, pty.TyLogLotteryDrawReward)))
+}
+
+func (env *execEnv) stats(lotteryId string) *pty.LotteryStats {
+	msg, err := env.l.Query_GetLotteryStats(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	return msg.(*pty.LotteryStats)
+}
+
+func TestLotteryStats(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, &pty.LotteryStats{LotteryId: lotteryId}, env.stats(lotteryId))
+	_, err = env.l.Query_GetLotteryStats(&pty.ReqLotteryInfo{LotteryId: "0xnotexist"})
+	assert.Equal(t, types.ErrNotFound, err)
+
+	//第一轮: 每个尾数都买保证有奖金发放
+	for i := int64(0); i < 10; i++ {
+		env.buyWay(PrivKeyA, lotteryId, 10, i, OneStar)
+	}
+	_, err = env.buyItems(PrivKeyB, lotteryId, []*pty.LotteryBuyItem{{Number: 12345, Amount: 5, Way: FiveStar}, {Number: 3, Amount: 1, Way: OneStar}})
+	assert.Nil(t, err)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	winners, err := env.l.findLotteryRoundWinners(lotteryId, 1)
+	assert.Nil(t, err)
+	assert.True(t, winners.TotalPayout > 0)
+
+	//第二轮: 新地址购买之后关闭退款
+	_, err = env.buyItems(PrivKeyA, lotteryId, []*pty.LotteryBuyItem{{Number: 1, Amount: 2, Way: FiveStar}})
+	assert.Nil(t, err)
+	buyReceipt, err := env.buyItems(PrivKeyD, lotteryId, []*pty.LotteryBuyItem{{Number: 2, Amount: 7, Way: FiveStar}})
+	assert.Nil(t, err)
+	tx, err := pty.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	closeReceipt, err := env.exec(tx, PrivKeyC)
+	assert.Nil(t, err)
+
+	expected := &pty.LotteryStats{
+		LotteryId:    lotteryId,
+		TicketsSold:  14,
+		TotalAmount:  115,
+		TotalPayout:  winners.TotalPayout,
+		TotalRefund:  9,
+		Rounds:       1,
+		UniqueBuyers: 3,
+	}
+	assert.Equal(t, expected, env.stats(lotteryId))
+
+	//回滚关闭和新地址的购买
+	set, err := env.l.ExecDelLocal_Close(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: closeReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	expected.TotalRefund = 0
+	assert.Equal(t, expected, env.stats(lotteryId))
+
+	set, err = env.l.ExecDelLocal_Buy(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: buyReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	expected.TicketsSold = 13
+	expected.TotalAmount = 108
+	expected.UniqueBuyers = 2
+	assert.Equal(t, expected, env.stats(lotteryId))
+
+	//同一个地址再次购买不重复计数
+	set, err = env.l.ExecLocal_Buy(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: buyReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	expected.TicketsSold = 14
+	expected.TotalAmount = 115
+	expected.UniqueBuyers = 3
+	assert.Equal(t, expected, env.stats(lotteryId))
+}
+
+func TestLotteryStatsDrawRollback(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	for i := int64(0); i < 10; i++ {
+		env.buyWay(PrivKeyA, lotteryId, 10, i, OneStar)
+	}
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	stats := env.stats(lotteryId)
+	assert.Equal(t, int64(1), stats.Rounds)
+	assert.Equal(t, int64(50*decimal), stats.TotalPayout)
+	assert.Equal(t, int64(1), stats.UniqueBuyers)
+
+	set, err := env.l.ExecDelLocal_Draw(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	stats = env.stats(lotteryId)
+	assert.Equal(t, int64(0), stats.Rounds)
+	assert.Equal(t, int64(0), stats.TotalPayout)
+	assert.Equal(t, int64(10), stats.TicketsSold)
+	assert.Equal(t, int64(100), stats.TotalAmount)
+}
+
+//按地址查询的购买记录, 包含开奖结果
+func (env *execEnv) buyEntries(lotteryId string, addr string) []*pty.LotteryBuyEntry {
+	msg, err := env.l.Query_GetBuyRecordsByAddr(&pty.ReqLotteryBuyRecordsByAddr{LotteryId: lotteryId, Addr: addr, Direction: ListASC})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryBuyRecordsByAddr).Records
+}
+
+func TestLotteryBuyResult(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	//每个尾数都买, 必然有一张中奖, 其余没有中奖
+	for i := int64(0); i < 10; i++ {
+		env.buyWay(PrivKeyA, lotteryId, 1, i, OneStar)
+	}
+	buyReceipt, err := env.buyItems(PrivKeyB, lotteryId, []*pty.LotteryBuyItem{{Number: 3, Amount: 1, Way: OneStar}, {Number: 8, Amount: 1, Way: OneStar}})
+	assert.Nil(t, err)
+	for _, addr := range []string{testBuyer, testOther} {
+		for _, entry := range env.buyEntries(lotteryId, addr) {
+			assert.Equal(t, int32(pty.LotteryBuyPending), entry.Result)
+			assert.False(t, entry.Drawn)
+		}
+	}
+
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	lucky := env.lottery(lotteryId).LuckyNumber
+	var won int
+	for _, addr := range []string{testBuyer, testOther} {
+		for _, entry := range env.buyEntries(lotteryId, addr) {
+			assert.True(t, entry.Drawn)
+			if entry.Number%10 == lucky%10 {
+				won++
+				assert.Equal(t, int32(pty.LotteryBuyWon), entry.Result)
+				assert.Equal(t, int64(OneStar), entry.Type)
+			} else {
+				assert.Equal(t, int32(pty.LotteryBuyLost), entry.Result)
+				assert.Equal(t, int64(0), entry.Type)
+			}
+		}
+	}
+	assert.True(t, won >= 1)
+
+	//回滚开奖之后恢复为未开奖
+	set, err := env.l.ExecDelLocal_Draw(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	for _, addr := range []string{testBuyer, testOther} {
+		entries := env.buyEntries(lotteryId, addr)
+		assert.True(t, len(entries) > 0)
+		for _, entry := range entries {
+			assert.Equal(t, int32(pty.LotteryBuyPending), entry.Result)
+			assert.Equal(t, int64(0), entry.Type)
+			assert.False(t, entry.Drawn)
+		}
+	}
+
+	//回滚购买之后轮次索引也删除
+	assert.Equal(t, 12, len(env.l.findLotteryRoundBuyKeys(lotteryId, 1)))
+	set, err = env.l.ExecDelLocal_Buy(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: buyReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, 10, len(env.l.findLotteryRoundBuyKeys(lotteryId, 1)))
+}
+
+func TestSelectWinners(t *testing.T) {
+	//sha256("lottery") 前4个字节为be7f94bb, 开奖号码32187
+	seed := common.Sha256([]byte("lottery"))
+	assert.Equal(t, int64(32187), LuckyNumFromSeed(seed))
+	assert.Equal(t, int64(12345), LuckyNumFromSeed([]byte{0, 0, 0x30, 0x39, 0xff}))
+	assert.Equal(t, int64(-1), LuckyNumFromSeed([]byte{1, 2, 3}))
+
+	entries := []DrawEntry{
+		{Addr: "addrC", Index: 1, Number: 7, Way: OneStar, Amount: 1},
+		{Addr: "addrB", Index: 2, Number: 11111, Way: OneStar, Amount: 1},
+		{Addr: "addrA", Index: 2, Number: 32187, Way: FiveStar, Amount: 2},
+		{Addr: "addrB", Index: 1, Number: 55587, Way: TwoStar, Amount: 3},
+		{Addr: "addrA", Index: 1, Number: 10187, Way: ThreeStar, Amount: 1},
+	}
+	expected := []DrawEntry{
+		{Addr: "addrA", Index: 1, Number: 10187, Way: ThreeStar, Amount: 1, Level: ThreeStar, Prize: lucky},
+		{Addr: "addrA", Index: 2, Number: 32187, Way: FiveStar, Amount: 2, Level: FiveStar, Prize: exciting},
+		{Addr: "addrB", Index: 1, Number: 55587, Way: TwoStar, Amount: 3, Level: TwoStar, Prize: happy},
+		{Addr: "addrC", Index: 1, Number: 7, Way: OneStar, Amount: 1, Level: OneStar, Prize: notbad},
+	}
+	assert.Equal(t, expected, SelectWinners(entries, seed, 0, false))
+	//相同的输入得到相同的结果, 并且不修改输入
+	assert.Equal(t, expected, SelectWinners(entries, seed, 0, false))
+	assert.Equal(t, int64(0), entries[2].Level)
+	assert.Equal(t, expected[:2], SelectWinners(entries, seed, 2, false))
+
+	//开奖号码12345 时没有号码中奖
+	assert.Equal(t, 0, len(SelectWinners(entries, []byte{0, 0, 0x30, 0x39}, 0, false)))
+	assert.Nil(t, SelectWinners(entries, nil, 0, false))
+}
+
+func TestSelectWeightedWinners(t *testing.T) {
+	entries := []DrawEntry{
+		{Addr: "addrA", Index: 1, Number: 1, Way: FiveStar, Amount: 1},
+		{Addr: "addrB", Index: 1, Number: 2, Way: FiveStar, Amount: 3},
+		{Addr: "addrC", Index: 1, Number: 3, Way: FiveStar, Amount: 6},
+		{Addr: "addrD", Index: 1, Number: 4, Way: FiveStar, Amount: 0},
+	}
+	//固定的seed 多次抽取, 中奖次数和购买数量成正比
+	wins := make(map[string]int)
+	draws := 2000
+	for i := 0; i < draws; i++ {
+		seed := common.Sha256([]byte(fmt.Sprintf("weighted-%d", i)))
+		winners := SelectWinners(entries, seed, 0, true)
+		assert.Equal(t, 1, len(winners))
+		assert.Equal(t, int64(FiveStar), winners[0].Level)
+		assert.Equal(t, int64(exciting), winners[0].Prize)
+		assert.Equal(t, winners, SelectWinners(entries, seed, 0, true))
+		wins[winners[0].Addr]++
+	}
+	assert.Equal(t, 0, wins["addrD"])
+	assert.True(t, wins["addrC"] > wins["addrB"])
+	assert.True(t, wins["addrB"] > wins["addrA"])
+	assert.InDelta(t, 0.1, float64(wins["addrA"])/float64(draws), 0.03)
+	assert.InDelta(t, 0.3, float64(wins["addrB"])/float64(draws), 0.03)
+	assert.InDelta(t, 0.6, float64(wins["addrC"])/float64(draws), 0.03)
+
+	//不放回抽取, 按地址排序返回, 数量不超过有权重的号码
+	seed := common.Sha256([]byte("lottery"))
+	winners := SelectWinners(entries, seed, 5, true)
+	assert.Equal(t, 3, len(winners))
+	assert.Equal(t, "addrA", winners[0].Addr)
+	assert.Equal(t, "addrB", winners[1].Addr)
+	assert.Equal(t, "addrC", winners[2].Addr)
+	assert.Equal(t, int64(0), entries[2].Level)
+	assert.Nil(t, SelectWinners(entries[3:], seed, 0, true))
+}
+
+func TestLotteryWeightedDraw(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50}, Weighted: true})
+	assert.Nil(t, err)
+	assert.True(t, env.lottery(lotteryId).Weighted)
+	//号码不影响加权开奖, 唯一的购买者中头奖, 分得奖池的50%
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 1))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, testBalance-10*decimal+5*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, int64(5), env.lottery(lotteryId).Fund)
+
+	msg, err := env.l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	winners := msg.(*pty.ReplyLotteryRoundWinners)
+	assert.Equal(t, 1, len(winners.Records))
+	assert.Equal(t, int64(FiveStar), winners.Records[0].Level)
+}
+
+func (env *execEnv) claim(priv string, lotteryId string, round int64) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryClaimTx(&pty.LotteryClaimTx{LotteryId: lotteryId, Round: round})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func (env *execEnv) payouts(lotteryId string, addr string) []*pty.LotteryPayout {
+	msg, err := env.l.Query_GetPayouts(&pty.ReqLotteryPayouts{LotteryId: lotteryId, Addr: addr})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryPayouts).Payouts
+}
+
+func TestLotteryPayoutMaturity(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PayoutMaturity: -1})
+	assert.Equal(t, pty.ErrLotteryPayoutMaturity, err)
+
+	//加权开奖保证唯一的购买者中头奖
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50}, Weighted: true, PayoutMaturity: 10})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 1))
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	drawHeight := env.height
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryPayoutLock)))
+	//开奖时不发放奖金, 留在奖池中
+	assert.Equal(t, testBalance-10*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, int64(10*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, int64(5*decimal), env.lottery(lotteryId).LockedPayout)
+	payouts := env.payouts(lotteryId, testBuyer)
+	assert.Equal(t, 1, len(payouts))
+	assert.Equal(t, int64(1), payouts[0].Round)
+	assert.Equal(t, int64(5*decimal), payouts[0].Amount)
+	assert.Equal(t, drawHeight+10, payouts[0].ClaimableHeight)
+	assert.False(t, payouts[0].Claimed)
+
+	//没有中奖的地址和没有开奖的轮次不能领取
+	_, err = env.claim(PrivKeyB, lotteryId, 1)
+	assert.Equal(t, pty.ErrLotteryNoPayout, err)
+	_, err = env.claim(PrivKeyA, lotteryId, 2)
+	assert.Equal(t, pty.ErrLotteryNoPayout, err)
+
+	//没有到期不能领取
+	_, err = env.claim(PrivKeyA, lotteryId, 1)
+	assert.Equal(t, pty.ErrLotteryPayoutImmature, err)
+	env.height = drawHeight + 8
+	_, err = env.claim(PrivKeyA, lotteryId, 1)
+	assert.Equal(t, pty.ErrLotteryPayoutImmature, err)
+
+	receipt, err = env.claim(PrivKeyA, lotteryId, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, drawHeight+10, env.height)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryClaim)))
+	assert.Equal(t, testBalance-10*decimal+5*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, int64(0), env.lottery(lotteryId).LockedPayout)
+	payouts = env.payouts(lotteryId, testBuyer)
+	assert.True(t, payouts[0].Claimed)
+	assert.Equal(t, common.ToHex(env.history[len(env.history)-1].tx.Hash()), payouts[0].ClaimTxHash)
+
+	_, err = env.claim(PrivKeyA, lotteryId, 1)
+	assert.Equal(t, pty.ErrLotteryPayoutClaimed, err)
+
+	//回滚领取之后恢复为未领取, 回滚开奖之后删除
+	for i := 1; i <= 2; i++ {
+		rec := env.history[len(env.history)-i]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		setLocalKVs(t, env.l, set.KV)
+		if i == 1 {
+			assert.False(t, env.payouts(lotteryId, testBuyer)[0].Claimed)
+		}
+	}
+	assert.Equal(t, 0, len(env.payouts(lotteryId, testBuyer)))
+}
+
+func TestLotteryPayoutReclaim(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, PrizeRatio: []int64{50}, Weighted: true, PayoutMaturity: 100, ReclaimBlockNum: 10})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 1))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	drawHeight := env.height
+	assert.Nil(t, env.close(lotteryId))
+
+	//回收奖池时保留还没有领取的奖金, 关闭之后仍然可以领取
+	env.height += 10
+	_, err = env.reclaim(PrivKeyC, lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5*decimal), env.prizePool(lotteryId))
+
+	env.height = drawHeight + 99
+	_, err = env.claim(PrivKeyA, lotteryId, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, testBalance-10*decimal+5*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, int64(0), env.prizePool(lotteryId))
+}
+
+func TestLotteryMinimumParam(t *testing.T) {
+	env := newExecEnv(t)
+	for _, create := range []*pty.LotteryCreateTx{
+		{PurBlockNum: 30, DrawBlockNum: 40, MinPurchaseNum: -1},
+		{PurBlockNum: 30, DrawBlockNum: 40, MinSalesAmount: -1},
+		{PurBlockNum: 30, DrawBlockNum: 40, MaxWaitBlocks: -1},
+		{PurBlockNum: 30, DrawBlockNum: 40, MaxWaitBlocks: 39},
+	} {
+		_, err := env.create(create)
+		assert.Equal(t, pty.ErrLotteryMinimumParam, err)
+	}
+
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MinPurchaseNum: 3, MinSalesAmount: 20, MaxWaitBlocks: 80, RefundBelowMin: true})
+	assert.Nil(t, err)
+	msg, err := env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	info := msg.(*pty.ReplyLotteryNormalInfo)
+	assert.Equal(t, int64(3), info.MinPurchaseNum)
+	assert.Equal(t, int64(20), info.MinSalesAmount)
+	assert.Equal(t, int64(80), info.MaxWaitBlocks)
+	assert.True(t, info.RefundBelowMin)
+}
+
+func TestLotteryMinimumDraw(t *testing.T) {
+	env := newExecEnv(t)
+	create := &pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MinPurchaseNum: 3, MinSalesAmount: 20}
+	//交易数量和销售额都不够
+	lotteryId, err := env.create(create)
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 5, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 2))
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryBelowMinimum, err)
+	assert.Equal(t, int32(pty.LotteryPurchase), env.lottery(lotteryId).Status)
+
+	//交易数量达到要求, 销售额还不够
+	lotteryId, err = env.create(create)
+	assert.Nil(t, err)
+	for i := int64(0); i < 3; i++ {
+		assert.Nil(t, env.buy(PrivKeyA, lotteryId, 5, i))
+	}
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryBelowMinimum, err)
+
+	lotteryId, err = env.create(create)
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 5, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 2))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 3))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+}
+
+func TestLotteryMinimumEscape(t *testing.T) {
+	env := newExecEnv(t)
+	//超过等待期限之后照常开奖
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MinPurchaseNum: 3, MaxWaitBlocks: 80})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 5, 1))
+	_, err = env.drawAt(PrivKeyC, lotteryId, 79)
+	assert.Equal(t, pty.ErrLotteryBelowMinimum, err)
+	receipt, err := env.drawAt(PrivKeyC, lotteryId, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryDraw)))
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+
+	//超过等待期限之后关闭并退款
+	lotteryId, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MinSalesAmount: 100, MaxWaitBlocks: 80, RefundBelowMin: true})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 10, 1))
+	_, err = env.drawAt(PrivKeyC, lotteryId, 79)
+	assert.Equal(t, pty.ErrLotteryBelowMinimum, err)
+	receipt, err = env.drawAt(PrivKeyC, lotteryId, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(findLogs(receipt, pty.TyLogLotteryDraw)))
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryRefund)))
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+	assert.Equal(t, testBalance, env.execAccount(testOther).Balance)
+}
+
+func TestLotteryMinimumCommit(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 10,
+		MinPurchaseNum: 2, MaxWaitBlocks: 80, RefundBelowMin: true})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 5, 1))
+	env.height = env.lottery(lotteryId).LastTransToPurState + 40
+	assert.Equal(t, pty.ErrLotteryBelowMinimum, env.commit(PrivKeyC, lotteryId, []byte("secret")))
+
+	//需要退款时不提交, 直接通过开奖交易关闭
+	_, err = env.drawAt(PrivKeyC, lotteryId, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+}
+
+func (env *execEnv) drawProof(lotteryId string, round int64) *pty.LotteryDrawProof {
+	msg, err := env.l.Query_GetDrawProof(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: round})
+	assert.Nil(env.t, err)
+	return msg.(*pty.LotteryDrawProof)
+}
+
+func TestLotteryDrawProof(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 3, 2))
+	begin := env.lottery(lotteryId).LastTransToPurState
+	receipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	lott := env.lottery(lotteryId)
+
+	proof := env.drawProof(lotteryId, 1)
+	assert.Equal(t, lotteryId, proof.LotteryId)
+	assert.Equal(t, int64(1), proof.Round)
+	assert.Equal(t, int32(pty.LotteryDrawByBlock), proof.Method)
+	assert.Equal(t, env.height, proof.DrawHeight)
+	assert.Equal(t, int64(pty.LotteryLuckyNumMol), proof.LuckyNumMol)
+	assert.Equal(t, int64(2), proof.PurchasedTxNum)
+	assert.Equal(t, begin, proof.BeginHeight)
+	assert.Equal(t, env.height-1, proof.EndHeight)
+	//mock 总是返回高度为1, 时间为1 的同一个区块
+	assert.Equal(t, []int64{1}, proof.MinerHeights)
+	assert.Equal(t, int64(len(proof.TimeHeights)), proof.TimeSum)
+	assert.Equal(t, []byte("modify"), proof.Modifies)
+
+	//只用证明中的输入在链下重新计算, 和链上的开奖号码一致
+	assert.Equal(t, pty.LotteryDrawStep(proof.PurchasedTxNum, proof.DrawHeight, proof.BeginHeight), proof.Step)
+	assert.Equal(t, pty.LotteryDrawTimeHeights(proof.BeginHeight, proof.EndHeight, proof.Step), proof.TimeHeights)
+	assert.Equal(t, pty.LotteryDrawModify(proof.Modifies, proof.TicketIds, proof.TimeSum, proof.Bits), proof.Seed)
+	assert.Equal(t, lott.LuckyNumber, pty.LotteryDrawProofNumber(proof))
+	assert.Equal(t, lott.LuckyNumber, proof.LuckyNumber)
+
+	//开奖回执中同样带有证明
+	var drawLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(findLogs(receipt, pty.TyLogLotteryDraw)[0].Log, &drawLog))
+	assert.Equal(t, proof.Seed, drawLog.DrawProof.Seed)
+
+	set, err := env.l.ExecDelLocal_Draw(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	_, err = env.l.Query_GetDrawProof(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 1})
+	assert.NotNil(t, err)
+}
+
+func TestLotteryDrawProofReveal(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, RevealBlockNum: 3, RevealTimeout: 10})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	secret := []byte("secret")
+	assert.Nil(t, env.commit(PrivKeyC, lotteryId, secret))
+	env.height += 3
+	_, err = env.reveal(PrivKeyC, lotteryId, secret)
+	assert.Nil(t, err)
+	lott := env.lottery(lotteryId)
+
+	proof := env.drawProof(lotteryId, 1)
+	assert.Equal(t, int32(pty.LotteryDrawByReveal), proof.Method)
+	assert.Equal(t, secret, proof.Secret)
+	assert.Equal(t, proof.DrawHeight-1, proof.HashHeight)
+	assert.Equal(t, pty.LotteryRevealSeed(proof.Secret, proof.BlockHash), proof.Seed)
+	assert.Equal(t, lott.LuckyNumber, pty.LotteryDrawProofNumber(proof))
+	assert.Equal(t, int64(-1), pty.LotteryDrawProofNumber(&pty.LotteryDrawProof{}))
+}
+
+func TestLotteryVerifyDraw(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	lucky := env.predictLuckyNum(2, 40)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, lucky))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 3, (lucky+1)%luckyNumMol))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+
+	//轮次信息中记录了seed 和算法版本
+	info := env.roundsInfo(lotteryId)[0]
+	assert.Equal(t, env.drawProof(lotteryId, 1).Seed, info.Seed)
+	assert.Equal(t, int32(pty.LotteryDrawAlgoVersion), info.AlgoVersion)
+
+	msg, err := env.l.Query_VerifyDraw(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	reply := msg.(*pty.ReplyLotteryVerifyDraw)
+	assert.True(t, reply.Verified)
+	assert.Equal(t, lucky, reply.LuckyNumber)
+	assert.Equal(t, int32(1), reply.ExpectedNum)
+	assert.Equal(t, int32(1), reply.RecordedNum)
+
+	//篡改中奖记录的奖级
+	msg, err = env.l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	winner := *msg.(*pty.ReplyLotteryRoundWinners).Records[0]
+	assert.Equal(t, testBuyer, winner.Addr)
+	key := calcLotteryWinnerKey(env.l.localPrefix(), lotteryId, 1, testBuyer, winner.Index)
+	winner.Level = OneStar
+	setLocalKVs(t, env.l, []*types.KeyValue{{Key: key, Value: types.Encode(&winner)}})
+	msg, err = env.l.Query_VerifyDraw(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	reply = msg.(*pty.ReplyLotteryVerifyDraw)
+	assert.False(t, reply.Verified)
+	assert.Equal(t, 1, len(reply.Mismatches))
+	assert.Equal(t, testBuyer, reply.Mismatches[0].Addr)
+	assert.Equal(t, int64(FiveStar), reply.Mismatches[0].Level)
+
+	//没有中奖的地址被加入中奖记录
+	winner.Level = FiveStar
+	fake := &pty.LotteryWinnerRecord{Addr: testOther, Round: 1, Index: 0, Level: FiveStar, Amount: 1}
+	setLocalKVs(t, env.l, []*types.KeyValue{
+		{Key: key, Value: types.Encode(&winner)},
+		{Key: calcLotteryWinnerKey(env.l.localPrefix(), lotteryId, 1, testOther, 0), Value: types.Encode(fake)},
+	})
+	msg, err = env.l.Query_VerifyDraw(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	reply = msg.(*pty.ReplyLotteryVerifyDraw)
+	assert.False(t, reply.Verified)
+	assert.Equal(t, int32(2), reply.RecordedNum)
+	assert.Equal(t, 1, len(reply.Mismatches))
+	assert.Equal(t, testOther, reply.Mismatches[0].Addr)
+
+	_, err = env.l.Query_VerifyDraw(&pty.ReqLotteryDrawProof{LotteryId: lotteryId})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = env.l.Query_VerifyDraw(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 2})
+	assert.NotNil(t, err)
+
+	//不支持的算法版本
+	info.AlgoVersion = pty.LotteryDrawAlgoVersion + 1
+	setLocalKVs(t, env.l, []*types.KeyValue{{Key: calcLotteryRoundKey(env.l.localPrefix(), lotteryId, 1), Value: types.Encode(info)}})
+	_, err = env.l.Query_VerifyDraw(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 1})
+	assert.Equal(t, pty.ErrLotteryAlgoVersion, err)
+}
+
+func TestLotteryFullInfo(t *testing.T) {
+	env := newExecEnv(t)
+	create := &pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, OpPurchaseLimit: 50, CreatorFeeRatio: 10, MaxBuyPerTx: 20, Drawers: []string{testThird}}
+	lotteryId, err := env.create(create)
+	assert.Nil(t, err)
+
+	//还没有购买
+	msg, err := env.l.Query_GetLotteryFullInfo(&pty.ReqLotteryFullInfo{LotteryId: lotteryId, Addr: testBuyer})
+	assert.Nil(t, err)
+	reply := msg.(*pty.ReplyLotteryFullInfo)
+	assert.Equal(t, int64(1), reply.Round)
+	assert.Nil(t, reply.LastDraw)
+	assert.Equal(t, &pty.LotteryAddrRoundInfo{Addr: testBuyer, Round: 1}, reply.AddrInfo)
+
+	//第一轮开奖之后, 第二轮购买中
+	lucky := env.predictLuckyNum(2, 40)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, lucky))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 8, (lucky+1)%luckyNumMol))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	lastDraw := env.roundsInfo(lotteryId)[0]
+	_, err = env.buyItems(PrivKeyA, lotteryId, []*pty.LotteryBuyItem{{Number: 1, Amount: 1, Way: FiveStar}, {Number: 2, Amount: 4, Way: OneStar}})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 3))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 1, 4))
+
+	msg, err = env.l.Query_GetLotteryFullInfo(&pty.ReqLotteryFullInfo{LotteryId: lotteryId, Addr: testBuyer})
+	assert.Nil(t, err)
+	reply = msg.(*pty.ReplyLotteryFullInfo)
+	records := reply.AddrInfo.Records
+	reply.AddrInfo.Records = nil
+	expect := &pty.ReplyLotteryFullInfo{
+		LotteryId:    lotteryId,
+		Status:       pty.LotteryPurchase,
+		CreateAddr:   testCreator,
+		Admin:        testCreator,
+		CreateHeight: env.lottery(lotteryId).CreateHeight,
+		Config: &pty.LotteryCreate{
+			PurBlockNum:     30,
+			DrawBlockNum:    40,
+			OpPurchaseLimit: 50,
+			CreatorFeeRatio: 10,
+			MaxBuyPerTx:     20,
+			Drawers:         []string{testThird},
+		},
+		Round:         2,
+		RoundSales:    11,
+		RoundTxNum:    3,
+		RoundBuyerNum: 2,
+		Fund:          15,
+		EscrowAddr:    escrowAddress(lotteryId),
+		LastDraw:      lastDraw,
+		AddrInfo: &pty.LotteryAddrRoundInfo{
+			Addr:       testBuyer,
+			Round:      2,
+			TicketNum:  2,
+			Amount:     5,
+			PendingNum: 2,
+		},
+	}
+	assert.Equal(t, expect, reply)
+	assert.Equal(t, int64(1), lastDraw.Round)
+	assert.Equal(t, lucky, lastDraw.LuckyNumber)
+	//最新的购买记录在前
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, int64(4), records[0].Amount)
+	assert.Equal(t, int64(OneStar), records[0].Way)
+
+	msg, err = env.l.Query_GetLotteryFullInfo(&pty.ReqLotteryFullInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Nil(t, msg.(*pty.ReplyLotteryFullInfo).AddrInfo)
+
+	//开奖之后返回刚开奖轮次的中奖金额
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	msg, err = env.l.Query_GetLotteryFullInfo(&pty.ReqLotteryFullInfo{LotteryId: lotteryId, Addr: testBuyer})
+	assert.Nil(t, err)
+	reply = msg.(*pty.ReplyLotteryFullInfo)
+	assert.Equal(t, int64(3), reply.Round)
+	assert.Equal(t, int64(2), reply.LastDraw.Round)
+	assert.Equal(t, int64(2), reply.AddrInfo.Round)
+	assert.Equal(t, int32(0), reply.AddrInfo.PendingNum)
+	msg, err = env.l.Query_GetAddrWinnings(&pty.ReqLotteryAddrWinnings{LotteryId: lotteryId, Addr: testBuyer})
+	assert.Nil(t, err)
+	//第一轮的中奖金额加上第二轮的
+	assert.Equal(t, msg.(*pty.LotteryAddrWinnings).TotalWon, reply.AddrInfo.Winnings+lastDraw.TotalPayout)
+}
+
+func (env *execEnv) modify(priv string, lotteryId string, add []string, remove []string) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryModifyTx(&pty.LotteryModifyTx{LotteryId: lotteryId, AddDrawers: add, RemoveDrawers: remove})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryDrawers(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Drawers: []string{"notaddr"}})
+	assert.Equal(t, pty.ErrLotteryDrawerAddr, err)
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Drawers: []string{testThird, testThird}})
+	assert.Equal(t, pty.ErrLotteryDrawerAddr, err)
+
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Drawers: []string{testThird}})
+	assert.Nil(t, err)
+	msg, err := env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{testThird}, msg.(*pty.ReplyLotteryNormalInfo).Drawers)
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	//既不是开奖地址也没有购买
+	_, err = env.drawAt(PrivKeyB, lotteryId, 40)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+	_, err = env.drawAt(PrivKeyD, lotteryId, 40)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+}
+
+func TestLotteryModifyDrawers(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	_, err = env.modify(PrivKeyA, lotteryId, []string{testThird}, nil)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	_, err = env.modify(PrivKeyC, lotteryId, nil, []string{testThird})
+	assert.Equal(t, pty.ErrLotteryDrawerAddr, err)
+	_, err = env.modify(PrivKeyC, lotteryId, []string{testThird}, nil)
+	assert.Nil(t, err)
+	_, err = env.modify(PrivKeyC, lotteryId, []string{testThird}, nil)
+	assert.Equal(t, pty.ErrLotteryDrawerAddr, err)
+	assert.Equal(t, []string{testThird}, env.lottery(lotteryId).Drawers)
+
+	//购买期间不能修改
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	_, err = env.modify(PrivKeyC, lotteryId, nil, []string{testThird})
+	assert.Equal(t, pty.ErrLotteryInvalidState, err)
+	_, err = env.drawAt(PrivKeyD, lotteryId, 40)
+	assert.Nil(t, err)
+
+	//开奖之后下一轮购买开始之前可以修改
+	receipt, err := env.modify(PrivKeyC, lotteryId, nil, []string{testThird})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(env.lottery(lotteryId).Drawers))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	_, err = env.drawAt(PrivKeyD, lotteryId, 40)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+
+	msg, err := env.l.Query_GetModifyRecords(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	records := msg.(*pty.ReplyLotteryModifyRecords).Records
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, []string{testThird}, records[0].RemoveDrawers)
+	assert.Equal(t, 0, len(records[0].Drawers))
+	assert.Equal(t, int64(1), records[0].Round)
+	assert.Equal(t, []string{testThird}, records[1].AddDrawers)
+	assert.Equal(t, testCreator, records[1].Addr)
+
+	set, err := env.l.ExecDelLocal_Modify(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: receipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	msg, err = env.l.Query_GetModifyRecords(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(msg.(*pty.ReplyLotteryModifyRecords).Records))
+}
+
+func (env *execEnv) winnings(lotteryId string, addr string) *pty.LotteryAddrWinnings {
+	msg, err := env.l.Query_GetAddrWinnings(&pty.ReqLotteryAddrWinnings{LotteryId: lotteryId, Addr: addr})
+	assert.Nil(env.t, err)
+	return msg.(*pty.LotteryAddrWinnings)
+}
+
+func TestLotteryAddrWinnings(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	_, err = env.l.Query_GetAddrWinnings(&pty.ReqLotteryAddrWinnings{LotteryId: "0xnotexist", Addr: testBuyer})
+	assert.Equal(t, types.ErrNotFound, err)
+
+	//第一轮: 每个尾数都买, 一星必定中奖
+	for i := int64(0); i < 10; i++ {
+		env.buyWay(PrivKeyA, lotteryId, 10, i, OneStar)
+	}
+	drawReceipt, err := env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, &pty.LotteryAddrWinnings{LotteryId: lotteryId, Addr: testBuyer, TotalWon: 50 * decimal, TotalSpent: 100 * decimal, Net: -50 * decimal},
+		env.winnings(lotteryId, testBuyer))
+
+	//第二轮: 没有中奖
+	lucky := env.predictLuckyNum(1, 40)
+	buyReceipt, err := env.buyItems(PrivKeyA, lotteryId, []*pty.LotteryBuyItem{{Number: (lucky + 1) % luckyNumMol, Amount: 3, Way: FiveStar}})
+	assert.Nil(t, err)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	winnings := env.winnings(lotteryId, testBuyer)
+	assert.Equal(t, int64(50*decimal), winnings.TotalWon)
+	assert.Equal(t, int64(103*decimal), winnings.TotalSpent)
+	assert.Equal(t, int64(-53*decimal), winnings.Net)
+	assert.Equal(t, &pty.LotteryAddrWinnings{LotteryId: lotteryId, Addr: testOther}, env.winnings(lotteryId, testOther))
+
+	//回滚第二轮的购买和第一轮的开奖
+	set, err := env.l.ExecDelLocal_Buy(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: buyReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	set, err = env.l.ExecDelLocal_Draw(nil, nil, &types.ReceiptData{Ty: types.ExecOk, Logs: drawReceipt.Logs}, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	winnings = env.winnings(lotteryId, testBuyer)
+	assert.Equal(t, int64(0), winnings.TotalWon)
+	assert.Equal(t, int64(100*decimal), winnings.TotalSpent)
+}
+
+//localdb 的每个key 都必须在回滚时处理, 按执行的相反顺序回滚全部交易之后查询不到任何记录
+func TestLotteryLocalRollback(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Drawers: []string{testThird}})
+	assert.Nil(t, err)
+	for i := int64(0); i < 10; i++ {
+		env.buyWay(PrivKeyA, lotteryId, 10, i, OneStar)
+	}
+	_, err = env.buyItems(PrivKeyB, lotteryId, []*pty.LotteryBuyItem{{Number: 12345, Amount: 5, Way: FiveStar}, {Number: 3, Amount: 1, Way: OneStar}})
+	assert.Nil(t, err)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	_, err = env.modify(PrivKeyC, lotteryId, []string{testOther}, nil)
+	assert.Nil(t, err)
+	_, err = env.buyItems(PrivKeyD, lotteryId, []*pty.LotteryBuyItem{{Number: 2, Amount: 7, Way: FiveStar}})
+	assert.Nil(t, err)
+	assert.Nil(t, env.close(lotteryId))
+
+	for i := len(env.history) - 1; i >= 0; i-- {
+		rec := env.history[i]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		deleted := make(map[string]bool)
+		for _, kv := range set.KV {
+			deleted[string(kv.Key)] = true
+		}
+		for _, kv := range rec.local.KV {
+			assert.True(t, deleted[string(kv.Key)], "key %s not rolled back", string(kv.Key))
+		}
+		setLocalKVs(t, env.l, set.KV)
+	}
+
+	for _, status := range []int32{pty.LotteryCreated, pty.LotteryPurchase, pty.LotteryDrawed, pty.LotteryClosed} {
+		assert.Equal(t, 0, len(listedIds(env.list(status, 0, ListASC, ""))))
+	}
+	assert.Equal(t, 0, len(env.buyEntries(lotteryId, testBuyer)))
+	msg, err := env.l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: lotteryId, Round: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(msg.(*pty.ReplyLotteryRoundWinners).Records))
+	_, err = env.l.Query_GetDrawProof(&pty.ReqLotteryDrawProof{LotteryId: lotteryId, Round: 1})
+	assert.NotNil(t, err)
+	_, err = env.l.Query_GetModifyRecords(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Equal(t, types.ErrNotFound, err)
+	assert.Equal(t, int64(0), env.winnings(lotteryId, testBuyer).TotalSpent)
+	assert.Equal(t, 0, len(env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0)))
+	assert.Equal(t, 0, len(env.leaderboard(lotteryId, pty.LotteryBoardWinnings, 0)))
+}
+
+func (env *execEnv) leaderboard(lotteryId string, metric int32, topN int32) []*pty.LotteryBoardEntry {
+	msg, err := env.l.Query_GetLeaderboard(&pty.ReqLotteryLeaderboard{LotteryId: lotteryId, Metric: metric, TopN: topN})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryLeaderboard).Entries
+}
+
+func boardAddrs(entries []*pty.LotteryBoardEntry) []string {
+	addrs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		addrs = append(addrs, entry.Addr)
+	}
+	return addrs
+}
+
+func TestLotteryLeaderboard(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	_, err = env.l.Query_GetLeaderboard(&pty.ReqLotteryLeaderboard{LotteryId: lotteryId, Metric: 3})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = env.l.Query_GetLeaderboard(&pty.ReqLotteryLeaderboard{LotteryId: "0xnotexist", Metric: pty.LotteryBoardSpent})
+	assert.Equal(t, types.ErrNotFound, err)
+	assert.Equal(t, 0, len(env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0)))
+
+	//购买金额: testThird > testOther > testBuyer, 中奖金额: testOther > testBuyer, testThird 没有中奖
+	lucky := env.predictLuckyNum(4, 40)
+	env.buyWay(PrivKeyA, lotteryId, 10, lucky, OneStar)
+	env.buyWay(PrivKeyB, lotteryId, 20, lucky, OneStar)
+	env.buyWay(PrivKeyD, lotteryId, 50, (lucky+1)%luckyNumMol, FiveStar)
+	env.buyWay(PrivKeyB, lotteryId, 10, lucky, OneStar)
+
+	spent := env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0)
+	assert.Equal(t, []*pty.LotteryBoardEntry{{Addr: testThird, Score: 50, Rank: 1}, {Addr: testOther, Score: 30, Rank: 2}, {Addr: testBuyer, Score: 10, Rank: 3}}, spent)
+	assert.Equal(t, []string{testThird, testOther}, boardAddrs(env.leaderboard(lotteryId, pty.LotteryBoardSpent, 2)))
+
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	winnings := env.leaderboard(lotteryId, pty.LotteryBoardWinnings, 0)
+	assert.Equal(t, []string{testOther, testBuyer}, boardAddrs(winnings))
+	assert.Equal(t, env.winnings(lotteryId, testOther).TotalWon, winnings[0].Score)
+	assert.Equal(t, env.winnings(lotteryId, testBuyer).TotalWon, winnings[1].Score)
+	assert.True(t, winnings[0].Score > winnings[1].Score)
+	assert.True(t, winnings[1].Score > 0)
+}
+
+//排行榜只保存前maxBoardSize 个地址, 回滚之后被挤出的地址重新回到排行榜
+func TestLotteryLeaderboardRollback(t *testing.T) {
+	defer func(max int) { maxBoardSize = max }(maxBoardSize)
+	maxBoardSize = 2
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	env.buyWay(PrivKeyA, lotteryId, 10, 1, FiveStar)
+	env.buyWay(PrivKeyB, lotteryId, 20, 2, FiveStar)
+	env.buyWay(PrivKeyD, lotteryId, 30, 3, FiveStar)
+	assert.Equal(t, []string{testThird, testOther}, boardAddrs(env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0)))
+	//分数相同时按地址排序
+	env.buyWay(PrivKeyA, lotteryId, 20, 4, FiveStar)
+	expect := []string{testBuyer, testThird}
+	if testThird < testBuyer {
+		expect = []string{testThird, testBuyer}
+	}
+	assert.Equal(t, expect, boardAddrs(env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0)))
+
+	rollback := func() {
+		rec := env.history[len(env.history)-1]
+		env.history = env.history[:len(env.history)-1]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		setLocalKVs(t, env.l, set.KV)
+	}
+	rollback()
+	assert.Equal(t, []*pty.LotteryBoardEntry{{Addr: testThird, Score: 30, Rank: 1}, {Addr: testOther, Score: 20, Rank: 2}},
+		env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0))
+	rollback()
+	assert.Equal(t, []*pty.LotteryBoardEntry{{Addr: testOther, Score: 20, Rank: 1}, {Addr: testBuyer, Score: 10, Rank: 2}},
+		env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0))
+	rollback()
+	rollback()
+	assert.Equal(t, 0, len(env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0)))
+}
+
+func (env *execEnv) numberHeat(lotteryId string, round int64) []*pty.LotteryNumberHeat {
+	msg, err := env.l.Query_GetNumberHeat(&pty.ReqLotteryNumberHeat{LotteryId: lotteryId, Round: round})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryNumberHeat).Numbers
+}
+
+func TestLotteryNumberHeat(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(env.numberHeat(lotteryId, 1)))
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 5))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 3, 5))
+	_, err = env.buyItems(PrivKeyA, lotteryId, []*pty.LotteryBuyItem{
+		{Number: 5, Amount: 1, Way: FiveStar},
+		{Number: 7, Amount: 1, Way: OneStar},
+	})
+	assert.Nil(t, err)
+	//盲选购买揭示之前不计入
+	nonce := []byte("nonce")
+	assert.Nil(t, env.blindBuy(PrivKeyD, lotteryId, 4, 7, nonce))
+	assert.Equal(t, []*pty.LotteryNumberHeat{{Number: 5, Purchases: 3, Amount: 6}, {Number: 7, Purchases: 1, Amount: 1}},
+		env.numberHeat(lotteryId, 1))
+	_, err = env.revealNumber(PrivKeyD, lotteryId, 7, nonce)
+	assert.Nil(t, err)
+	assert.Equal(t, []*pty.LotteryNumberHeat{{Number: 5, Purchases: 3, Amount: 6}, {Number: 7, Purchases: 2, Amount: 5}},
+		env.numberHeat(lotteryId, 1))
+	assert.Equal(t, 0, len(env.numberHeat(lotteryId, 2)))
+
+	rollback := func() {
+		rec := env.history[len(env.history)-1]
+		env.history = env.history[:len(env.history)-1]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		setLocalKVs(t, env.l, set.KV)
+	}
+	rollback()
+	assert.Equal(t, []*pty.LotteryNumberHeat{{Number: 5, Purchases: 3, Amount: 6}, {Number: 7, Purchases: 1, Amount: 1}},
+		env.numberHeat(lotteryId, 1))
+	rollback()
+	rollback()
+	assert.Equal(t, []*pty.LotteryNumberHeat{{Number: 5, Purchases: 2, Amount: 5}}, env.numberHeat(lotteryId, 1))
+	rollback()
+	rollback()
+	assert.Equal(t, 0, len(env.numberHeat(lotteryId, 1)))
+}
+
+func TestLotteryLocalPrefix(t *testing.T) {
+	assert.Equal(t, "LODB-lottery-", calcLocalPrefix(pty.LotteryX))
+	assert.Equal(t, "LODB-lottery-user.lottery.a:", calcLocalPrefix("user.lottery.a"))
+
+	//两个别名共用同一个localdb, 各自只能看到自己的记录
+	env := newExecEnv(t)
+	env.l.SetCurrentExecName("user.lottery.a")
+	idA, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, idA, 2, 1))
+	env.l.SetCurrentExecName("user.lottery.b")
+	idB, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, idB, 2, 1))
+
+	assert.Equal(t, int64(1), env.stats(idB).TicketsSold)
+	assert.Equal(t, int64(0), env.stats(idA).TicketsSold)
+	assert.True(t, env.statusIndexed(idB, pty.LotteryPurchase))
+	assert.False(t, env.statusIndexed(idA, pty.LotteryPurchase))
+	env.l.SetCurrentExecName("user.lottery.a")
+	assert.Equal(t, int64(1), env.stats(idA).TicketsSold)
+	assert.Equal(t, int64(0), env.stats(idB).TicketsSold)
+	assert.True(t, env.statusIndexed(idA, pty.LotteryPurchase))
+	assert.False(t, env.statusIndexed(idB, pty.LotteryPurchase))
+	env.l.SetCurrentExecName(pty.LotteryX)
+	assert.Equal(t, int64(0), env.stats(idA).TicketsSold)
+	assert.Equal(t, int64(0), env.stats(idB).TicketsSold)
+
+	//执行区块时框架检查每个localdb key 的前缀, 别名执行器写入的key 也必须通过
+	for i, rec := range env.history {
+		execer := []byte("user.lottery.a")
+		if i >= 2 {
+			execer = []byte("user.lottery.b")
+		}
+		for _, kv := range rec.local.KV {
+			assert.Nil(t, checkLocalKeyPrefix(execer, kv.Key), string(kv.Key))
+		}
+	}
+}
+
+//和chain33 executor 中的isAllowLocalKey 相同: 执行区块时每个localdb key 必须以LODB-{真实执行器名字}- 开头, 否则panic
+func checkLocalKeyPrefix(execer []byte, key []byte) error {
+	execer = types.GetRealExecName(execer)
+	minkeylen := len(types.LocalPrefix) + len(execer) + 2
+	if len(key) <= minkeylen {
+		return types.ErrLocalKeyLen
+	}
+	if key[minkeylen-1] != '-' || !bytes.HasPrefix(key, types.LocalPrefix) || !bytes.HasPrefix(key[len(types.LocalPrefix)+1:], execer) {
+		return types.ErrLocalPrefix
+	}
+	return nil
+}
+
+func (env *execEnv) roundsInfo(lotteryId string) []*pty.LotteryRoundInfo {
+	msg, err := env.l.Query_GetRoundsInfo(&pty.ReqLotteryRoundsInfo{LotteryId: lotteryId, Direction: ListASC})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryRoundsInfo).Rounds
+}
+
+//没有购买的轮次到期之后跳过, 轮次信息中记录为LotteryRoundEmpty
+func TestLotteryDrawEmpty(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MaxRounds: 3})
+	assert.Nil(t, err)
+	createHeight := env.lottery(lotteryId).CreateHeight
+	drawEmpty := func(priv string, elapsed int64) (*types.Receipt, error) {
+		env.height = createHeight + elapsed - 1
+		tx, err := pty.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{LotteryId: lotteryId})
+		assert.Nil(t, err)
+		return env.exec(tx, priv)
+	}
+	_, err = drawEmpty(PrivKeyC, 39)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+	_, err = drawEmpty(PrivKeyA, 40)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+	receipt, err := drawEmpty(PrivKeyC, 40)
+	assert.Nil(t, err)
+	logs := findLogs(receipt, pty.TyLogLotteryDrawEmpty)
+	assert.Equal(t, 1, len(logs))
+	var emptyLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(logs[0].Log, &emptyLog))
+	assert.Equal(t, int64(1), emptyLog.Round)
+	assert.Equal(t, createHeight+40, emptyLog.Height)
+	assert.Equal(t, int32(pty.LotteryCreated), emptyLog.PrevStatus)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(1), lott.Round)
+	assert.Equal(t, int32(pty.LotteryDrawed), lott.Status)
+	assert.Equal(t, int64(0), lott.Fund)
+	rounds := env.roundsInfo(lotteryId)
+	assert.Equal(t, 1, len(rounds))
+	assert.Equal(t, int32(pty.LotteryRoundEmpty), rounds[0].Status)
+	assert.Equal(t, int64(1), rounds[0].Round)
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryDrawed))
+
+	//回滚之后删除轮次信息, 状态索引恢复
+	rec := env.history[len(env.history)-1]
+	set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, 0, len(env.roundsInfo(lotteryId)))
+	assert.True(t, env.statusIndexed(lotteryId, pty.LotteryCreated))
+	assert.False(t, env.statusIndexed(lotteryId, pty.LotteryDrawed))
+	setLocalKVs(t, env.l, rec.local.KV)
+
+	//下一笔购买开始第2轮
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	assert.Equal(t, int64(2), env.lottery(lotteryId).Round)
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	rounds = env.roundsInfo(lotteryId)
+	assert.Equal(t, 2, len(rounds))
+	assert.Equal(t, int32(pty.LotteryDrawed), rounds[1].Status)
+	assert.Equal(t, int64(2), rounds[1].Round)
+
+	//最后一轮跳过之后关闭
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+	env.height = env.lottery(lotteryId).LastTransToDrawState + 40 - 1
+	tx, err := pty.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	receipt, err = env.exec(tx, PrivKeyC)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryDrawEmpty)))
+	assert.Equal(t, 1, len(findLogs(receipt, pty.TyLogLotteryClose)))
+	lott = env.lottery(lotteryId)
+	assert.Equal(t, int64(3), lott.Round)
+	assert.Equal(t, int32(pty.LotteryClosed), lott.Status)
+	rounds = env.roundsInfo(lotteryId)
+	assert.Equal(t, 3, len(rounds))
+	assert.Equal(t, int32(pty.LotteryRoundEmpty), rounds[2].Status)
+}
+
+//购买期为[h, h+purBlockNum], 购买期结束到开奖之前的购买被拒绝, 不会算到下一轮
+func TestLotteryPurchaseWindow(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	start := env.lottery(lotteryId).LastTransToPurState
+	assert.Equal(t, env.height, start)
+
+	//exec 在当前高度加1 之后执行
+	env.height = start + 30 - 1
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 1, 2))
+	assert.Equal(t, pty.ErrLotteryPurchasePeriodExpired, env.buy(PrivKeyB, lotteryId, 1, 3))
+	env.height = start + 40 - 2
+	assert.Equal(t, pty.ErrLotteryPurchasePeriodExpired, env.buy(PrivKeyD, lotteryId, 1, 4))
+	//ForkLotteryBuyWindow 之前的区块同样拒绝, 错误为ErrLotteryStatus
+	types.Init("chain33", nil)
+	assert.Equal(t, pty.ErrLotteryStatus, env.buy(PrivKeyD, lotteryId, 1, 4))
+	types.Init("local", nil)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(1), lott.Round)
+	assert.Equal(t, int64(2), lott.TotalPurchasedTxNum)
+
+	env.height = start + 40 - 1
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(env.buyEntries(lotteryId, testBuyer))+len(env.buyEntries(lotteryId, testOther)))
+	assert.Equal(t, 0, len(env.buyEntries(lotteryId, testThird)))
+
+	//和开奖在同一个区块中的购买不能开始新的一轮
+	env.height--
+	assert.Equal(t, pty.ErrLotteryStatus, env.buy(PrivKeyD, lotteryId, 1, 4))
+	//开奖之后的下一个区块开始新的一轮
+	assert.Nil(t, env.buy(PrivKeyD, lotteryId, 1, 4))
+	lott = env.lottery(lotteryId)
+	assert.Equal(t, int64(2), lott.Round)
+	assert.Equal(t, env.height, lott.LastTransToPurState)
+}
+
+func (env *execEnv) transfer(priv string, lotteryId string, to string) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryTransferTx(&pty.LotteryTransferTx{LotteryId: lotteryId, To: to})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryTransfer(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	info, err := env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, testCreator, info.(*pty.ReplyLotteryNormalInfo).Admin)
+
+	//移交之前只有创建者有权限
+	_, err = env.transfer(PrivKeyD, lotteryId, testThird)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	_, err = env.modify(PrivKeyD, lotteryId, []string{testOther}, nil)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	_, err = env.transfer(PrivKeyC, lotteryId, "notaddr")
+	assert.Equal(t, pty.ErrLotteryAdminAddr, err)
+	_, err = env.transfer(PrivKeyC, lotteryId, testCreator)
+	assert.Equal(t, pty.ErrLotteryAdminAddr, err)
+
+	//购买期间移交
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	receipt, err := env.transfer(PrivKeyC, lotteryId, testThird)
+	assert.Nil(t, err)
+	logs := findLogs(receipt, pty.TyLogLotteryTransfer)
+	assert.Equal(t, 1, len(logs))
+	var transferlog pty.ReceiptLotteryTransfer
+	assert.Nil(t, types.Decode(logs[0].Log, &transferlog))
+	assert.Equal(t, int32(pty.LotteryPurchase), transferlog.Status)
+	assert.Equal(t, testCreator, transferlog.PrevAdmin)
+	assert.Equal(t, testThird, transferlog.Admin)
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, testCreator, lott.CreateAddr)
+	assert.Equal(t, testThird, lott.Admin)
+	//管理地址和创建者都不能购买
+	assert.Equal(t, pty.ErrLotteryCreatorBuy, env.buy(PrivKeyD, lotteryId, 1, 1))
+
+	//移交之后原来的创建者不能开奖, 关闭, 修改和再次移交
+	_, err = env.drawAt(PrivKeyC, lotteryId, 40)
+	assert.Equal(t, pty.ErrLotteryDrawActionInvalid, err)
+	_, err = env.transfer(PrivKeyC, lotteryId, testOther)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	assert.Equal(t, pty.ErrLotteryErrCloser, env.close(lotteryId))
+
+	//新的管理地址可以开奖和修改开奖地址
+	_, err = env.drawAt(PrivKeyD, lotteryId, 40)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+	_, err = env.modify(PrivKeyC, lotteryId, []string{testOther}, nil)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	_, err = env.modify(PrivKeyD, lotteryId, []string{testOther}, nil)
+	assert.Nil(t, err)
+	_, err = env.transfer(PrivKeyD, lotteryId, testCreator)
+	assert.Nil(t, err)
+	info, err = env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, testCreator, info.(*pty.ReplyLotteryNormalInfo).Admin)
+
+	msg, err := env.l.Query_GetTransferRecords(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	records := msg.(*pty.ReplyLotteryTransferRecords).Records
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, testThird, records[0].PrevAdmin)
+	assert.Equal(t, testCreator, records[0].Admin)
+	assert.Equal(t, testThird, records[1].Admin)
+
+	//回滚最后一次移交
+	rec := env.history[len(env.history)-1]
+	set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	msg, err = env.l.Query_GetTransferRecords(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(msg.(*pty.ReplyLotteryTransferRecords).Records))
+	assert.Nil(t, env.close(lotteryId))
+}
+
+func (env *execEnv) reclaim(priv string, lotteryId string) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryReclaimTx(&pty.LotteryReclaimTx{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryReclaim(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, ReclaimBlockNum: -1})
+	assert.Equal(t, pty.ErrLotteryReclaimBlockNum, err)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, ReclaimBlockNum: 10})
+	assert.Nil(t, err)
+	_, err = env.reclaim(PrivKeyC, lotteryId)
+	assert.Equal(t, pty.ErrLotteryInvalidState, err)
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 1))
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Nil(t, env.close(lotteryId))
+	assert.Equal(t, env.height, env.lottery(lotteryId).CloseHeight)
+
+	//模拟分配之后留在奖池中的零头
+	coins := account.NewCoinsAccount()
+	coins.SetDB(env.stateDB)
+	escrow := env.lottery(lotteryId).EscrowAddr
+	execaddr := address.ExecAddress(pty.LotteryX)
+	acc := coins.LoadExecAccount(escrow, execaddr)
+	acc.Balance += 7
+	coins.SaveExecAccount(execaddr, acc)
+	residual := env.prizePool(lotteryId)
+	assert.True(t, residual >= 7)
+
+	_, err = env.reclaim(PrivKeyD, lotteryId)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	//还没有到等待期限
+	_, err = env.reclaim(PrivKeyC, lotteryId)
+	assert.Equal(t, pty.ErrLotteryStatus, err)
+
+	env.height += 10
+	before := env.execAccount(testCreator).Balance
+	receipt, err := env.reclaim(PrivKeyC, lotteryId)
+	assert.Nil(t, err)
+	logs := findLogs(receipt, pty.TyLogLotteryReclaim)
+	assert.Equal(t, 1, len(logs))
+	var reclaimlog pty.ReceiptLotteryReclaim
+	assert.Nil(t, types.Decode(logs[0].Log, &reclaimlog))
+	assert.Equal(t, residual, reclaimlog.Amount)
+	assert.Equal(t, before+residual, env.execAccount(testCreator).Balance)
+	assert.Equal(t, int64(0), env.prizePool(lotteryId))
+	assert.Equal(t, residual, env.stats(lotteryId).TotalReclaim)
+
+	//只能回收一次
+	_, err = env.reclaim(PrivKeyC, lotteryId)
+	assert.Equal(t, pty.ErrLotteryReclaimed, err)
+
+	rec := env.history[len(env.history)-1]
+	set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, int64(0), env.stats(lotteryId).TotalReclaim)
+}
+
+func (env *execEnv) blacklist(priv string, lotteryId string, add []string, remove []string) error {
+	tx, err := pty.CreateRawLotteryBlacklistTx(&pty.LotteryBlacklistTx{LotteryId: lotteryId, Add: add, Remove: remove})
+	assert.Nil(env.t, err)
+	_, err = env.exec(tx, priv)
+	return err
+}
+
+func TestLotteryBlacklist(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Blacklist: []string{"notaddr"}})
+	assert.Equal(t, pty.ErrLotteryBlacklistAddr, err)
+	_, err = env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Blacklist: []string{testOther, testOther}})
+	assert.Equal(t, pty.ErrLotteryBlacklistAddr, err)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, Blacklist: []string{testThird, testOther}})
+	assert.Nil(t, err)
+	expect := []string{testThird, testOther}
+	sort.Strings(expect)
+	assert.Equal(t, expect, env.lottery(lotteryId).Blacklist)
+
+	assert.Equal(t, pty.ErrLotteryAddrBlacklisted, env.buy(PrivKeyB, lotteryId, 1, 1))
+	assert.Equal(t, pty.ErrLotteryAddrBlacklisted, env.buy(PrivKeyD, lotteryId, 1, 1))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+
+	//只有管理地址可以修改, 删除不存在的地址和添加已经存在的地址都是错误
+	assert.Equal(t, pty.ErrNoPrivilege, env.blacklist(PrivKeyA, lotteryId, nil, []string{testOther}))
+	assert.Equal(t, types.ErrInvalidParam, env.blacklist(PrivKeyC, lotteryId, nil, nil))
+	assert.Equal(t, pty.ErrLotteryBlacklistAddr, env.blacklist(PrivKeyC, lotteryId, nil, []string{testBuyer}))
+	assert.Equal(t, pty.ErrLotteryBlacklistAddr, env.blacklist(PrivKeyC, lotteryId, []string{testThird}, nil))
+
+	//购买期间移出黑名单之后可以购买, 加入黑名单之后不能再购买
+	assert.Nil(t, env.blacklist(PrivKeyC, lotteryId, []string{testBuyer}, []string{testOther}))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 1, 2))
+	assert.Equal(t, pty.ErrLotteryAddrBlacklisted, env.buy(PrivKeyA, lotteryId, 1, 3))
+	assert.Equal(t, pty.ErrLotteryAddrBlacklisted, env.buy(PrivKeyD, lotteryId, 1, 3))
+
+	info, err := env.l.Query_GetLotteryNormalInfo(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(t, err)
+	expect = []string{testThird, testBuyer}
+	sort.Strings(expect)
+	assert.Equal(t, expect, info.(*pty.ReplyLotteryNormalInfo).Blacklist)
+
+	//已经购买的记录照常开奖
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(pty.LotteryDrawed), env.lottery(lotteryId).Status)
+}
+
+func (env *execEnv) configHistory(lotteryId string) []*pty.LotteryConfigChange {
+	msg, err := env.l.Query_GetConfigHistory(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	if err == types.ErrNotFound {
+		return nil
+	}
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryConfigHistory).Records
+}
+
+func TestLotteryConfigHistory(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+
+	//没有打开saveConfigHistory 时不保存也不能查询
+	assert.Nil(t, env.blacklist(PrivKeyC, lotteryId, []string{testOther}, nil))
+	_, err = env.l.Query_GetConfigHistory(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Equal(t, types.ErrActionNotSupport, err)
+
+	cfg.SaveConfigHistory = true
+	defer func() {
+		cfg.SaveConfigHistory = false
+	}()
+	assert.Equal(t, 0, len(env.configHistory(lotteryId)))
+	assert.Nil(t, env.blacklist(PrivKeyC, lotteryId, []string{testThird}, []string{testOther}))
+	_, err = env.modify(PrivKeyC, lotteryId, []string{testOther}, nil)
+	assert.Nil(t, err)
+	_, err = env.transfer(PrivKeyC, lotteryId, testBuyer)
+	assert.Nil(t, err)
+
+	//按高度从早到晚排列
+	history := env.configHistory(lotteryId)
+	assert.Equal(t, 3, len(history))
+	assert.Equal(t, int32(pty.LotteryActionBlacklist), history[0].ActionTy)
+	assert.Equal(t, testCreator, history[0].Addr)
+	assert.Equal(t, []*pty.LotteryConfigField{{Name: "blacklist", Remove: []string{testOther}, Add: []string{testThird}}}, history[0].Fields)
+	assert.Equal(t, int32(pty.LotteryActionModify), history[1].ActionTy)
+	assert.Equal(t, []*pty.LotteryConfigField{{Name: "drawers", Add: []string{testOther}}}, history[1].Fields)
+	assert.Equal(t, int32(pty.LotteryActionTransfer), history[2].ActionTy)
+	assert.Equal(t, []*pty.LotteryConfigField{{Name: "admin", Remove: []string{testCreator}, Add: []string{testBuyer}}}, history[2].Fields)
+	for i, change := range history {
+		assert.Equal(t, lotteryId, change.LotteryId)
+		assert.Equal(t, env.height-int64(2-i), change.Height)
+		assert.Equal(t, change.Height*types.MaxTxsPerBlock, change.Index)
+	}
+
+	//回滚时删除最新的修改记录
+	for i := 0; i < 2; i++ {
+		rec := env.history[len(env.history)-1]
+		env.history = env.history[:len(env.history)-1]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		setLocalKVs(t, env.l, set.KV)
+	}
+	history = env.configHistory(lotteryId)
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, int32(pty.LotteryActionBlacklist), history[0].ActionTy)
+}
+
+func TestLotterySafeMath(t *testing.T) {
+	v, err := safeAdd(math.MaxInt64-1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), v)
+	_, err = safeAdd(math.MaxInt64, 1)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+	_, err = safeAdd(math.MinInt64, -1)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+
+	v, err = safeMul(1<<31, 1<<31)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1<<62), v)
+	_, err = safeMul(1<<62, 2)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+	_, err = safeMul(math.MinInt64, -1)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+	_, err = safeMul(math.MaxInt64, decimal)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+
+	//中间结果超过int64 也能得到正确的结果
+	v, err = safeMulDiv(math.MaxInt64, exciting, exciting)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), v)
+	_, err = safeMulDiv(math.MaxInt64, 2, 1)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+	_, err = safeMulDiv(1, 1, 0)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+}
+
+func TestLotteryBuyAmountLimit(t *testing.T) {
+	env := newExecEnv(t)
+	_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MaxBuyPerTx: -1})
+	assert.Equal(t, pty.ErrLotteryBuyAmount, err)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, MaxBuyPerTx: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), env.lottery(lotteryId).MaxBuyPerTx)
+
+	for _, amount := range []int64{-1, 0, 11, 1 << 62, math.MaxInt64} {
+		assert.Equal(t, pty.ErrLotteryBuyAmount, env.buy(PrivKeyA, lotteryId, amount, 1), amount)
+	}
+	//多个号码的总数也不能超过限制
+	items := []*pty.LotteryBuyItem{{Number: 1, Amount: 6, Way: FiveStar}, {Number: 2, Amount: 5, Way: FiveStar}}
+	_, err = env.buyItems(PrivKeyA, lotteryId, items)
+	assert.Equal(t, pty.ErrLotteryBuyAmount, err)
+	assert.Equal(t, testBalance, env.execAccount(testBuyer).Balance)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 10, 1))
+
+	//不限制时按资产的总量检查
+	other, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, pty.ErrLotteryBuyAmount, env.buy(PrivKeyA, other, types.MaxCoin/decimal+1, 1))
+	assert.Equal(t, pty.ErrLotteryBuyAmount, env.buy(PrivKeyA, other, math.MaxInt64, 1))
+
+	//奖池的累计数量溢出时返回错误, 不会回绕
+	lott := &LotteryDB{*env.lottery(lotteryId)}
+	lott.Fund = math.MaxInt64 - 5
+	lott.Save(env.stateDB)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, env.buy(PrivKeyB, lotteryId, 6, 1))
+	assert.Equal(t, int64(math.MaxInt64-5), env.lottery(lotteryId).Fund)
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 5, 1))
+	assert.Equal(t, int64(math.MaxInt64), env.lottery(lotteryId).Fund)
+}
+
+//开奖时奖金的计算溢出, 开奖失败而不是按错误的金额发奖
+func TestLotteryDrawOverflow(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+
+	//每个尾号都买一星, 无论开出什么号码都会中奖
+	lott := &LotteryDB{*env.lottery(lotteryId)}
+	records := lott.Records[testBuyer]
+	records.Record = nil
+	for i := int64(0); i < 10; i++ {
+		records.Record = append(records.Record, &pty.PurchaseRecord{Amount: math.MaxInt64/notbad + 1, Number: i, Way: OneStar, Index: i})
+	}
+	lott.Save(env.stateDB)
+	_, err = env.draw(lotteryId)
+	assert.Equal(t, pty.ErrLotteryAmountOverflow, err)
+	assert.Equal(t, int32(pty.LotteryPurchase), env.lottery(lotteryId).Status)
+}
+
+func (env *execEnv) currentPool(lotteryId string) *pty.ReplyLotteryCurrentPool {
+	msg, err := env.l.Query_GetCurrentPool(&pty.ReqLotteryInfo{LotteryId: lotteryId})
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryCurrentPool)
+}
+
+func TestLotteryCurrentPool(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, &pty.ReplyLotteryCurrentPool{LotteryId: lotteryId, Round: 1}, env.currentPool(lotteryId))
+
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 1))
+	_, err = env.buyItems(PrivKeyB, lotteryId, []*pty.LotteryBuyItem{{Number: 2, Amount: 2, Way: FiveStar}, {Number: 3, Amount: 4, Way: FiveStar}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9), env.currentPool(lotteryId).Amount)
+
+	//开奖之后是下一轮, 还没有购买
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	assert.Equal(t, &pty.ReplyLotteryCurrentPool{LotteryId: lotteryId, Round: 2}, env.currentPool(lotteryId))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 5, 1))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 2, 2))
+	assert.Equal(t, &pty.ReplyLotteryCurrentPool{LotteryId: lotteryId, Round: 2, Amount: 7}, env.currentPool(lotteryId))
+
+	//关闭时退款, 累计数量减去退款
+	assert.Nil(t, env.close(lotteryId))
+	assert.Equal(t, int64(0), env.currentPool(lotteryId).Amount)
+
+	//回滚关闭和最后一笔购买
+	for _, amount := range []int64{7, 5} {
+		rec := env.history[len(env.history)-1]
+		env.history = env.history[:len(env.history)-1]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		setLocalKVs(t, env.l, set.KV)
+		assert.Equal(t, amount, env.l.findLocalInt64(calcLotteryRoundPoolKey(env.l.localPrefix(), lotteryId, 2)))
+	}
+	assert.Equal(t, int64(9), env.l.findLocalInt64(calcLotteryRoundPoolKey(env.l.localPrefix(), lotteryId, 1)))
+}
+
+func (env *execEnv) agentBuy(priv string, lotteryId string, agentAddr string, items []*pty.LotteryBuyItem) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: lotteryId, Items: items, AgentAddr: agentAddr})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func (env *execEnv) agentSales(lotteryId string, agentAddr string, round int64) *pty.LotteryAgentSales {
+	msg, err := env.l.Query_GetAgentSales(&pty.ReqLotteryAgentSales{LotteryId: lotteryId, AgentAddr: agentAddr, Round: round})
+	assert.Nil(env.t, err)
+	return msg.(*pty.LotteryAgentSales)
+}
+
+func TestLotteryAgentCommission(t *testing.T) {
+	env := newExecEnv(t)
+	for _, ratio := range []int64{-1, maxCommission + 1} {
+		_, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CommissionRatio: ratio})
+		assert.Equal(t, pty.ErrLotteryCommissionRatio, err)
+	}
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CommissionRatio: 10, ForbidSelfDealing: true})
+	assert.Nil(t, err)
+
+	_, err = env.agentBuy(PrivKeyA, lotteryId, testBuyer, []*pty.LotteryBuyItem{{Number: 1, Amount: 10, Way: FiveStar}})
+	assert.Equal(t, pty.ErrLotteryAgentAddr, err)
+	_, err = env.agentBuy(PrivKeyA, lotteryId, "notanaddress", []*pty.LotteryBuyItem{{Number: 1, Amount: 10, Way: FiveStar}})
+	assert.Equal(t, pty.ErrLotteryAgentAddr, err)
+
+	//10张的佣金为1张, 剩下的进入奖池
+	receipt, err := env.agentBuy(PrivKeyA, lotteryId, testThird, []*pty.LotteryBuyItem{{Number: 1, Amount: 10, Way: FiveStar}})
+	assert.Nil(t, err)
+	var buyLog pty.ReceiptLottery
+	assert.Nil(t, types.Decode(findLogs(receipt, pty.TyLogLotteryBuy)[0].Log, &buyLog))
+	assert.Equal(t, testThird, buyLog.AgentAddr)
+	assert.Equal(t, int64(1), buyLog.Commission)
+	assert.Equal(t, testBalance-10*decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance+decimal, env.execAccount(testThird).Balance)
+	assert.Equal(t, int64(9*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, int64(9), env.lottery(lotteryId).Fund)
+
+	//每个号码单独计算佣金, 5张不足1张佣金
+	_, err = env.agentBuy(PrivKeyB, lotteryId, testThird, []*pty.LotteryBuyItem{{Number: 2, Amount: 5, Way: FiveStar}, {Number: 3, Amount: 15, Way: FiveStar}})
+	assert.Nil(t, err)
+	assert.Equal(t, testBalance+2*decimal, env.execAccount(testThird).Balance)
+	assert.Equal(t, int64(28*decimal), env.prizePool(lotteryId))
+	//没有代理的购买不付佣金
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 4, 4))
+	assert.Equal(t, int64(32*decimal), env.prizePool(lotteryId))
+
+	expected := &pty.LotteryAgentSales{LotteryId: lotteryId, AgentAddr: testThird, Round: 1, Amount: 30, Commission: 2, TxNum: 2}
+	assert.Equal(t, expected, env.agentSales(lotteryId, testThird, 1))
+	expected.Round = 0
+	assert.Equal(t, expected, env.agentSales(lotteryId, testThird, 0))
+	assert.Equal(t, &pty.LotteryAgentSales{LotteryId: lotteryId, AgentAddr: testOther, Round: 1}, env.agentSales(lotteryId, testOther, 1))
+
+	//回滚一笔代理购买
+	rec := env.history[len(env.history)-2]
+	set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	expected = &pty.LotteryAgentSales{LotteryId: lotteryId, AgentAddr: testThird, Round: 1, Amount: 10, Commission: 1, TxNum: 1}
+	assert.Equal(t, expected, env.agentSales(lotteryId, testThird, 1))
+	setLocalKVs(t, env.l, rec.local.KV)
+
+	//关闭时退款不包括已经付给代理的佣金, 佣金不退还
+	assert.Equal(t, int64(34), env.lottery(lotteryId).TotalSales)
+	assert.Nil(t, env.close(lotteryId))
+	//销售额减去的是购买数量, 不受佣金影响
+	assert.Equal(t, int64(0), env.lottery(lotteryId).TotalSales)
+	assert.Equal(t, int64(0), env.prizePool(lotteryId))
+	assert.Equal(t, testBalance-decimal, env.execAccount(testBuyer).Balance)
+	assert.Equal(t, testBalance-decimal, env.execAccount(testOther).Balance)
+	assert.Equal(t, testBalance+2*decimal, env.execAccount(testThird).Balance)
+	assert.Equal(t, int64(2), env.agentSales(lotteryId, testThird, 0).Commission)
+}
+
+//没有设置佣金比例时, 通过代理购买只记录销售数量
+func TestLotteryAgentNoCommission(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	//没有禁止时代理可以是购买地址本身
+	_, err = env.agentBuy(PrivKeyA, lotteryId, testBuyer, []*pty.LotteryBuyItem{{Number: 1, Amount: 10, Way: FiveStar}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10*decimal), env.prizePool(lotteryId))
+	assert.Equal(t, &pty.LotteryAgentSales{LotteryId: lotteryId, AgentAddr: testBuyer, Round: 1, Amount: 10, TxNum: 1}, env.agentSales(lotteryId, testBuyer, 1))
+	_, err = env.l.Query_GetAgentSales(&pty.ReqLotteryAgentSales{LotteryId: lotteryId})
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
+
+//localdb 中当前执行器的全部数据, 用于比较回滚前后的状态
+func (env *execEnv) localSnapshot() map[string]string {
+	it := env.l.GetLocalDB().(*dbm.KVDBList).DB.Iterator([]byte(env.l.localPrefix()), nil, false)
+	defer it.Close()
+	snapshot := make(map[string]string)
+	for it.Rewind(); it.Valid(); it.Next() {
+		snapshot[string(it.Key())] = string(it.Value())
+	}
+	return snapshot
+}
+
+//创建, 购买, 开奖, 关闭退款之后逐个区块回滚, 每回滚一个区块localdb 都和执行之前完全相同,
+//全部回滚之后统计, 奖池和排行榜的计数都回到创建之前的状态
+func TestLotteryReorgCounters(t *testing.T) {
+	env := newExecEnv(t)
+	snapshots := []map[string]string{env.localSnapshot()}
+	step := func(err error) {
+		assert.Nil(t, err)
+		assert.Equal(t, len(env.history), len(snapshots))
+		snapshots = append(snapshots, env.localSnapshot())
+	}
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CreatorFeeRatio: 10, CommissionRatio: 10})
+	step(err)
+	genesis := struct {
+		stats   *pty.LotteryStats
+		tickets []*pty.LotteryBoardEntry
+		won     []*pty.LotteryBoardEntry
+	}{env.stats(lotteryId), env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0), env.leaderboard(lotteryId, pty.LotteryBoardWinnings, 0)}
+
+	lucky := env.predictLuckyNum(4, 40)
+	env.buyWay(PrivKeyA, lotteryId, 10, lucky, OneStar)
+	step(nil)
+	env.buyWay(PrivKeyB, lotteryId, 20, lucky, TwoStar)
+	step(nil)
+	_, err = env.agentBuy(PrivKeyD, lotteryId, testThird, []*pty.LotteryBuyItem{{Number: lucky, Amount: 5, Way: FiveStar}})
+	step(err)
+	nonce := []byte("nonce")
+	step(env.blindBuy(PrivKeyA, lotteryId, 3, lucky, nonce))
+	_, err = env.revealNumber(PrivKeyA, lotteryId, lucky, nonce)
+	step(err)
+	_, err = env.draw(lotteryId)
+	step(err)
+	assert.Equal(t, lucky, env.lottery(lotteryId).LuckyNumber)
+	assert.True(t, len(env.leaderboard(lotteryId, pty.LotteryBoardWinnings, 0)) > 0)
+
+	//第二轮购买之后关闭退款
+	env.buyWay(PrivKeyB, lotteryId, 4, 1, FiveStar)
+	step(nil)
+	_, err = env.agentBuy(PrivKeyA, lotteryId, testThird, []*pty.LotteryBuyItem{{Number: 2, Amount: 6, Way: FiveStar}})
+	step(err)
+	step(env.close(lotteryId))
+	assert.Equal(t, int32(pty.LotteryClosed), env.lottery(lotteryId).Status)
+
+	for i := len(env.history) - 1; i >= 0; i-- {
+		rec := env.history[i]
+		set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+		assert.Nil(t, err)
+		setLocalKVs(t, env.l, set.KV)
+		assert.Equal(t, snapshots[i], env.localSnapshot(), "rollback tx %d", i)
+		if i == 1 {
+			//状态数据库没有回滚, 回滚到只剩创建交易时查询彩票的计数
+			assert.Equal(t, genesis.stats, env.stats(lotteryId))
+			assert.Equal(t, genesis.tickets, env.leaderboard(lotteryId, pty.LotteryBoardSpent, 0))
+			assert.Equal(t, genesis.won, env.leaderboard(lotteryId, pty.LotteryBoardWinnings, 0))
+			for _, addr := range []string{testBuyer, testOther, testThird} {
+				assert.Equal(t, &pty.LotteryAddrWinnings{LotteryId: lotteryId, Addr: addr}, env.winnings(lotteryId, addr))
+			}
+			for round := int64(1); round <= 2; round++ {
+				assert.Equal(t, int64(0), env.l.findLocalInt64(calcLotteryRoundPoolKey(env.l.localPrefix(), lotteryId, round)))
+				assert.Equal(t, 0, len(env.numberHeat(lotteryId, round)))
+			}
+			assert.Equal(t, &pty.LotteryAgentSales{LotteryId: lotteryId, AgentAddr: testThird}, env.agentSales(lotteryId, testThird, 0))
+		}
+	}
+	assert.Equal(t, 0, len(env.localSnapshot()))
+}
+
+func (env *execEnv) transferTicket(priv string, lotteryId string, index int64, to string) (*types.Receipt, error) {
+	tx, err := pty.CreateRawLotteryTransferTicketTx(&pty.LotteryTransferTicketTx{LotteryId: lotteryId, Index: index, To: to})
+	assert.Nil(env.t, err)
+	return env.exec(tx, priv)
+}
+
+func TestLotteryTransferTicket(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, OpPurchaseLimit: 10})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 5))
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 3, 6))
+	assert.Nil(t, env.buy(PrivKeyB, lotteryId, 9, 7))
+	records := env.buyRecords(lotteryId, testBuyer, 1)
+	assert.Equal(t, 2, len(records))
+	//localdb 按key 倒序返回, 按号码找到第一笔购买
+	var index int64
+	for _, record := range records {
+		if record.Number == 5 {
+			index = record.Index
+		}
+	}
+	assert.NotEqual(t, int64(0), index)
+
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index+1, testThird)
+	assert.Equal(t, pty.ErrLotteryTicketNotFound, err)
+	_, err = env.transferTicket(PrivKeyD, lotteryId, index, testOther)
+	assert.Equal(t, pty.ErrLotteryTicketNotFound, err)
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index, testBuyer)
+	assert.Equal(t, pty.ErrLotteryTransferAddr, err)
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index, "invalid")
+	assert.Equal(t, pty.ErrLotteryTransferAddr, err)
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index, testCreator)
+	assert.Equal(t, pty.ErrLotteryCreatorBuy, err)
+	//接收地址本轮已经买了9张, 再转入2张超过限制
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index, testOther)
+	assert.Equal(t, pty.ErrLotteryPurchaseLimit, err)
+
+	before := env.localSnapshot()
+	receipt, err := env.transferTicket(PrivKeyA, lotteryId, index, testThird)
+	assert.Nil(t, err)
+	logs := findLogs(receipt, pty.TyLogLotteryTicketTransfer)
+	assert.Equal(t, 1, len(logs))
+	var transferlog pty.ReceiptLotteryTicketTransfer
+	assert.Nil(t, types.Decode(logs[0].Log, &transferlog))
+	assert.Equal(t, testBuyer, transferlog.From)
+	assert.Equal(t, testThird, transferlog.To)
+	assert.Equal(t, index, transferlog.Index)
+	assert.Equal(t, int64(5), transferlog.Number)
+	assert.Equal(t, int64(2), transferlog.Amount)
+
+	lott := env.lottery(lotteryId)
+	assert.Equal(t, int64(3), lott.Records[testBuyer].AmountOneRound)
+	assert.Equal(t, 1, len(lott.Records[testBuyer].Record))
+	assert.Equal(t, int64(2), lott.Records[testThird].AmountOneRound)
+	assert.Equal(t, index, lott.Records[testThird].Record[0].Index)
+	//购买记录移到新的地址, 交易hash 仍然是原来的购买交易
+	records = env.buyRecords(lotteryId, testThird, 1)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, index, records[0].Index)
+	assert.Equal(t, int64(5), records[0].Number)
+	assert.Equal(t, 1, len(env.buyRecords(lotteryId, testBuyer, 1)))
+	assert.Equal(t, 1, len(env.buyEntries(lotteryId, testThird)))
+	reply, err := env.buyByTxHash(lotteryId, records[0].TxHash)
+	assert.Nil(t, err)
+	if assert.NotNil(t, reply) {
+		assert.Equal(t, 0, len(reply.Records))
+	}
+
+	//已经转出的记录不能再次转让
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index, testOther)
+	assert.Equal(t, pty.ErrLotteryTicketNotFound, err)
+
+	//回滚之后购买记录回到原来的地址
+	rec := env.history[len(env.history)-1]
+	set, err := env.l.ExecDelLocal(rec.tx, rec.receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, before, env.localSnapshot())
+	set, err = env.l.ExecLocal(rec.tx, rec.receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+
+	//开奖之后本轮的购买记录都有了结果, 下一轮也不能转让上一轮的记录
+	_, err = env.draw(lotteryId)
+	assert.Nil(t, err)
+	_, err = env.transferTicket(PrivKeyD, lotteryId, index, testOther)
+	assert.Equal(t, pty.ErrLotteryTicketResolved, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 1, 1))
+	_, err = env.transferTicket(PrivKeyD, lotteryId, index, testOther)
+	assert.Equal(t, pty.ErrLotteryTicketResolved, err)
+}
+
+func TestLotteryTransferRefundedTicket(t *testing.T) {
+	env := newExecEnv(t)
+	lotteryId, err := env.create(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Nil(t, env.buy(PrivKeyA, lotteryId, 2, 5))
+	index := env.buyRecords(lotteryId, testBuyer, 1)[0].Index
+	assert.Nil(t, env.close(lotteryId))
+	_, err = env.transferTicket(PrivKeyA, lotteryId, index, testOther)
+	assert.Equal(t, pty.ErrLotteryTicketResolved, err)
+}
@@ -16,8 +16,9 @@ import (
 const retryNum = 10
 
 //different impl on main chain and parachain
-func (action *Action) getTxActions(height int64, blockNum int64) ([]*tickettypes.TicketAction, error) {
+func (action *Action) getTxActions(height int64, blockNum int64) ([]*tickettypes.TicketAction, []int64, error) {
 	var txActions []*tickettypes.TicketAction
+	var heights []int64
 	llog.Error("getTxActions", "height", height, "blockNum", blockNum)
 	if !types.IsPara() {
 		req := &types.ReqBlocks{height - blockNum + 1, height, false, []string{""}}
@@ -25,39 +26,41 @@ func (action *Action) getTxActions(height int64, blockNum int64) ([]*tickettypes
 		blockDetails, err := action.api.GetBlocks(req)
 		if err != nil {
 			llog.Error("getTxActions", "height", height, "blockNum", blockNum, "err", err)
-			return txActions, err
+			return txActions, heights, err
 		}
 		for _, block := range blockDetails.Items {
 			llog.Debug("getTxActions", "blockHeight", block.Block.Height, "blockhash", block.Block.Hash())
 			ticketAction, err := action.getMinerTx(block.Block)
 			if err != nil {
-				return txActions, err
+				return txActions, heights, err
 			}
 			txActions = append(txActions, ticketAction)
+			heights = append(heights, block.Block.Height)
 		}
-		return txActions, nil
+		return txActions, heights, nil
 	} else {
 		//block height on main
 		mainHeight := action.GetMainHeightByTxHash(action.txhash)
 		if mainHeight < 0 {
 			llog.Error("LotteryCreate", "mainHeight", mainHeight)
-			return nil, pty.ErrLotteryStatus
+			return nil, nil, pty.ErrLotteryStatus
 		}
 
 		blockDetails, err := action.GetBlocksOnMain(mainHeight-blockNum, mainHeight-1)
 		if err != nil {
 			llog.Error("LotteryCreate", "mainHeight", mainHeight)
-			return nil, pty.ErrLotteryStatus
+			return nil, nil, pty.ErrLotteryStatus
 		}
 
 		for _, block := range blockDetails.Items {
 			ticketAction, err := action.getMinerTx(block.Block)
 			if err != nil {
-				return txActions, err
+				return txActions, heights, err
 			}
 			txActions = append(txActions, ticketAction)
+			heights = append(heights, block.Block.Height)
 		}
-		return txActions, nil
+		return txActions, heights, nil
 	}
 }
 
@@ -5,17 +5,22 @@
 package executor
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"sort"
-	"strconv"
+	"strings"
 
 	"github.com/33cn/chain33/account"
 	"github.com/33cn/chain33/client"
 	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/address"
 	dbm "github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
 	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	tokenty "github.com/33cn/plugin/plugin/dapp/token/types"
 	"google.golang.org/grpc"
 )
 
@@ -27,14 +32,29 @@ const (
 )
 
 const (
-	minPurBlockNum  = 30
-	minDrawBlockNum = 40
+	minPurBlockNum    = 30
+	minDrawBlockNum   = 40
+	maxFeeRatio       = 20  //创建者最多从每轮销售额中分成20%
+	maxCommission     = 20  //代理最多从每笔购买中分成20%
+	maxBuyItems       = 100 //一笔交易最多购买100个号码
+	minRevealBlockNum = 2
+	maxDrawReward     = 5  //超过开奖期限之后开奖的地址最多从本轮销售额中获得5%
+	maxDrawers        = 10 //创建者以外最多10个开奖地址
+	maxBlacklist      = 1000
+
+	defaultReclaimBlockNum = 1000 //没有设置时关闭之后等待1000个区块才能回收奖池
 )
 
 const (
 	creatorKey = "lottery-creator"
 )
 
+//购买中关闭时每笔交易最多给多少个地址退款, 剩下的地址由后续的关闭交易继续处理
+var maxRefundsPerClose = 200
+
+//排行榜每种排名保存的地址数量
+var maxBoardSize = 100
+
 const (
 	ListDESC    = int32(0)
 	ListASC     = int32(1)
@@ -49,12 +69,64 @@ const (
 	OneStar   = 1
 )
 
+//彩票状态机, 每种操作允许的当前状态:
+//创建之后可以购买或者关闭, 购买期间可以继续购买, 开奖或者关闭, 开奖之后可以开始下一轮购买或者关闭, 关闭之后不能再操作
+var lotteryTransitions = map[int32]map[int32]bool{
+	pty.LotteryActionBuy:            {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true},
+	pty.LotteryActionDraw:           {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true, pty.LotteryCommitted: true},
+	pty.LotteryActionClose:          {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true, pty.LotteryCommitted: true},
+	pty.LotteryActionCommit:         {pty.LotteryPurchase: true},
+	pty.LotteryActionReveal:         {pty.LotteryCommitted: true},
+	pty.LotteryActionRevealNumber:   {pty.LotteryPurchase: true, pty.LotteryCommitted: true},
+	pty.LotteryActionModify:         {pty.LotteryCreated: true, pty.LotteryDrawed: true},
+	pty.LotteryActionTransfer:       {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true, pty.LotteryCommitted: true},
+	pty.LotteryActionReclaim:        {pty.LotteryClosed: true},
+	pty.LotteryActionBlacklist:      {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true, pty.LotteryCommitted: true},
+	pty.LotteryActionClaim:          {pty.LotteryPurchase: true, pty.LotteryDrawed: true, pty.LotteryClosed: true, pty.LotteryCommitted: true},
+	pty.LotteryActionTransferTicket: {pty.LotteryPurchase: true, pty.LotteryCommitted: true},
+}
+
+//ForkLotteryState 之前只有这三种操作检查状态, 返回ErrLotteryStatus
+var legacyLotteryTransitions = map[int32]map[int32]bool{
+	pty.LotteryActionBuy:   {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true},
+	pty.LotteryActionDraw:  {pty.LotteryPurchase: true},
+	pty.LotteryActionClose: {pty.LotteryCreated: true, pty.LotteryPurchase: true, pty.LotteryDrawed: true},
+}
+
+func checkLotteryTransition(status int32, actionTy int32) error {
+	if !lotteryTransitions[actionTy][status] {
+		llog.Error("checkLotteryTransition", "status", status, "action", actionTy)
+		return pty.ErrLotteryInvalidState
+	}
+	return nil
+}
+
+//checkTransition 按交易所在的高度选择状态机, 升级之前的区块重新执行时结果不变
+func (action *Action) checkTransition(status int32, actionTy int32) error {
+	if !types.IsDappFork(action.height, pty.LotteryX, "ForkLotteryState") {
+		if states, ok := legacyLotteryTransitions[actionTy]; ok {
+			if !states[status] {
+				llog.Error("checkTransition", "status", status, "action", actionTy, "height", action.height)
+				return pty.ErrLotteryStatus
+			}
+			return nil
+		}
+	}
+	return checkLotteryTransition(status, actionTy)
+}
+
+//自定义奖级时prizeRatio 依次对应的奖级
+var prizeTiers = []int64{FiveStar, ThreeStar, TwoStar, OneStar}
+
 //const defaultAddrPurTimes = 10
-const luckyNumMol = 100000
+const luckyNumMol = pty.LotteryLuckyNumMol
 const decimal = 100000000 //1e8
 const randMolNum = 5
 const grpcRecSize int = 5 * 30 * 1024 * 1024
 const blockNum = 5
+const tokenX = "token"
+const tokenKeyPrefix = "mavl-token-"
+const paraX = "paracross"
 
 type LotteryDB struct {
 	pty.Lottery
@@ -99,6 +171,11 @@ func Key(id string) (key []byte) {
 	return key
 }
 
+//中奖地址在一轮中锁定的奖金
+func payoutKey(id string, round int64, addr string) []byte {
+	return []byte(fmt.Sprintf("mavl-%s-payout-%s-%d-%s", pty.LotteryX, id, round, addr))
+}
+
 type Action struct {
 	coinsAccount *account.DB
 	db           dbm.KV
@@ -140,6 +217,7 @@ func (action *Action) GetReceiptLog(lottery *pty.Lottery, preStatus int32, logTy
 	l.LotteryId = lottery.LotteryId
 	l.Status = lottery.Status
 	l.PrevStatus = preStatus
+	l.CreateHeight = lottery.CreateHeight
 	if logTy == pty.TyLogLotteryBuy {
 		l.Round = round
 		l.Number = buyNumber
@@ -150,15 +228,31 @@ func (action *Action) GetReceiptLog(lottery *pty.Lottery, preStatus int32, logTy
 		l.Time = action.blocktime
 		l.TxHash = common.ToHex(action.txhash)
 	}
+	//开奖和关闭时amount为本轮的销售总额
 	if logTy == pty.TyLogLotteryDraw {
 		l.Round = round
+		l.Amount = amount
 		l.LuckyNumber = luckyNum
+		l.Rollover = lottery.RolloverPool
 		l.Time = action.blocktime
 		l.TxHash = common.ToHex(action.txhash)
 		if len(updateInfo.BuyInfo) > 0 {
 			l.UpdateInfo = updateInfo
 		}
 	}
+	if logTy == pty.TyLogLotteryCommit {
+		l.Round = round
+		l.Addr = action.fromaddr
+		l.Time = action.blocktime
+		l.TxHash = common.ToHex(action.txhash)
+	}
+	if logTy == pty.TyLogLotteryClose {
+		l.Round = round
+		l.Amount = amount
+		l.Rollover = lottery.RolloverPool
+		l.Time = action.blocktime
+		l.TxHash = common.ToHex(action.txhash)
+	}
 
 	log.Log = types.Encode(l)
 	return log
@@ -180,16 +274,13 @@ func (action *Action) LotteryCreate(create *pty.LotteryCreate) (*types.Receipt,
 		return nil, pty.ErrNoPrivilege
 	}
 
-	if create.GetPurBlockNum() < minPurBlockNum {
-		return nil, pty.ErrLotteryPurBlockLimit
-	}
-
-	if create.GetDrawBlockNum() < minDrawBlockNum {
-		return nil, pty.ErrLotteryDrawBlockLimit
+	if err := CheckLotteryCreate(create); err != nil {
+		return nil, err
 	}
 
-	if create.GetPurBlockNum() > create.GetDrawBlockNum() {
-		return nil, pty.ErrLotteryDrawBlockLimit
+	if create.GetAssetExec() != paraX && create.GetTokenSymbol() != "" && !isTokenExist(action.db, create.GetTokenSymbol()) {
+		llog.Error("LotteryCreate", "tokenSymbol", create.GetTokenSymbol())
+		return nil, pty.ErrLotteryTokenNotExist
 	}
 
 	_, err := findLottery(action.db, lotteryId)
@@ -200,6 +291,31 @@ func (action *Action) LotteryCreate(create *pty.LotteryCreate) (*types.Receipt,
 
 	lott := NewLotteryDB(lotteryId, create.GetPurBlockNum(),
 		create.GetDrawBlockNum(), action.height, action.fromaddr)
+	lott.OpPurchaseLimit = create.GetOpPurchaseLimit()
+	lott.CreatorFeeRatio = create.GetCreatorFeeRatio()
+	lott.PrizeRatio = create.GetPrizeRatio()
+	lott.MaxRounds = create.GetMaxRounds()
+	lott.RevealBlockNum = create.GetRevealBlockNum()
+	lott.RevealTimeout = create.GetRevealTimeout()
+	lott.TimeoutRefund = create.GetTimeoutRefund()
+	lott.RolloverToBuyers = create.GetRolloverToBuyers()
+	lott.TokenSymbol = create.GetTokenSymbol()
+	lott.AssetExec = create.GetAssetExec()
+	lott.DrawDeadlineBlocks = create.GetDrawDeadlineBlocks()
+	lott.DrawRewardRatio = create.GetDrawRewardRatio()
+	lott.MinPurchaseNum = create.GetMinPurchaseNum()
+	lott.MinSalesAmount = create.GetMinSalesAmount()
+	lott.MaxWaitBlocks = create.GetMaxWaitBlocks()
+	lott.RefundBelowMin = create.GetRefundBelowMin()
+	lott.Drawers = create.GetDrawers()
+	lott.ReclaimBlockNum = create.GetReclaimBlockNum()
+	lott.Blacklist = sortedBlacklist(create.GetBlacklist())
+	lott.MaxBuyPerTx = create.GetMaxBuyPerTx()
+	lott.CommissionRatio = create.GetCommissionRatio()
+	lott.ForbidSelfDealing = create.GetForbidSelfDealing()
+	lott.Weighted = create.GetWeighted()
+	lott.PayoutMaturity = create.GetPayoutMaturity()
+	lott.EscrowAddr = escrowAddress(lotteryId)
 
 	if types.IsPara() {
 		mainHeight := action.GetMainHeightByTxHash(action.txhash)
@@ -237,9 +353,18 @@ func (action *Action) LotteryBuy(buy *pty.LotteryBuy) (*types.Receipt, error) {
 	lott := &LotteryDB{*lottery}
 	preStatus := lott.Status
 
-	if lott.Status == pty.LotteryClosed {
-		llog.Error("LotteryBuy", "status", lott.Status)
-		return nil, pty.ErrLotteryStatus
+	if err := action.checkTransition(lott.Status, pty.LotteryActionBuy); err != nil {
+		return nil, err
+	}
+
+	if isBlacklisted(lott, action.fromaddr) {
+		llog.Error("LotteryBuy", "blacklisted", action.fromaddr)
+		return nil, pty.ErrLotteryAddrBlacklisted
+	}
+
+	if lott.Closing {
+		llog.Error("LotteryBuy", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
 	}
 
 	if lott.Status == pty.LotteryDrawed {
@@ -251,6 +376,10 @@ func (action *Action) LotteryBuy(buy *pty.LotteryBuy) (*types.Receipt, error) {
 	}
 
 	if lott.Status == pty.LotteryCreated || lott.Status == pty.LotteryDrawed {
+		if lott.MaxRounds > 0 && lott.Round >= lott.MaxRounds {
+			llog.Error("LotteryBuy", "round", lott.Round, "maxRounds", lott.MaxRounds)
+			return nil, pty.ErrLotteryMaxRounds
+		}
 		llog.Debug("LotteryBuy switch to purchasestate")
 		lott.LastTransToPurState = action.height
 		lott.Status = pty.LotteryPurchase
@@ -265,37 +394,54 @@ func (action *Action) LotteryBuy(buy *pty.LotteryBuy) (*types.Receipt, error) {
 		}
 	}
 
+	//购买期结束之后到开奖之前不能购买, 不会把购买算到下一轮
 	if lott.Status == pty.LotteryPurchase {
-		if types.IsPara() {
-			mainHeight := action.GetMainHeightByTxHash(action.txhash)
-			if mainHeight < 0 {
-				llog.Error("LotteryBuy", "mainHeight", mainHeight)
-				return nil, pty.ErrLotteryStatus
-			}
-			if mainHeight-lott.LastTransToPurStateOnMain > lott.GetPurBlockNum() {
-				llog.Error("LotteryBuy", "action.height", action.height, "mainHeight", mainHeight, "LastTransToPurStateOnMain", lott.LastTransToPurStateOnMain)
-				return nil, pty.ErrLotteryStatus
-			}
-		} else {
-			if action.height-lott.LastTransToPurState > lott.GetPurBlockNum() {
-				llog.Error("LotteryBuy", "action.height", action.height, "LastTransToPurState", lott.LastTransToPurState)
+		elapsed, err := action.purchaseElapsed(lott)
+		if err != nil {
+			return nil, err
+		}
+		if elapsed > lott.GetPurBlockNum() {
+			llog.Error("LotteryBuy", "action.height", action.height, "elapsed", elapsed, "purBlockNum", lott.GetPurBlockNum())
+			if !types.IsDappFork(action.height, pty.LotteryX, "ForkLotteryBuyWindow") {
 				return nil, pty.ErrLotteryStatus
 			}
+			return nil, pty.ErrLotteryPurchasePeriodExpired
 		}
 	}
 
-	if lott.CreateAddr == action.fromaddr {
+	if lott.CreateAddr == action.fromaddr || lotteryAdmin(lott) == action.fromaddr {
 		return nil, pty.ErrLotteryCreatorBuy
 	}
 
-	if buy.GetAmount() <= 0 {
-		llog.Error("LotteryBuy", "buyAmount", buy.GetAmount())
-		return nil, pty.ErrLotteryBuyAmount
+	items, amount, err := action.checkBuyItems(lott, buy)
+	if err != nil {
+		return nil, err
+	}
+	if err := action.checkBuyAgent(lott, buy); err != nil {
+		return nil, err
+	}
+	var commission int64
+	for _, item := range items {
+		commission += itemCommission(lott, buy, item.Amount)
+	}
+
+	accDB, err := action.assetAccount(lott)
+	if err != nil {
+		return nil, err
 	}
+	precision := assetPrecision(lott)
 
-	if buy.GetNumber() < 0 || buy.GetNumber() >= luckyNumMol {
-		llog.Error("LotteryBuy", "buyNumber", buy.GetNumber())
-		return nil, pty.ErrLotteryBuyNumber
+	//每轮开奖之后records会被清空, 购买数量重新计算
+	if lott.OpPurchaseLimit > 0 {
+		var bought int64
+		if record, ok := lott.Records[action.fromaddr]; ok {
+			bought = record.AmountOneRound
+		}
+		total, err := safeAdd(bought, amount)
+		if err != nil || total > lott.OpPurchaseLimit {
+			llog.Error("LotteryBuy", "bought", bought, "buyAmount", amount, "opPurchaseLimit", lott.OpPurchaseLimit)
+			return nil, pty.ErrLotteryPurchaseLimit
+		}
 	}
 
 	if lott.Records == nil {
@@ -303,61 +449,199 @@ func (action *Action) LotteryBuy(buy *pty.LotteryBuy) (*types.Receipt, error) {
 		lott.Records = make(map[string]*pty.PurchaseRecords)
 	}
 
-	newRecord := &pty.PurchaseRecord{buy.GetAmount(), buy.GetNumber(), action.GetIndex(), buy.GetWay()}
-	llog.Debug("LotteryBuy", "amount", buy.GetAmount(), "number", buy.GetNumber())
-
-	/**********
-	Once ExecTransfer succeed, ExecFrozen succeed, no roolback needed
-	**********/
-
-	receipt, err := action.coinsAccount.ExecTransfer(action.fromaddr, lott.CreateAddr, action.execaddr, buy.GetAmount()*decimal)
+	//佣金直接付给代理, 不进入奖池
+	fund, err := safeAdd(lott.Fund, amount-commission)
 	if err != nil {
-		llog.Error("LotteryBuy.ExecTransfer", "addr", action.fromaddr, "execaddr", action.execaddr, "amount", buy.GetAmount())
+		llog.Error("LotteryBuy", "fund", lott.Fund, "buyAmount", amount)
 		return nil, err
 	}
-	logs = append(logs, receipt.Logs...)
-	kv = append(kv, receipt.KV...)
-
-	receipt, err = action.coinsAccount.ExecFrozen(lott.CreateAddr, action.execaddr, buy.GetAmount()*decimal)
-
+	totalSales, err := safeAdd(lott.TotalSales, amount)
+	if err != nil {
+		llog.Error("LotteryBuy", "totalSales", lott.TotalSales, "buyAmount", amount)
+		return nil, err
+	}
+	deposit, err := safeMul(amount-commission, precision)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := action.depositPool(accDB, lott, deposit)
 	if err != nil {
-		llog.Error("LotteryBuy.Frozen", "addr", lott.CreateAddr, "execaddr", action.execaddr, "amount", buy.GetAmount())
+		llog.Error("LotteryBuy.depositPool", "addr", action.fromaddr, "execaddr", action.execaddr, "amount", amount)
 		return nil, err
 	}
 	logs = append(logs, receipt.Logs...)
 	kv = append(kv, receipt.KV...)
+	if commission > 0 {
+		receipt, err := accDB.ExecTransfer(action.fromaddr, buy.AgentAddr, action.execaddr, commission*precision)
+		if err != nil {
+			llog.Error("LotteryBuy.commission", "addr", action.fromaddr, "agent", buy.AgentAddr, "commission", commission)
+			return nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kv = append(kv, receipt.KV...)
+	}
 
-	lott.Fund += buy.GetAmount()
+	lott.Fund = fund
+	lott.TotalSales = totalSales
 
-	if record, ok := lott.Records[action.fromaddr]; ok {
-		record.Record = append(record.Record, newRecord)
-	} else {
-		initrecord := &pty.PurchaseRecords{}
-		initrecord.Record = append(initrecord.Record, newRecord)
-		initrecord.FundWin = 0
-		initrecord.AmountOneRound = 0
-		lott.Records[action.fromaddr] = initrecord
+	if _, ok := lott.Records[action.fromaddr]; !ok {
+		lott.Records[action.fromaddr] = &pty.PurchaseRecords{}
 	}
-	lott.Records[action.fromaddr].AmountOneRound += buy.Amount
+	for _, item := range items {
+		newRecord := &pty.PurchaseRecord{Amount: item.Amount, Number: item.Number, Index: item.Index, Way: item.Way, CommitHash: buy.CommitHash,
+			Commission: itemCommission(lott, buy, item.Amount)}
+		llog.Debug("LotteryBuy", "amount", item.Amount, "number", item.Number)
+		lott.Records[action.fromaddr].Record = append(lott.Records[action.fromaddr].Record, newRecord)
+	}
+	lott.Records[action.fromaddr].AmountOneRound += amount
 	lott.TotalPurchasedTxNum++
 
 	lott.Save(action.db)
 	kv = append(kv, lott.GetKVSet()...)
 
-	receiptLog := action.GetReceiptLog(&lott.Lottery, preStatus, pty.TyLogLotteryBuy, lott.Round, buy.GetNumber(), buy.GetAmount(), buy.GetWay(), 0, nil)
+	receiptLog := action.GetBuyReceiptLog(&lott.Lottery, preStatus, lott.Round, items, buy, commission)
 	logs = append(logs, receiptLog)
 
 	receipt = &types.Receipt{types.ExecOk, kv, logs}
 	return receipt, nil
 }
 
+//checkBuyAgent 代理地址必须合法, 禁止自营的彩票代理不能是购买地址本身
+func (action *Action) checkBuyAgent(lott *LotteryDB, buy *pty.LotteryBuy) error {
+	if buy.GetAgentAddr() == "" {
+		return nil
+	}
+	if address.CheckAddress(buy.GetAgentAddr()) != nil {
+		llog.Error("LotteryBuy", "agentAddr", buy.GetAgentAddr())
+		return pty.ErrLotteryAgentAddr
+	}
+	if lott.ForbidSelfDealing && buy.GetAgentAddr() == action.fromaddr {
+		llog.Error("LotteryBuy", "self dealing", action.fromaddr)
+		return pty.ErrLotteryAgentAddr
+	}
+	return nil
+}
+
+//每个号码单独计算佣金, 和创建者的分成一样按购买数量向下取整
+func itemCommission(lott *LotteryDB, buy *pty.LotteryBuy, amount int64) int64 {
+	if buy.GetAgentAddr() == "" {
+		return 0
+	}
+	return amount * lott.CommissionRatio / 100
+}
+
+//退款时扣除已经付给代理的佣金
+func refundAmount(record *pty.PurchaseRecords) int64 {
+	refund := record.AmountOneRound
+	for _, rec := range record.Record {
+		refund -= rec.Commission
+	}
+	return refund
+}
+
+//checkBuyItems 检查本次购买的所有号码, 任何一个不合法整笔交易失败
+//items 为空时按旧的单个号码字段处理
+func (action *Action) checkBuyItems(lott *LotteryDB, buy *pty.LotteryBuy) ([]*pty.LotteryBuyItem, int64, error) {
+	if err := checkBuyCommit(buy); err != nil {
+		llog.Error("LotteryBuy", "commitHash", common.ToHex(buy.GetCommitHash()), "items", len(buy.GetItems()))
+		return nil, 0, err
+	}
+	items := buy.GetItems()
+	if len(items) == 0 {
+		items = []*pty.LotteryBuyItem{{Number: buy.GetNumber(), Amount: buy.GetAmount(), Way: buy.GetWay()}}
+	}
+	if len(items) > maxBuyItems {
+		llog.Error("LotteryBuy", "items", len(items))
+		return nil, 0, pty.ErrLotteryBuyItems
+	}
+
+	//购买数量按张计算, 每张为一个币, 扣款时乘以资产的精度
+	var total int64
+	maxAmount := assetMaxAmount(lott) / assetPrecision(lott)
+	if lott.MaxBuyPerTx > 0 && lott.MaxBuyPerTx < maxAmount {
+		maxAmount = lott.MaxBuyPerTx
+	}
+	checked := make([]*pty.LotteryBuyItem, len(items))
+	for i, item := range items {
+		if item.GetAmount() <= 0 || item.GetAmount() > maxAmount-total {
+			llog.Error("LotteryBuy", "buyAmount", item.GetAmount())
+			return nil, 0, pty.ErrLotteryBuyAmount
+		}
+		if item.GetNumber() < 0 || item.GetNumber() >= luckyNumMol {
+			llog.Error("LotteryBuy", "buyNumber", item.GetNumber())
+			return nil, 0, pty.ErrLotteryBuyNumber
+		}
+		total += item.GetAmount()
+		//同一笔交易中的每个号码需要不同的index, 用于localdb 和中奖记录
+		checked[i] = &pty.LotteryBuyItem{Number: item.GetNumber(), Amount: item.GetAmount(), Way: item.GetWay(),
+			Index: action.GetIndex()*maxBuyItems + int64(i)}
+	}
+	return checked, total, nil
+}
+
+//GetRefundReceiptLog 关闭时给每个购买地址的退款回执, amount 为退款的数量, rollover 为分得的累积头奖
+func (action *Action) GetRefundReceiptLog(lottery *pty.Lottery, addr string, amount int64, rollover int64) *types.ReceiptLog {
+	l := &pty.ReceiptLotteryRefund{
+		LotteryId: lottery.LotteryId,
+		Round:     lottery.Round,
+		Addr:      addr,
+		Amount:    amount,
+		Rollover:  rollover,
+		Time:      action.blocktime,
+		TxHash:    common.ToHex(action.txhash),
+	}
+	return &types.ReceiptLog{Ty: pty.TyLogLotteryRefund, Log: types.Encode(l)}
+}
+
+//GetDrawReceiptLog 开奖回执, 附带推导开奖号码的全部输入
+func (action *Action) GetDrawReceiptLog(lottery *pty.Lottery, preStatus int32, amount int64, updateInfo *pty.LotteryUpdateBuyInfo, proof *pty.LotteryDrawProof) *types.ReceiptLog {
+	l := &pty.ReceiptLottery{}
+	l.LotteryId = lottery.LotteryId
+	l.Status = lottery.Status
+	l.PrevStatus = preStatus
+	l.CreateHeight = lottery.CreateHeight
+	l.Round = lottery.Round
+	l.Amount = amount
+	l.LuckyNumber = lottery.LuckyNumber
+	l.Rollover = lottery.RolloverPool
+	l.Time = action.blocktime
+	l.TxHash = common.ToHex(action.txhash)
+	if len(updateInfo.BuyInfo) > 0 {
+		l.UpdateInfo = updateInfo
+	}
+	l.DrawProof = proof
+	return &types.ReceiptLog{Ty: pty.TyLogLotteryDraw, Log: types.Encode(l)}
+}
+
+//GetBuyReceiptLog 一笔购买交易只生成一条回执, 包含所有购买的号码, 通过代理购买时记录代理地址和佣金
+func (action *Action) GetBuyReceiptLog(lottery *pty.Lottery, preStatus int32, round int64, items []*pty.LotteryBuyItem, buy *pty.LotteryBuy, commission int64) *types.ReceiptLog {
+	l := &pty.ReceiptLottery{}
+	l.LotteryId = lottery.LotteryId
+	l.Status = lottery.Status
+	l.PrevStatus = preStatus
+	l.CreateHeight = lottery.CreateHeight
+	l.Round = round
+	l.Addr = action.fromaddr
+	l.Time = action.blocktime
+	l.TxHash = common.ToHex(action.txhash)
+	l.BuyItems = items
+	l.CommitHash = buy.CommitHash
+	l.AgentAddr = buy.AgentAddr
+	l.Commission = commission
+	//只买一个号码时同时填充旧字段, 兼容旧的回执解析
+	if len(items) == 1 {
+		l.Number = items[0].Number
+		l.Amount = items[0].Amount
+		l.Way = items[0].Way
+		l.Index = items[0].Index
+	}
+	return &types.ReceiptLog{Ty: pty.TyLogLotteryBuy, Log: types.Encode(l)}
+}
+
 //1.Anyone who buy a ticket
 //2.Creator
+//3.Anyone after the draw deadline
 func (action *Action) LotteryDraw(draw *pty.LotteryDraw) (*types.Receipt, error) {
-	var logs []*types.ReceiptLog
-	var kv []*types.KeyValue
-	var receipt *types.Receipt
-
 	lottery, err := findLottery(action.db, draw.LotteryId)
 	if err != nil {
 		llog.Error("LotteryBuy", "LotteryId", draw.LotteryId)
@@ -368,196 +652,1613 @@ func (action *Action) LotteryDraw(draw *pty.LotteryDraw) (*types.Receipt, error)
 
 	preStatus := lott.Status
 
-	if lott.Status != pty.LotteryPurchase {
-		llog.Error("LotteryDraw", "lott.Status", lott.Status)
-		return nil, pty.ErrLotteryStatus
+	if err := action.checkTransition(lott.Status, pty.LotteryActionDraw); err != nil {
+		return nil, err
 	}
 
-	if types.IsPara() {
-		mainHeight := action.GetMainHeightByTxHash(action.txhash)
-		if mainHeight < 0 {
-			llog.Error("LotteryBuy", "mainHeight", mainHeight)
-			return nil, pty.ErrLotteryStatus
+	if lott.Status == pty.LotteryCreated || lott.Status == pty.LotteryDrawed {
+		return action.drawEmptyRound(lott, preStatus)
+	}
+
+	//提交之后超时没有揭示, 按创建时的配置退款或者使用原来的方式开奖
+	if lott.Status == pty.LotteryCommitted {
+		if err := action.checkDrawer(lott); err != nil {
+			return nil, err
 		}
-		if mainHeight-lott.GetLastTransToPurStateOnMain() < lott.GetDrawBlockNum() {
-			llog.Error("LotteryDraw", "action.height", action.height, "mainHeight", mainHeight, "GetLastTransToPurStateOnMain", lott.GetLastTransToPurState())
+		if action.height-lott.CommitHeight <= lott.RevealTimeout {
+			llog.Error("LotteryDraw", "action.height", action.height, "commitHeight", lott.CommitHeight, "revealTimeout", lott.RevealTimeout)
 			return nil, pty.ErrLotteryStatus
 		}
-	} else {
-		if action.height-lott.GetLastTransToPurState() < lott.GetDrawBlockNum() {
-			llog.Error("LotteryDraw", "action.height", action.height, "GetLastTransToPurState", lott.GetLastTransToPurState())
-			return nil, pty.ErrLotteryStatus
+		if lott.TimeoutRefund {
+			return action.closeLottery(lott, preStatus)
 		}
+		return action.drawLottery(lott, preStatus, action.findDrawProof(lott))
 	}
 
-	if action.fromaddr != lott.GetCreateAddr() {
-		if _, ok := lott.Records[action.fromaddr]; !ok {
-			llog.Error("LotteryDraw", "action.fromaddr", action.fromaddr)
-			return nil, pty.ErrLotteryDrawActionInvalid
-		}
+	if lott.Closing {
+		llog.Error("LotteryDraw", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
 	}
 
-	rec, updateInfo, err := action.checkDraw(lott)
-	if err != nil {
+	//超过开奖期限之后创建者可能已经无法提交, 允许直接开奖; 参与不足需要退款时也不需要提交
+	refundBelowMin := lott.RefundBelowMin && !reachMinimum(lott) && action.pastMaxWait(lott)
+	if lott.RevealBlockNum > 0 && !action.pastDrawDeadline(lott) && !refundBelowMin {
+		return nil, pty.ErrLotteryCommitRequired
+	}
+
+	if err := action.checkDrawHeight(lott); err != nil {
 		return nil, err
 	}
-	kv = append(kv, rec.KV...)
-	logs = append(logs, rec.Logs...)
 
-	lott.Save(action.db)
-	kv = append(kv, lott.GetKVSet()...)
+	if err := action.checkDrawer(lott); err != nil {
+		return nil, err
+	}
 
-	receiptLog := action.GetReceiptLog(&lott.Lottery, preStatus, pty.TyLogLotteryDraw, lott.Round, 0, 0, 0, lott.LuckyNumber, updateInfo)
-	logs = append(logs, receiptLog)
+	//参与不足时继续等待, 超过等待期限之后按创建时的配置关闭退款或者照常开奖
+	if !reachMinimum(lott) {
+		if !action.pastMaxWait(lott) {
+			llog.Error("LotteryDraw", "txNum", lott.TotalPurchasedTxNum, "minPurchaseNum", lott.MinPurchaseNum, "minSalesAmount", lott.MinSalesAmount)
+			return nil, pty.ErrLotteryBelowMinimum
+		}
+		if refundBelowMin {
+			return action.closeLottery(lott, preStatus)
+		}
+	}
 
-	receipt = &types.Receipt{types.ExecOk, kv, logs}
-	return receipt, nil
+	return action.drawLottery(lott, preStatus, action.findDrawProof(lott))
 }
 
-func (action *Action) LotteryClose(draw *pty.LotteryClose) (*types.Receipt, error) {
+//本轮没有购买, 到了开奖高度之后跳过本轮, 轮次加1, 下一笔购买开始新的一轮
+//奖池和滚存不变, 最后一轮跳过之后和正常开奖一样自动关闭
+func (action *Action) drawEmptyRound(lott *LotteryDB, preStatus int32) (*types.Receipt, error) {
 	var logs []*types.ReceiptLog
-	var kv []*types.KeyValue
-	//var receipt *types.Receipt
 
-	if !isEableToClose() {
-		return nil, pty.ErrLotteryErrUnableClose
+	if lott.Closing {
+		llog.Error("LotteryDraw", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
 	}
-
-	lottery, err := findLottery(action.db, draw.LotteryId)
+	if lott.MaxRounds > 0 && lott.Round >= lott.MaxRounds {
+		llog.Error("LotteryDraw", "round", lott.Round, "maxRounds", lott.MaxRounds)
+		return nil, pty.ErrLotteryMaxRounds
+	}
+	if action.fromaddr != lotteryAdmin(lott) && !isDrawer(lott, action.fromaddr) {
+		llog.Error("LotteryDraw", "action.fromaddr", action.fromaddr)
+		return nil, pty.ErrLotteryDrawActionInvalid
+	}
+	elapsed, err := action.emptyRoundElapsed(lott)
 	if err != nil {
-		llog.Error("LotteryBuy", "LotteryId", draw.LotteryId)
 		return nil, err
 	}
-
-	lott := &LotteryDB{*lottery}
-	preStatus := lott.Status
-
-	if action.fromaddr != lott.CreateAddr {
-		return nil, pty.ErrLotteryErrCloser
+	if elapsed < lott.GetDrawBlockNum() {
+		llog.Error("LotteryDraw", "action.height", action.height, "elapsed", elapsed, "status", lott.Status)
+		return nil, pty.ErrLotteryStatus
 	}
 
-	if lott.Status == pty.LotteryClosed {
-		return nil, pty.ErrLotteryStatus
+	lott.Round++
+	lott.Status = pty.LotteryDrawed
+	lott.LastTransToDrawState = action.height
+	if types.IsPara() {
+		mainHeight := action.GetMainHeightByTxHash(action.txhash)
+		if mainHeight < 0 {
+			llog.Error("LotteryDraw", "mainHeight", mainHeight)
+			return nil, pty.ErrLotteryStatus
+		}
+		lott.LastTransToDrawStateOnMain = mainHeight
+	}
+	l := &pty.ReceiptLottery{
+		LotteryId:    lott.LotteryId,
+		Status:       lott.Status,
+		PrevStatus:   preStatus,
+		CreateHeight: lott.CreateHeight,
+		Round:        lott.Round,
+		Rollover:     lott.RolloverPool,
+		Time:         action.blocktime,
+		TxHash:       common.ToHex(action.txhash),
+		Height:       action.height,
 	}
+	logs = append(logs, &types.ReceiptLog{Ty: pty.TyLogLotteryDrawEmpty, Log: types.Encode(l)})
 
-	addrkeys := make([]string, len(lott.Records))
-	i := 0
-	var totalReturn int64 = 0
-	for addr := range lott.Records {
-		totalReturn += lott.Records[addr].AmountOneRound
-		addrkeys[i] = addr
-		i++
+	if lott.MaxRounds > 0 && lott.Round >= lott.MaxRounds {
+		llog.Debug("LotteryDraw reach maxRounds, switch to closestate", "round", lott.Round)
+		lott.Status = pty.LotteryClosed
+		lott.CloseHeight = action.height
+		logs = append(logs, action.GetReceiptLog(&lott.Lottery, pty.LotteryDrawed, pty.TyLogLotteryClose, lott.Round, 0, 0, 0, 0, nil))
 	}
-	llog.Debug("LotteryClose", "totalReturn", totalReturn)
 
-	if totalReturn > 0 {
+	lott.Save(action.db)
+	return &types.Receipt{Ty: types.ExecOk, KV: lott.GetKVSet(), Logs: logs}, nil
+}
 
-		if !action.CheckExecAccount(lott.CreateAddr, decimal*totalReturn, true) {
-			return nil, pty.ErrLotteryFundNotEnough
+//没有购买的轮次从创建或者上一次开奖开始计算, 平行链上一次开奖之后按主链高度计算
+func (action *Action) emptyRoundElapsed(lott *LotteryDB) (int64, error) {
+	if lott.Status == pty.LotteryCreated {
+		return action.height - lott.CreateHeight, nil
+	}
+	if types.IsPara() {
+		mainHeight := action.GetMainHeightByTxHash(action.txhash)
+		if mainHeight < 0 {
+			llog.Error("LotteryDraw", "mainHeight", mainHeight)
+			return 0, pty.ErrLotteryStatus
 		}
+		return mainHeight - lott.LastTransToDrawStateOnMain, nil
+	}
+	return action.height - lott.LastTransToDrawState, nil
+}
 
-		sort.Strings(addrkeys)
+//盲选购买的地址在开奖之前揭示号码, 号码和nonce 需要和购买时提交的hash 一致
+func (action *Action) LotteryRevealNumber(reveal *pty.LotteryRevealNumber) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, reveal.LotteryId)
+	if err != nil {
+		llog.Error("LotteryRevealNumber", "LotteryId", reveal.LotteryId)
+		return nil, err
+	}
 
-		for _, addr := range addrkeys {
-			if lott.Records[addr].AmountOneRound > 0 {
-				receipt, err := action.coinsAccount.ExecTransferFrozen(lott.CreateAddr, addr, action.execaddr,
-					decimal*lott.Records[addr].AmountOneRound)
-				if err != nil {
-					return nil, err
-				}
+	lott := &LotteryDB{*lottery}
 
-				kv = append(kv, receipt.KV...)
-				logs = append(logs, receipt.Logs...)
-			}
-		}
+	if err := action.checkTransition(lott.Status, pty.LotteryActionRevealNumber); err != nil {
+		return nil, err
 	}
 
-	for addr := range lott.Records {
-		lott.Records[addr].Record = lott.Records[addr].Record[0:0]
-		delete(lott.Records, addr)
+	if lott.Closing {
+		llog.Error("LotteryRevealNumber", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
 	}
 
-	lott.TotalPurchasedTxNum = 0
-	llog.Debug("LotteryClose switch to closestate")
-	lott.Status = pty.LotteryClosed
+	if reveal.Number < 0 || reveal.Number >= luckyNumMol {
+		llog.Error("LotteryRevealNumber", "number", reveal.Number)
+		return nil, pty.ErrLotteryBuyNumber
+	}
+
+	records, ok := lott.Records[action.fromaddr]
+	if !ok {
+		llog.Error("LotteryRevealNumber", "action.fromaddr", action.fromaddr)
+		return nil, pty.ErrLotteryRevealNumber
+	}
+	hash := pty.CalcBuyCommitHash(reveal.Number, reveal.Nonce)
+	var found *pty.PurchaseRecord
+	for _, rec := range records.Record {
+		if !rec.Revealed && len(rec.CommitHash) > 0 && bytes.Equal(rec.CommitHash, hash) {
+			found = rec
+			break
+		}
+	}
+	if found == nil {
+		llog.Error("LotteryRevealNumber", "hash", common.ToHex(hash))
+		return nil, pty.ErrLotteryRevealNumber
+	}
+	found.Number = reveal.Number
+	found.Revealed = true
 
 	lott.Save(action.db)
-	kv = append(kv, lott.GetKVSet()...)
+	kv := lott.GetKVSet()
+
+	l := &pty.ReceiptLottery{
+		LotteryId:    lott.LotteryId,
+		Status:       lott.Status,
+		PrevStatus:   lott.Status,
+		CreateHeight: lott.CreateHeight,
+		Addr:         action.fromaddr,
+		Round:        lott.Round,
+		Number:       found.Number,
+		Amount:       found.Amount,
+		Way:          found.Way,
+		Index:        found.Index,
+		CommitHash:   found.CommitHash,
+		Time:         action.blocktime,
+		TxHash:       common.ToHex(action.txhash),
+	}
+	receiptLog := &types.ReceiptLog{Ty: pty.TyLogLotteryRevealNumber, Log: types.Encode(l)}
+	return &types.Receipt{types.ExecOk, kv, []*types.ReceiptLog{receiptLog}}, nil
+}
 
-	receiptLog := action.GetReceiptLog(&lott.Lottery, preStatus, pty.TyLogLotteryClose, 0, 0, 0, 0, 0, nil)
-	logs = append(logs, receiptLog)
+//开奖时盲选购买还没有揭示的号码不参与开奖, 按购买数量退款, 每个地址一条退款回执
+func (action *Action) refundUnrevealed(accDB *account.DB, lott *LotteryDB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
 
-	return &types.Receipt{types.ExecOk, kv, logs}, nil
-}
+	var addrkeys []string
+	for addr := range lott.Records {
+		addrkeys = append(addrkeys, addr)
+	}
+	sort.Strings(addrkeys)
 
-func (action *Action) GetModify(beg, end int64, randMolNum int64) ([]byte, error) {
-	//通过某个区间计算modify
-	timeSource := int64(0)
-	total := int64(0)
-	//last := []byte("last")
-	newmodify := ""
-	for i := beg; i < end; i += randMolNum {
-		req := &types.ReqBlocks{i, i, false, []string{""}}
-		blocks, err := action.api.GetBlocks(req)
-		if err != nil {
-			return []byte{}, err
+	for _, addr := range addrkeys {
+		record := lott.Records[addr]
+		var refund, tickets int64
+		var kept []*pty.PurchaseRecord
+		for _, rec := range record.Record {
+			if len(rec.CommitHash) > 0 && !rec.Revealed {
+				refund += rec.Amount - rec.Commission
+				tickets += rec.Amount
+				continue
+			}
+			kept = append(kept, rec)
 		}
-		block := blocks.Items[0].Block
-		timeSource += block.BlockTime
-		total += block.BlockTime
+		if tickets == 0 {
+			continue
+		}
+		if refund > 0 {
+			receipt, err := action.payFromPool(accDB, lott, addr, assetPrecision(lott)*refund)
+			if err != nil {
+				llog.Error("refundUnrevealed.payFromPool", "addr", addr, "execaddr", action.execaddr, "amount", refund)
+				return nil, err
+			}
+			kv = append(kv, receipt.KV...)
+			logs = append(logs, receipt.Logs...)
+			logs = append(logs, action.GetRefundReceiptLog(&lott.Lottery, addr, refund, 0))
+		}
+
+		record.Record = kept
+		record.AmountOneRound -= tickets
+		lott.Fund -= refund
+		//销售额中包含佣金, 减去的是购买的数量而不是退款
+		lott.TotalSales -= tickets
 	}
+	return &types.Receipt{types.ExecOk, kv, logs}, nil
+}
 
-	//for main chain, 5 latest block
-	//for para chain, 5 latest block -- 5 sequence main block
-	txActions, err := action.getTxActions(end, blockNum)
+//开奖分为两步时, 开奖人先提交sha256(secret)
+func (action *Action) LotteryCommit(commit *pty.LotteryCommit) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, commit.LotteryId)
 	if err != nil {
+		llog.Error("LotteryCommit", "LotteryId", commit.LotteryId)
 		return nil, err
 	}
 
-	//modify, bits, id
-	var modifies []byte
-	var bits uint32
-	var ticketIds string
+	lott := &LotteryDB{*lottery}
+	preStatus := lott.Status
 
-	for _, ticketAction := range txActions {
-		llog.Debug("GetModify", "modify", ticketAction.GetMiner().GetModify(), "bits", ticketAction.GetMiner().GetBits(), "ticketId", ticketAction.GetMiner().GetTicketId())
-		modifies = append(modifies, ticketAction.GetMiner().GetModify()...)
-		bits += ticketAction.GetMiner().GetBits()
-		ticketIds += ticketAction.GetMiner().GetTicketId()
+	if err := action.checkTransition(lott.Status, pty.LotteryActionCommit); err != nil {
+		return nil, err
 	}
 
-	newmodify = fmt.Sprintf("%s:%s:%d:%d", string(modifies), ticketIds, total, bits)
+	if lott.Closing {
+		llog.Error("LotteryCommit", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
+	}
 
-	modify := common.Sha256([]byte(newmodify))
-	return modify, nil
-}
+	if lott.RevealBlockNum == 0 {
+		return nil, pty.ErrLotteryCommitDisabled
+	}
 
-//random used for verfication in solo
-func (action *Action) findLuckyNum(isSolo bool, lott *LotteryDB) int64 {
-	var num int64 = 0
-	if isSolo {
-		//used for internal verfication
-		num = 12345
-	} else {
-		randMolNum := (lott.TotalPurchasedTxNum+action.height-lott.LastTransToPurState)%3 + 2 //3~5
+	if len(commit.Hash) != sha256.Size {
+		llog.Error("LotteryCommit", "hash", commit.Hash)
+		return nil, pty.ErrLotteryRevealHash
+	}
 
-		modify, err := action.GetModify(lott.LastTransToPurState, action.height-1, randMolNum)
-		llog.Error("findLuckyNum", "begin", lott.LastTransToPurState, "end", action.height-1, "randMolNum", randMolNum)
+	if err := action.checkDrawHeight(lott); err != nil {
+		return nil, err
+	}
+
+	if err := action.checkDrawer(lott); err != nil {
+		return nil, err
+	}
+
+	//参与不足并且需要退款时只能通过开奖交易关闭
+	if !reachMinimum(lott) && (!action.pastMaxWait(lott) || lott.RefundBelowMin) {
+		return nil, pty.ErrLotteryBelowMinimum
+	}
+
+	llog.Debug("LotteryCommit switch to committedstate")
+	lott.Status = pty.LotteryCommitted
+	lott.CommitHash = commit.Hash
+	lott.CommitHeight = action.height
+	lott.CommitAddr = action.fromaddr
+
+	lott.Save(action.db)
+	kv := lott.GetKVSet()
+
+	receiptLog := action.GetReceiptLog(&lott.Lottery, preStatus, pty.TyLogLotteryCommit, lott.Round, 0, 0, 0, 0, nil)
+	return &types.Receipt{types.ExecOk, kv, []*types.ReceiptLog{receiptLog}}, nil
+}
+
+//提交的地址在等待revealBlockNum 个区块之后揭示secret 并开奖
+func (action *Action) LotteryReveal(reveal *pty.LotteryReveal) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, reveal.LotteryId)
+	if err != nil {
+		llog.Error("LotteryReveal", "LotteryId", reveal.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+	preStatus := lott.Status
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionReveal); err != nil {
+		return nil, err
+	}
+
+	if action.fromaddr != lott.CommitAddr {
+		llog.Error("LotteryReveal", "action.fromaddr", action.fromaddr, "commitAddr", lott.CommitAddr)
+		return nil, pty.ErrLotteryDrawActionInvalid
+	}
+
+	if action.height-lott.CommitHeight < lott.RevealBlockNum {
+		llog.Error("LotteryReveal", "action.height", action.height, "commitHeight", lott.CommitHeight, "revealBlockNum", lott.RevealBlockNum)
+		return nil, pty.ErrLotteryStatus
+	}
+
+	if action.height-lott.CommitHeight > lott.RevealTimeout {
+		llog.Error("LotteryReveal", "action.height", action.height, "commitHeight", lott.CommitHeight, "revealTimeout", lott.RevealTimeout)
+		return nil, pty.ErrLotteryRevealTimeout
+	}
+
+	if !bytes.Equal(common.Sha256(reveal.Secret), lott.CommitHash) {
+		llog.Error("LotteryReveal", "commitHash", common.ToHex(lott.CommitHash))
+		return nil, pty.ErrLotteryRevealHash
+	}
+
+	proof, err := action.revealDrawProof(lott, reveal.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return action.drawLottery(lott, preStatus, proof)
+}
+
+//开奖号码由secret 和上一个区块的hash 共同决定, 提交时还不知道这个区块hash, 出块时还不知道secret
+func (action *Action) revealDrawProof(lott *LotteryDB, secret []byte) (*pty.LotteryDrawProof, error) {
+	proof := action.newDrawProof(lott, pty.LotteryDrawByReveal)
+	proof.HashHeight = action.height - 1
+	reply, err := action.api.GetBlockHash(&types.ReqInt{Height: proof.HashHeight})
+	if err != nil {
+		llog.Error("revealDrawProof", "height", proof.HashHeight, "err", err)
+		return nil, err
+	}
+	proof.Secret = secret
+	proof.BlockHash = reply.Hash
+	proof.Seed = pty.LotteryRevealSeed(secret, reply.Hash)
+	proof.LuckyNumber = LuckyNumFromSeed(proof.Seed)
+	return proof, nil
+}
+
+//开奖证明记录推导开奖号码的全部输入, 随开奖回执一起保存
+func (action *Action) newDrawProof(lott *LotteryDB, method int32) *pty.LotteryDrawProof {
+	return &pty.LotteryDrawProof{
+		LotteryId:   lott.LotteryId,
+		TxHash:      common.ToHex(action.txhash),
+		DrawHeight:  action.height,
+		Method:      method,
+		LuckyNumMol: luckyNumMol,
+		LuckyNumber: -1,
+		AlgoVersion: pty.LotteryDrawAlgoVersion,
+	}
+}
+
+//购买阶段至少持续drawBlockNum 个区块才能开奖
+func (action *Action) checkDrawHeight(lott *LotteryDB) error {
+	if lott.MaxRounds > 0 && lott.Round > lott.MaxRounds {
+		llog.Error("LotteryDraw", "round", lott.Round, "maxRounds", lott.MaxRounds)
+		return pty.ErrLotteryMaxRounds
+	}
+
+	elapsed, err := action.purchaseElapsed(lott)
+	if err != nil {
+		return err
+	}
+	if elapsed < lott.GetDrawBlockNum() {
+		llog.Error("LotteryDraw", "action.height", action.height, "elapsed", elapsed, "GetLastTransToPurState", lott.GetLastTransToPurState())
+		return pty.ErrLotteryStatus
+	}
+	return nil
+}
+
+//本轮开始购买之后经过的区块数, 平行链按主链高度计算
+func (action *Action) purchaseElapsed(lott *LotteryDB) (int64, error) {
+	if types.IsPara() {
+		mainHeight := action.GetMainHeightByTxHash(action.txhash)
+		if mainHeight < 0 {
+			llog.Error("LotteryDraw", "mainHeight", mainHeight)
+			return 0, pty.ErrLotteryStatus
+		}
+		return mainHeight - lott.GetLastTransToPurStateOnMain(), nil
+	}
+	return action.height - lott.GetLastTransToPurState(), nil
+}
+
+//设置了开奖期限并且本轮已经超过期限
+func (action *Action) pastDrawDeadline(lott *LotteryDB) bool {
+	if lott.DrawDeadlineBlocks <= 0 {
+		return false
+	}
+	elapsed, err := action.purchaseElapsed(lott)
+	if err != nil {
+		return false
+	}
+	return elapsed > lott.DrawDeadlineBlocks
+}
+
+//本轮购买交易数量和销售额是否达到开奖的要求
+func reachMinimum(lott *LotteryDB) bool {
+	return lott.TotalPurchasedTxNum >= lott.MinPurchaseNum && roundSales(lott) >= lott.MinSalesAmount
+}
+
+//参与不足时是否已经超过等待期限
+func (action *Action) pastMaxWait(lott *LotteryDB) bool {
+	if lott.MaxWaitBlocks <= 0 {
+		return false
+	}
+	elapsed, err := action.purchaseElapsed(lott)
+	if err != nil {
+		return false
+	}
+	return elapsed >= lott.MaxWaitBlocks
+}
+
+//管理地址和本轮的购买者可以开奖, 超过开奖期限之后任何地址都可以开奖
+func (action *Action) checkDrawer(lott *LotteryDB) error {
+	if action.fromaddr != lotteryAdmin(lott) && !isDrawer(lott, action.fromaddr) {
+		if _, ok := lott.Records[action.fromaddr]; !ok && !action.pastDrawDeadline(lott) {
+			llog.Error("LotteryDraw", "action.fromaddr", action.fromaddr)
+			return pty.ErrLotteryDrawActionInvalid
+		}
+	}
+	return nil
+}
+
+func (action *Action) drawLottery(lott *LotteryDB, preStatus int32, proof *pty.LotteryDrawProof) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
+
+	accDB, err := action.assetAccount(lott)
+	if err != nil {
+		return nil, err
+	}
+
+	refundReceipt, err := action.refundUnrevealed(accDB, lott)
+	if err != nil {
+		return nil, err
+	}
+	kv = append(kv, refundReceipt.KV...)
+	logs = append(logs, refundReceipt.Logs...)
+
+	sales := roundSales(lott)
+	if lott.CreatorFeeRatio > 0 {
+		feeReceipt, err := action.payCreatorFee(accDB, lott, sales)
+		if err != nil {
+			return nil, err
+		}
+		kv = append(kv, feeReceipt.KV...)
+		logs = append(logs, feeReceipt.Logs...)
+	}
+	//管理地址没有按时开奖, 奖励替他开奖的地址
+	if lott.DrawRewardRatio > 0 && action.fromaddr != lotteryAdmin(lott) && action.pastDrawDeadline(lott) {
+		rewardReceipt, err := action.payDrawReward(accDB, lott, sales)
+		if err != nil {
+			return nil, err
+		}
+		kv = append(kv, rewardReceipt.KV...)
+		logs = append(logs, rewardReceipt.Logs...)
+	}
+	rec, updateInfo, err := action.checkDraw(accDB, lott, proof.LuckyNumber)
+	if err != nil {
+		return nil, err
+	}
+	kv = append(kv, rec.KV...)
+	logs = append(logs, rec.Logs...)
+	lott.CommitHash = nil
+	lott.CommitHeight = 0
+	lott.CommitAddr = ""
+
+	proof.Round = lott.Round
+	receiptLog := action.GetDrawReceiptLog(&lott.Lottery, preStatus, sales, updateInfo, proof)
+	logs = append(logs, receiptLog)
+
+	//最后一轮开奖之后自动关闭, 开奖时本轮的购买记录已经结算, 不需要退款
+	if lott.MaxRounds > 0 && lott.Round >= lott.MaxRounds {
+		llog.Debug("LotteryDraw reach maxRounds, switch to closestate", "round", lott.Round)
+		lott.Status = pty.LotteryClosed
+		receiptLog = action.GetReceiptLog(&lott.Lottery, pty.LotteryDrawed, pty.TyLogLotteryClose, lott.Round, 0, 0, 0, 0, nil)
+		logs = append(logs, receiptLog)
+	}
+
+	lott.Save(action.db)
+	kv = append(kv, lott.GetKVSet()...)
+
+	return &types.Receipt{types.ExecOk, kv, logs}, nil
+}
+
+func (action *Action) LotteryClose(draw *pty.LotteryClose) (*types.Receipt, error) {
+	if !isEableToClose() {
+		return nil, pty.ErrLotteryErrUnableClose
+	}
+
+	lottery, err := findLottery(action.db, draw.LotteryId)
+	if err != nil {
+		llog.Error("LotteryBuy", "LotteryId", draw.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+	preStatus := lott.Status
+
+	if action.fromaddr != lotteryAdmin(lott) {
+		return nil, pty.ErrLotteryErrCloser
+	}
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionClose); err != nil {
+		return nil, err
+	}
+
+	return action.closeLottery(lott, preStatus)
+}
+
+//创建者修改开奖地址, 购买期间不能修改, 本轮的开奖地址在第一笔购买时就已经确定
+func (action *Action) LotteryModify(modify *pty.LotteryModify) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, modify.LotteryId)
+	if err != nil {
+		llog.Error("LotteryModify", "LotteryId", modify.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+
+	if action.fromaddr != lotteryAdmin(lott) {
+		return nil, pty.ErrNoPrivilege
+	}
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionModify); err != nil {
+		return nil, err
+	}
+
+	if lott.Closing {
+		llog.Error("LotteryModify", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
+	}
+
+	if len(modify.AddDrawers) == 0 && len(modify.RemoveDrawers) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+
+	//先删除再添加, 删除不存在的地址或者添加已经存在的地址都是错误
+	drawers := append([]string{}, lott.Drawers...)
+	for _, addr := range modify.RemoveDrawers {
+		index := -1
+		for i, drawer := range drawers {
+			if drawer == addr {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			llog.Error("LotteryModify", "remove", addr)
+			return nil, pty.ErrLotteryDrawerAddr
+		}
+		drawers = append(drawers[:index], drawers[index+1:]...)
+	}
+	drawers = append(drawers, modify.AddDrawers...)
+	if err := checkDrawers(drawers); err != nil {
+		return nil, err
+	}
+	lott.Drawers = drawers
+
+	lott.Save(action.db)
+	kv := lott.GetKVSet()
+
+	l := &pty.ReceiptLotteryModify{
+		LotteryId:     lott.LotteryId,
+		Round:         lott.Round,
+		Addr:          action.fromaddr,
+		AddDrawers:    modify.AddDrawers,
+		RemoveDrawers: modify.RemoveDrawers,
+		Drawers:       lott.Drawers,
+		Time:          action.blocktime,
+		TxHash:        common.ToHex(action.txhash),
+		Index:         action.GetIndex(),
+	}
+	receiptLog := &types.ReceiptLog{Ty: pty.TyLogLotteryModify, Log: types.Encode(l)}
+	return &types.Receipt{types.ExecOk, kv, []*types.ReceiptLog{receiptLog}}, nil
+}
+
+//lotteryAdmin 当前有开奖, 关闭和修改开奖地址权限的地址, 没有移交过的彩票由创建者管理
+func lotteryAdmin(lott *LotteryDB) string {
+	if lott.Admin != "" {
+		return lott.Admin
+	}
+	return lott.CreateAddr
+}
+
+//管理地址移交给另一个地址, 购买期间也可以移交, 本轮之后的开奖和关闭由新的地址负责
+//奖池和创建者的分成仍然属于创建者, 没有奖池地址的旧彩票资金冻结在创建者的账户中
+func (action *Action) LotteryTransfer(transfer *pty.LotteryTransfer) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, transfer.LotteryId)
+	if err != nil {
+		llog.Error("LotteryTransfer", "LotteryId", transfer.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+	prevAdmin := lotteryAdmin(lott)
+
+	if action.fromaddr != prevAdmin {
+		return nil, pty.ErrNoPrivilege
+	}
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionTransfer); err != nil {
+		return nil, err
+	}
+
+	if lott.Closing {
+		llog.Error("LotteryTransfer", "closing", lott.LotteryId)
+		return nil, pty.ErrLotteryInvalidState
+	}
+
+	if err := address.CheckAddress(transfer.To); err != nil || transfer.To == prevAdmin {
+		llog.Error("LotteryTransfer", "to", transfer.To, "err", err)
+		return nil, pty.ErrLotteryAdminAddr
+	}
+
+	if lott.Status == pty.LotteryPurchase || lott.Status == pty.LotteryCommitted {
+		llog.Info("LotteryTransfer during round", "lotteryId", lott.LotteryId, "round", lott.Round, "status", lott.Status,
+			"from", prevAdmin, "to", transfer.To)
+	}
+	lott.Admin = transfer.To
+
+	lott.Save(action.db)
+	kv := lott.GetKVSet()
+
+	l := &pty.ReceiptLotteryTransfer{
+		LotteryId: lott.LotteryId,
+		Round:     lott.Round,
+		Status:    lott.Status,
+		PrevAdmin: prevAdmin,
+		Admin:     lott.Admin,
+		Time:      action.blocktime,
+		TxHash:    common.ToHex(action.txhash),
+		Index:     action.GetIndex(),
+	}
+	receiptLog := &types.ReceiptLog{Ty: pty.TyLogLotteryTransfer, Log: types.Encode(l)}
+	return &types.Receipt{types.ExecOk, kv, []*types.ReceiptLog{receiptLog}}, nil
+}
+
+//LotteryReclaim 关闭之后等待reclaimBlockNum 个区块, 管理地址把奖池中剩余的资金(分配时的零头)转回自己的账户
+//关闭时已经退完款, 奖池中的资金不再属于任何购买者, 只能回收一次
+func (action *Action) LotteryReclaim(reclaim *pty.LotteryReclaim) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, reclaim.LotteryId)
+	if err != nil {
+		llog.Error("LotteryReclaim", "LotteryId", reclaim.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+
+	if action.fromaddr != lotteryAdmin(lott) {
+		return nil, pty.ErrNoPrivilege
+	}
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionReclaim); err != nil {
+		return nil, err
+	}
+
+	if lott.Reclaimed {
+		return nil, pty.ErrLotteryReclaimed
+	}
+
+	//没有奖池地址的旧彩票资金冻结在创建者的账户中, 无法区分属于哪个彩票
+	if lott.EscrowAddr == "" {
+		return nil, pty.ErrLotteryNoEscrow
+	}
+
+	//还有没有完成的退款
+	if lott.Closing || len(lott.Records) > 0 {
+		llog.Error("LotteryReclaim", "closing", lott.Closing, "records", len(lott.Records))
+		return nil, pty.ErrLotteryInvalidState
+	}
+
+	wait := lott.ReclaimBlockNum
+	if wait == 0 {
+		wait = defaultReclaimBlockNum
+	}
+	if action.height-lott.CloseHeight < wait {
+		llog.Error("LotteryReclaim", "action.height", action.height, "closeHeight", lott.CloseHeight, "reclaimBlockNum", wait)
+		return nil, pty.ErrLotteryStatus
+	}
+
+	accDB, err := action.assetAccount(lott)
+	if err != nil {
+		return nil, err
+	}
+
+	var kv []*types.KeyValue
+	var logs []*types.ReceiptLog
+	//还没有领取的奖金仍然属于中奖地址
+	amount := accDB.LoadExecAccount(lott.EscrowAddr, action.execaddr).Balance - lott.LockedPayout
+	if amount > 0 {
+		receipt, err := accDB.ExecTransfer(lott.EscrowAddr, action.fromaddr, action.execaddr, amount)
+		if err != nil {
+			llog.Error("LotteryReclaim.ExecTransfer", "addr", action.fromaddr, "execaddr", action.execaddr, "amount", amount)
+			return nil, err
+		}
+		kv = append(kv, receipt.KV...)
+		logs = append(logs, receipt.Logs...)
+	}
+	lott.Reclaimed = true
+	lott.Fund = 0
+
+	lott.Save(action.db)
+	kv = append(kv, lott.GetKVSet()...)
+
+	l := &pty.ReceiptLotteryReclaim{
+		LotteryId: lott.LotteryId,
+		Round:     lott.Round,
+		Addr:      action.fromaddr,
+		Amount:    amount,
+		Time:      action.blocktime,
+		TxHash:    common.ToHex(action.txhash),
+		Index:     action.GetIndex(),
+	}
+	logs = append(logs, &types.ReceiptLog{Ty: pty.TyLogLotteryReclaim, Log: types.Encode(l)})
+	return &types.Receipt{types.ExecOk, kv, logs}, nil
+}
+
+//开奖时把奖金锁定在奖池中, 开奖之后payoutMaturity 个区块中奖地址才能领取
+func (action *Action) lockPayout(lott *LotteryDB, addr string, amount int64) (*types.Receipt, error) {
+	locked, err := safeAdd(lott.LockedPayout, amount)
+	if err != nil {
+		return nil, err
+	}
+	lott.LockedPayout = locked
+	payout := &pty.LotteryPayout{
+		LotteryId:       lott.LotteryId,
+		Round:           lott.Round,
+		Addr:            addr,
+		Amount:          amount,
+		ClaimableHeight: action.height + lott.PayoutMaturity,
+	}
+	kv := []*types.KeyValue{{Key: payoutKey(lott.LotteryId, lott.Round, addr), Value: types.Encode(payout)}}
+	action.db.Set(kv[0].Key, kv[0].Value)
+	log := action.getPayoutReceiptLog(pty.TyLogLotteryPayoutLock, payout)
+	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: []*types.ReceiptLog{log}}, nil
+}
+
+func (action *Action) getPayoutReceiptLog(logTy int32, payout *pty.LotteryPayout) *types.ReceiptLog {
+	l := &pty.ReceiptLotteryPayout{
+		LotteryId:       payout.LotteryId,
+		Round:           payout.Round,
+		Addr:            payout.Addr,
+		Amount:          payout.Amount,
+		ClaimableHeight: payout.ClaimableHeight,
+		Time:            action.blocktime,
+		TxHash:          common.ToHex(action.txhash),
+		Index:           action.GetIndex(),
+	}
+	return &types.ReceiptLog{Ty: logTy, Log: types.Encode(l)}
+}
+
+func findPayout(db dbm.KV, lotteryId string, round int64, addr string) (*pty.LotteryPayout, error) {
+	data, err := db.Get(payoutKey(lotteryId, round, addr))
+	if err != nil {
+		return nil, err
+	}
+	var payout pty.LotteryPayout
+	if err := types.Decode(data, &payout); err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+//LotteryClaim 中奖地址领取一轮锁定的奖金, 每一轮只能领取一次
+func (action *Action) LotteryClaim(claim *pty.LotteryClaim) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, claim.LotteryId)
+	if err != nil {
+		llog.Error("LotteryClaim", "LotteryId", claim.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionClaim); err != nil {
+		return nil, err
+	}
+
+	payout, err := findPayout(action.db, lott.LotteryId, claim.Round, action.fromaddr)
+	if err == types.ErrNotFound {
+		return nil, pty.ErrLotteryNoPayout
+	}
+	if err != nil {
+		return nil, err
+	}
+	if payout.Claimed {
+		return nil, pty.ErrLotteryPayoutClaimed
+	}
+	if action.height < payout.ClaimableHeight {
+		llog.Error("LotteryClaim", "action.height", action.height, "claimableHeight", payout.ClaimableHeight)
+		return nil, pty.ErrLotteryPayoutImmature
+	}
+
+	accDB, err := action.assetAccount(lott)
+	if err != nil {
+		return nil, err
+	}
+
+	var kv []*types.KeyValue
+	var logs []*types.ReceiptLog
+	receipt, err := action.payFromPool(accDB, lott, action.fromaddr, payout.Amount)
+	if err != nil {
+		llog.Error("LotteryClaim.payFromPool", "addr", action.fromaddr, "amount", payout.Amount)
+		return nil, err
+	}
+	kv = append(kv, receipt.KV...)
+	logs = append(logs, receipt.Logs...)
+
+	payout.Claimed = true
+	key := payoutKey(lott.LotteryId, claim.Round, action.fromaddr)
+	action.db.Set(key, types.Encode(payout))
+	kv = append(kv, &types.KeyValue{Key: key, Value: types.Encode(payout)})
+
+	lott.LockedPayout -= payout.Amount
+	lott.Save(action.db)
+	kv = append(kv, lott.GetKVSet()...)
+
+	logs = append(logs, action.getPayoutReceiptLog(pty.TyLogLotteryClaim, payout))
+	return &types.Receipt{types.ExecOk, kv, logs}, nil
+}
+
+//LotteryTransferTicket 购买地址把本轮还没有开奖的一条购买记录转给另一个地址, 开奖时按新的地址计算中奖
+//已经开奖或者退款的购买记录不能转让, 盲选购买的号码转让之后由新的地址揭示
+func (action *Action) LotteryTransferTicket(transfer *pty.LotteryTransferTicket) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, transfer.LotteryId)
+	if err != nil {
+		llog.Error("LotteryTransferTicket", "LotteryId", transfer.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+
+	//开奖和关闭之后购买记录已经清空, 之前的购买都已经有了结果
+	if lott.Status == pty.LotteryDrawed || lott.Status == pty.LotteryClosed {
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "status", lott.Status, "index", transfer.Index)
+		return nil, pty.ErrLotteryTicketResolved
+	}
+	if err := action.checkTransition(lott.Status, pty.LotteryActionTransferTicket); err != nil {
+		return nil, err
+	}
+
+	records, ok := lott.Records[action.fromaddr]
+	if ok && records.Refunded {
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "refunded", action.fromaddr)
+		return nil, pty.ErrLotteryTicketResolved
+	}
+	if lott.Closing {
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "closing", lott.Closing)
+		return nil, pty.ErrLotteryInvalidState
+	}
+
+	pos := -1
+	if ok {
+		for i, rec := range records.Record {
+			if rec.Index == transfer.Index {
+				pos = i
+				break
+			}
+		}
+	}
+	if pos < 0 {
+		//index 由购买的高度计算, 本轮开始之前的购买已经开奖或者退款
+		if transfer.Index < lott.LastTransToPurState*types.MaxTxsPerBlock*maxBuyItems {
+			llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "index", transfer.Index, "lastTransToPurState", lott.LastTransToPurState)
+			return nil, pty.ErrLotteryTicketResolved
+		}
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "addr", action.fromaddr, "index", transfer.Index)
+		return nil, pty.ErrLotteryTicketNotFound
+	}
+
+	if address.CheckAddress(transfer.To) != nil || transfer.To == action.fromaddr {
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "to", transfer.To)
+		return nil, pty.ErrLotteryTransferAddr
+	}
+	if lott.CreateAddr == transfer.To || lotteryAdmin(lott) == transfer.To {
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "creator", transfer.To)
+		return nil, pty.ErrLotteryCreatorBuy
+	}
+	if isBlacklisted(lott, transfer.To) {
+		llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "blacklisted", transfer.To)
+		return nil, pty.ErrLotteryAddrBlacklisted
+	}
+
+	rec := records.Record[pos]
+	//接收地址和购买一样受每轮购买数量的限制
+	if lott.OpPurchaseLimit > 0 {
+		var bought int64
+		if record, ok := lott.Records[transfer.To]; ok {
+			bought = record.AmountOneRound
+		}
+		total, err := safeAdd(bought, rec.Amount)
+		if err != nil || total > lott.OpPurchaseLimit {
+			llog.Error("LotteryTransferTicket", "LotteryId", lott.LotteryId, "bought", bought, "amount", rec.Amount, "opPurchaseLimit", lott.OpPurchaseLimit)
+			return nil, pty.ErrLotteryPurchaseLimit
+		}
+	}
+
+	//佣金跟着购买记录一起移动, 关闭时按记录退款给新的地址
+	records.Record = append(records.Record[:pos], records.Record[pos+1:]...)
+	records.AmountOneRound -= rec.Amount
+	if len(records.Record) == 0 {
+		delete(lott.Records, action.fromaddr)
+	}
+	if _, ok := lott.Records[transfer.To]; !ok {
+		lott.Records[transfer.To] = &pty.PurchaseRecords{}
+	}
+	lott.Records[transfer.To].Record = append(lott.Records[transfer.To].Record, rec)
+	lott.Records[transfer.To].AmountOneRound += rec.Amount
+	llog.Debug("LotteryTransferTicket", "LotteryId", lott.LotteryId, "from", action.fromaddr, "to", transfer.To, "index", rec.Index)
+
+	lott.Save(action.db)
+	kv := lott.GetKVSet()
+
+	l := &pty.ReceiptLotteryTicketTransfer{
+		LotteryId: lott.LotteryId,
+		Round:     lott.Round,
+		Index:     rec.Index,
+		From:      action.fromaddr,
+		To:        transfer.To,
+		Number:    rec.Number,
+		Amount:    rec.Amount,
+		Time:      action.blocktime,
+		TxHash:    common.ToHex(action.txhash),
+	}
+	receiptLog := &types.ReceiptLog{Ty: pty.TyLogLotteryTicketTransfer, Log: types.Encode(l)}
+	return &types.Receipt{types.ExecOk, kv, []*types.ReceiptLog{receiptLog}}, nil
+}
+
+//LotteryBlacklist 管理地址修改不能购买的地址, 先删除再添加, 已经购买的记录不受影响
+func (action *Action) LotteryBlacklist(blacklist *pty.LotteryBlacklist) (*types.Receipt, error) {
+	lottery, err := findLottery(action.db, blacklist.LotteryId)
+	if err != nil {
+		llog.Error("LotteryBlacklist", "LotteryId", blacklist.LotteryId)
+		return nil, err
+	}
+
+	lott := &LotteryDB{*lottery}
+
+	if action.fromaddr != lotteryAdmin(lott) {
+		return nil, pty.ErrNoPrivilege
+	}
+
+	if err := action.checkTransition(lott.Status, pty.LotteryActionBlacklist); err != nil {
+		return nil, err
+	}
+
+	if len(blacklist.Add) == 0 && len(blacklist.Remove) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+
+	list := append([]string{}, lott.Blacklist...)
+	for _, addr := range blacklist.Remove {
+		i := sort.SearchStrings(list, addr)
+		if i >= len(list) || list[i] != addr {
+			llog.Error("LotteryBlacklist", "remove", addr)
+			return nil, pty.ErrLotteryBlacklistAddr
+		}
+		list = append(list[:i], list[i+1:]...)
+	}
+	list = append(list, blacklist.Add...)
+	if err := checkBlacklist(list); err != nil {
+		return nil, err
+	}
+	lott.Blacklist = sortedBlacklist(list)
+
+	lott.Save(action.db)
+	kv := lott.GetKVSet()
+
+	l := &pty.ReceiptLotteryBlacklist{
+		LotteryId: lott.LotteryId,
+		Round:     lott.Round,
+		Addr:      action.fromaddr,
+		Add:       blacklist.Add,
+		Remove:    blacklist.Remove,
+		Time:      action.blocktime,
+		TxHash:    common.ToHex(action.txhash),
+		Index:     action.GetIndex(),
+	}
+	receiptLog := &types.ReceiptLog{Ty: pty.TyLogLotteryBlacklist, Log: types.Encode(l)}
+	return &types.Receipt{types.ExecOk, kv, []*types.ReceiptLog{receiptLog}}, nil
+}
+
+func (action *Action) closeLottery(lott *LotteryDB, preStatus int32) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
+
+	accDB, err := action.assetAccount(lott)
+	if err != nil {
+		return nil, err
+	}
+	precision := assetPrecision(lott)
+
+	//已经退过款的地址不再处理, 避免重复退款
+	var addrkeys []string
+	var totalReturn int64 = 0
+	for addr, record := range lott.Records {
+		totalReturn += record.AmountOneRound
+		if !record.Refunded {
+			addrkeys = append(addrkeys, addr)
+		}
+	}
+	llog.Debug("LotteryClose", "totalReturn", totalReturn)
+
+	sort.Strings(addrkeys)
+	remain := 0
+	if len(addrkeys) > maxRefundsPerClose {
+		remain = len(addrkeys) - maxRefundsPerClose
+		addrkeys = addrkeys[:maxRefundsPerClose]
+	}
+
+	//退款中扣除了佣金, 销售额中包含佣金, 销售额减去的是这些地址本轮购买的数量
+	var refund, shares, bought int64
+	for _, addr := range addrkeys {
+		refund += refundAmount(lott.Records[addr])
+		shares += rolloverShare(lott, lott.Records[addr], totalReturn)
+		bought += lott.Records[addr].AmountOneRound
+	}
+	if refund+shares > 0 && !action.checkPool(accDB, lott, precision*(refund+shares)) {
+		return nil, pty.ErrLotteryFundNotEnough
+	}
+
+	for _, addr := range addrkeys {
+		record := lott.Records[addr]
+		share := rolloverShare(lott, record, totalReturn)
+		if refundAmount(record)+share > 0 {
+			receipt, err := action.payFromPool(accDB, lott, addr, precision*(refundAmount(record)+share))
+			if err != nil {
+				return nil, err
+			}
+
+			kv = append(kv, receipt.KV...)
+			logs = append(logs, receipt.Logs...)
+			logs = append(logs, action.GetRefundReceiptLog(&lott.Lottery, addr, refundAmount(record), share))
+		}
+		record.Refunded = true
+	}
+	lott.Fund -= refund + shares
+	lott.TotalSales -= bought
+	//升级之前创建的彩票没有记录累计销售额
+	if lott.TotalSales < 0 {
+		lott.TotalSales = 0
+	}
+
+	if remain > 0 {
+		llog.Debug("LotteryClose refund in batches", "remain", remain)
+		lott.Closing = true
+		lott.Save(action.db)
+		kv = append(kv, lott.GetKVSet()...)
+		return &types.Receipt{types.ExecOk, kv, logs}, nil
+	}
+
+	//累积的头奖没有分给购买者的部分退还给创建者
+	if lott.RolloverPool > 0 {
+		var distributed int64
+		for _, record := range lott.Records {
+			distributed += rolloverShare(lott, record, totalReturn)
+		}
+		if back := lott.RolloverPool - distributed; back > 0 {
+			receipt, err := action.payFromPool(accDB, lott, lott.CreateAddr, precision*back)
+			if err != nil {
+				llog.Error("LotteryClose.payFromPool", "addr", lott.CreateAddr, "execaddr", action.execaddr, "rollover", back)
+				return nil, err
+			}
+			kv = append(kv, receipt.KV...)
+			logs = append(logs, receipt.Logs...)
+			lott.Fund -= back
+		}
+	}
+
+	for addr := range lott.Records {
+		lott.Records[addr].Record = lott.Records[addr].Record[0:0]
+		delete(lott.Records, addr)
+	}
+
+	lott.TotalPurchasedTxNum = 0
+	llog.Debug("LotteryClose switch to closestate")
+	lott.Status = pty.LotteryClosed
+	lott.CloseHeight = action.height
+	lott.Closing = false
+	lott.CommitHash = nil
+	lott.CommitHeight = 0
+	lott.CommitAddr = ""
+
+	receiptLog := action.GetReceiptLog(&lott.Lottery, preStatus, pty.TyLogLotteryClose, lott.Round, 0, totalReturn, 0, 0, nil)
+	logs = append(logs, receiptLog)
+	lott.RolloverPool = 0
+
+	lott.Save(action.db)
+	kv = append(kv, lott.GetKVSet()...)
+
+	return &types.Receipt{types.ExecOk, kv, logs}, nil
+}
+
+//购买中关闭时, 每个购买者按本轮购买的数量分得累积的头奖
+func rolloverShare(lott *LotteryDB, record *pty.PurchaseRecords, totalReturn int64) int64 {
+	if !lott.RolloverToBuyers || lott.RolloverPool == 0 || totalReturn == 0 {
+		return 0
+	}
+	return lott.RolloverPool * record.AmountOneRound / totalReturn
+}
+
+//CheckLotteryCreate 检查创建参数的范围, 不依赖链上的状态, 构造交易时也用来提前检查
+func CheckLotteryCreate(create *pty.LotteryCreate) error {
+	if create.GetPurBlockNum() < minPurBlockNum {
+		return pty.ErrLotteryPurBlockLimit
+	}
+
+	if create.GetDrawBlockNum() < minDrawBlockNum {
+		return pty.ErrLotteryDrawBlockLimit
+	}
+
+	if create.GetPurBlockNum() > create.GetDrawBlockNum() {
+		return pty.ErrLotteryDrawBlockLimit
+	}
+
+	if create.GetOpPurchaseLimit() < 0 {
+		return pty.ErrLotteryPurchaseLimit
+	}
+
+	if create.GetCreatorFeeRatio() < 0 || create.GetCreatorFeeRatio() > maxFeeRatio {
+		return pty.ErrLotteryCreatorFeeRatio
+	}
+
+	if create.GetMaxRounds() < 0 {
+		return pty.ErrLotteryMaxRounds
+	}
+
+	if create.GetDrawDeadlineBlocks() < 0 || (create.GetDrawDeadlineBlocks() > 0 && create.GetDrawDeadlineBlocks() < create.GetDrawBlockNum()) {
+		return pty.ErrLotteryDrawDeadline
+	}
+
+	if create.GetDrawRewardRatio() < 0 || create.GetDrawRewardRatio() > maxDrawReward {
+		return pty.ErrLotteryDrawRewardRatio
+	}
+
+	if create.GetDrawRewardRatio() > 0 && create.GetDrawDeadlineBlocks() == 0 {
+		return pty.ErrLotteryDrawRewardRatio
+	}
+
+	if create.GetMinPurchaseNum() < 0 || create.GetMinSalesAmount() < 0 || create.GetMaxWaitBlocks() < 0 {
+		return pty.ErrLotteryMinimumParam
+	}
+
+	if create.GetMaxWaitBlocks() > 0 && create.GetMaxWaitBlocks() < create.GetDrawBlockNum() {
+		return pty.ErrLotteryMinimumParam
+	}
+
+	if err := checkRevealParam(create); err != nil {
+		return err
+	}
+
+	if err := checkDrawers(create.GetDrawers()); err != nil {
+		return err
+	}
+
+	if create.GetReclaimBlockNum() < 0 {
+		return pty.ErrLotteryReclaimBlockNum
+	}
+
+	if create.GetMaxBuyPerTx() < 0 {
+		return pty.ErrLotteryBuyAmount
+	}
+
+	if create.GetCommissionRatio() < 0 || create.GetCommissionRatio() > maxCommission {
+		return pty.ErrLotteryCommissionRatio
+	}
+
+	if err := checkAssetExec(create.GetAssetExec(), create.GetTokenSymbol()); err != nil {
+		return err
+	}
+
+	if err := checkBlacklist(create.GetBlacklist()); err != nil {
+		return err
+	}
+
+	if create.GetPayoutMaturity() < 0 {
+		return pty.ErrLotteryPayoutMaturity
+	}
+
+	return checkPrizeRatio(create.GetPrizeRatio())
+}
+
+//资产执行器只支持token 和paracross, 为空时按tokenSymbol 使用coins 或者token.
+//paracross 中是主链跨链过来的资产, 平行链上没有主链的token 信息, 只检查symbol 的格式
+func checkAssetExec(exec, symbol string) error {
+	switch exec {
+	case "":
+		return nil
+	case tokenX:
+		if symbol == "" {
+			return pty.ErrLotteryAssetExec
+		}
+		return nil
+	case paraX:
+		parts := strings.SplitN(symbol, ".", 2)
+		if len(parts) != 2 || parts[1] == "" || (parts[0] != "coins" && parts[0] != tokenX) {
+			llog.Error("checkAssetExec", "exec", exec, "symbol", symbol)
+			return pty.ErrLotteryAssetExec
+		}
+		return nil
+	}
+	llog.Error("checkAssetExec", "exec", exec)
+	return pty.ErrLotteryAssetExec
+}
+
+//开奖地址必须是合法的地址, 不能重复
+func checkDrawers(drawers []string) error {
+	if len(drawers) > maxDrawers {
+		return pty.ErrLotteryDrawerAddr
+	}
+	seen := make(map[string]bool)
+	for _, addr := range drawers {
+		if seen[addr] || address.CheckAddress(addr) != nil {
+			llog.Error("checkDrawers", "addr", addr)
+			return pty.ErrLotteryDrawerAddr
+		}
+		seen[addr] = true
+	}
+	return nil
+}
+
+func checkBlacklist(blacklist []string) error {
+	if len(blacklist) > maxBlacklist {
+		return pty.ErrLotteryBlacklistAddr
+	}
+	seen := make(map[string]bool)
+	for _, addr := range blacklist {
+		if seen[addr] || address.CheckAddress(addr) != nil {
+			llog.Error("checkBlacklist", "addr", addr)
+			return pty.ErrLotteryBlacklistAddr
+		}
+		seen[addr] = true
+	}
+	return nil
+}
+
+//黑名单按地址排序保存, 购买时二分查找
+func sortedBlacklist(blacklist []string) []string {
+	if len(blacklist) == 0 {
+		return nil
+	}
+	sorted := append([]string{}, blacklist...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func isBlacklisted(lott *LotteryDB, addr string) bool {
+	i := sort.SearchStrings(lott.Blacklist, addr)
+	return i < len(lott.Blacklist) && lott.Blacklist[i] == addr
+}
+
+func isDrawer(lott *LotteryDB, addr string) bool {
+	for _, drawer := range lott.Drawers {
+		if drawer == addr {
+			return true
+		}
+	}
+	return false
+}
+
+//CheckLotteryBuy 构造购买交易时检查号码, 数量和玩法, 执行时还会按彩票使用的资产检查总额
+func CheckLotteryBuy(buy *pty.LotteryBuy) error {
+	if buy.GetLotteryId() == "" {
+		return types.ErrInvalidParam
+	}
+	if err := checkBuyCommit(buy); err != nil {
+		return err
+	}
+	items := buy.GetItems()
+	if len(items) == 0 {
+		items = []*pty.LotteryBuyItem{{Number: buy.GetNumber(), Amount: buy.GetAmount(), Way: buy.GetWay()}}
+	}
+	if len(items) > maxBuyItems {
+		return pty.ErrLotteryBuyItems
+	}
+	for _, item := range items {
+		if item.GetAmount() <= 0 {
+			return pty.ErrLotteryBuyAmount
+		}
+		if item.GetNumber() < 0 || item.GetNumber() >= luckyNumMol {
+			return pty.ErrLotteryBuyNumber
+		}
+		if !isValidWay(item.GetWay()) {
+			return types.ErrInvalidParam
+		}
+	}
+	return nil
+}
+
+//盲选购买只能买一个号码, 号码字段不填, 揭示之前不能泄露
+func checkBuyCommit(buy *pty.LotteryBuy) error {
+	if len(buy.GetCommitHash()) == 0 {
+		return nil
+	}
+	if len(buy.GetCommitHash()) != sha256.Size || len(buy.GetItems()) > 0 || buy.GetNumber() != 0 {
+		return pty.ErrLotteryBuyCommit
+	}
+	return nil
+}
+
+func isValidWay(way int64) bool {
+	for _, tier := range prizeTiers {
+		if way == tier {
+			return true
+		}
+	}
+	return false
+}
+
+//揭示时使用上一个区块的hash, 至少等待两个区块才能保证这个区块在提交之后产生
+func checkRevealParam(create *pty.LotteryCreate) error {
+	if create.GetRevealBlockNum() == 0 {
+		return nil
+	}
+	if create.GetRevealBlockNum() < minRevealBlockNum || create.GetRevealTimeout() <= create.GetRevealBlockNum() {
+		return pty.ErrLotteryRevealParam
+	}
+	return nil
+}
+
+//奖级比例不能超过奖级的数量, 总和不能超过100%
+func checkPrizeRatio(prizeRatio []int64) error {
+	if len(prizeRatio) > len(prizeTiers) {
+		return pty.ErrLotteryPrizeRatio
+	}
+	var total int64
+	for _, ratio := range prizeRatio {
+		if ratio < 0 {
+			return pty.ErrLotteryPrizeRatio
+		}
+		total += ratio
+	}
+	if total > 100 {
+		return pty.ErrLotteryPrizeRatio
+	}
+	return nil
+}
+
+//创建者的分成从奖池中扣除
+func (action *Action) payCreatorFee(accDB *account.DB, lott *LotteryDB, sales int64) (*types.Receipt, error) {
+	fee := sales * lott.CreatorFeeRatio / 100
+	if fee <= 0 {
+		return &types.Receipt{Ty: types.ExecOk}, nil
+	}
+	if fee > lott.Fund {
+		fee = lott.Fund
+	}
+	receipt, err := action.payFromPool(accDB, lott, lott.CreateAddr, assetPrecision(lott)*fee)
+	if err != nil {
+		llog.Error("payCreatorFee.payFromPool", "addr", lott.CreateAddr, "execaddr", action.execaddr, "fee", fee)
+		return nil, err
+	}
+	lott.Fund -= fee
+	feeLog := &pty.ReceiptLotteryCreatorFee{
+		LotteryId: lott.LotteryId,
+		Round:     lott.Round,
+		Addr:      lott.CreateAddr,
+		Fee:       assetPrecision(lott) * fee,
+	}
+	receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: pty.TyLogLotteryFee, Log: types.Encode(feeLog)})
+	return receipt, nil
+}
+
+//超过开奖期限之后开奖的奖励从奖池中扣除
+func (action *Action) payDrawReward(accDB *account.DB, lott *LotteryDB, sales int64) (*types.Receipt, error) {
+	reward := sales * lott.DrawRewardRatio / 100
+	if reward <= 0 {
+		return &types.Receipt{Ty: types.ExecOk}, nil
+	}
+	if reward > lott.Fund {
+		reward = lott.Fund
+	}
+	receipt, err := action.payFromPool(accDB, lott, action.fromaddr, assetPrecision(lott)*reward)
+	if err != nil {
+		llog.Error("payDrawReward.payFromPool", "addr", action.fromaddr, "execaddr", action.execaddr, "reward", reward)
+		return nil, err
+	}
+	lott.Fund -= reward
+	rewardLog := &pty.ReceiptLotteryDrawReward{
+		LotteryId: lott.LotteryId,
+		Round:     lott.Round,
+		Addr:      action.fromaddr,
+		Reward:    assetPrecision(lott) * reward,
+	}
+	receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: pty.TyLogLotteryDrawReward, Log: types.Encode(rewardLog)})
+	return receipt, nil
+}
+
+//本轮所有地址购买的总额
+func roundSales(lott *LotteryDB) int64 {
+	var sales int64
+	for _, record := range lott.Records {
+		sales += record.AmountOneRound
+	}
+	return sales
+}
+
+func (action *Action) GetModify(beg, end int64, randMolNum int64) ([]byte, error) {
+	proof := &pty.LotteryDrawProof{BeginHeight: beg, EndHeight: end, Step: randMolNum}
+	if err := action.fillDrawModify(proof); err != nil {
+		return nil, err
+	}
+	return proof.Seed, nil
+}
+
+//通过某个区间计算modify, 取样的区块和挖矿交易都记录到开奖证明中
+func (action *Action) fillDrawModify(proof *pty.LotteryDrawProof) error {
+	total := int64(0)
+	heights := pty.LotteryDrawTimeHeights(proof.BeginHeight, proof.EndHeight, proof.Step)
+	for _, i := range heights {
+		req := &types.ReqBlocks{i, i, false, []string{""}}
+		blocks, err := action.api.GetBlocks(req)
+		if err != nil {
+			return err
+		}
+		block := blocks.Items[0].Block
+		total += block.BlockTime
+	}
 
-		if err != nil {
-			llog.Error("findLuckyNum", "err", err)
-			return -1
+	//for main chain, 5 latest block
+	//for para chain, 5 latest block -- 5 sequence main block
+	txActions, minerHeights, err := action.getTxActions(proof.EndHeight, blockNum)
+	if err != nil {
+		return err
+	}
+
+	//modify, bits, id
+	var modifies []byte
+	var bits uint32
+	var ticketIds string
+
+	for _, ticketAction := range txActions {
+		llog.Debug("GetModify", "modify", ticketAction.GetMiner().GetModify(), "bits", ticketAction.GetMiner().GetBits(), "ticketId", ticketAction.GetMiner().GetTicketId())
+		modifies = append(modifies, ticketAction.GetMiner().GetModify()...)
+		bits += ticketAction.GetMiner().GetBits()
+		ticketIds += ticketAction.GetMiner().GetTicketId()
+	}
+
+	proof.TimeHeights = heights
+	proof.TimeSum = total
+	proof.MinerHeights = minerHeights
+	proof.Modifies = modifies
+	proof.TicketIds = ticketIds
+	proof.Bits = bits
+	proof.Seed = pty.LotteryDrawModify(modifies, ticketIds, total, bits)
+	return nil
+}
+
+//random used for verfication in solo
+func (action *Action) findLuckyNum(isSolo bool, lott *LotteryDB) int64 {
+	if isSolo {
+		//used for internal verfication
+		return 12345
+	}
+	return action.findDrawProof(lott).LuckyNumber
+}
+
+//由区块数据推导开奖号码, 出错时开奖号码为-1
+func (action *Action) findDrawProof(lott *LotteryDB) *pty.LotteryDrawProof {
+	proof := action.newDrawProof(lott, pty.LotteryDrawByBlock)
+	proof.PurchasedTxNum = lott.TotalPurchasedTxNum
+	proof.BeginHeight = lott.LastTransToPurState
+	proof.EndHeight = action.height - 1
+	proof.Step = pty.LotteryDrawStep(proof.PurchasedTxNum, action.height, proof.BeginHeight) //3~5
+
+	err := action.fillDrawModify(proof)
+	llog.Error("findLuckyNum", "begin", proof.BeginHeight, "end", proof.EndHeight, "randMolNum", proof.Step)
+	if err != nil {
+		llog.Error("findLuckyNum", "err", err)
+		return proof
+	}
+	proof.LuckyNumber = LuckyNumFromSeed(proof.Seed)
+	return proof
+}
+
+//DrawEntry 参与开奖的一个购买号码, 选出中奖号码时填写奖级和每注的奖金
+type DrawEntry struct {
+	Addr   string
+	Index  int64
+	Number int64
+	Way    int64
+	Amount int64
+	Level  int64
+	Prize  int64
+}
+
+//LuckyNumFromSeed 取seed 的前4个字节计算开奖号码, seed 不足4个字节时返回-1
+func LuckyNumFromSeed(seed []byte) int64 {
+	return pty.LotteryLuckyNum(seed)
+}
+
+//SelectWinners 由seed 确定开奖号码, 按地址和购买序号的顺序返回最多count 个中奖号码, count<=0 时返回全部
+//weighted 为true 时不按号码匹配, 以购买数量为权重随机抽取count 个头奖, count<=0 时抽取1个
+//只依赖参数, 相同的输入总是得到相同的结果
+func SelectWinners(entries []DrawEntry, seed []byte, count int, weighted bool) []DrawEntry {
+	luckynum := LuckyNumFromSeed(seed)
+	if luckynum < 0 {
+		return nil
+	}
+	return selectRoundWinners(entries, luckynum, count, weighted)
+}
+
+func selectRoundWinners(entries []DrawEntry, luckynum int64, count int, weighted bool) []DrawEntry {
+	if weighted {
+		return selectWeightedWinners(entries, luckynum, count)
+	}
+	return selectWinners(entries, luckynum, count)
+}
+
+//按地址和购买序号排序, 不修改输入
+func sortedEntries(entries []DrawEntry) []DrawEntry {
+	sorted := make([]DrawEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Addr != sorted[j].Addr {
+			return sorted[i].Addr < sorted[j].Addr
 		}
+		return sorted[i].Index < sorted[j].Index
+	})
+	return sorted
+}
 
-		baseNum, err := strconv.ParseUint(common.ToHex(modify[0:4]), 0, 64)
-		if err != nil {
-			llog.Error("findLuckyNum", "err", err)
-			return -1
+func selectWinners(entries []DrawEntry, luckynum int64, count int) []DrawEntry {
+	sorted := sortedEntries(entries)
+	var winners []DrawEntry
+	for _, entry := range sorted {
+		if count > 0 && len(winners) >= count {
+			break
+		}
+		prize, level := checkFundAmount(luckynum, entry.Number, entry.Way)
+		if prize == 0 {
+			continue
+		}
+		entry.Prize = prize
+		entry.Level = level
+		winners = append(winners, entry)
+	}
+	return winners
+}
+
+//加权抽取不放回, 第i次抽取的随机数为sha256(开奖号码 || i) 的前8个字节对剩余的总权重取模
+func selectWeightedWinners(entries []DrawEntry, luckynum int64, count int) []DrawEntry {
+	var pool []DrawEntry
+	var total uint64
+	for _, entry := range sortedEntries(entries) {
+		if entry.Amount <= 0 {
+			continue
+		}
+		pool = append(pool, entry)
+		total += uint64(entry.Amount)
+	}
+	if count <= 0 {
+		count = 1
+	}
+	var winners []DrawEntry
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, uint64(luckynum))
+	for i := 0; i < count && len(pool) > 0; i++ {
+		binary.BigEndian.PutUint64(buf[8:], uint64(i))
+		r := binary.BigEndian.Uint64(common.Sha256(buf)[:8]) % total
+		for j, entry := range pool {
+			if r >= uint64(entry.Amount) {
+				r -= uint64(entry.Amount)
+				continue
+			}
+			entry.Prize = exciting
+			entry.Level = FiveStar
+			winners = append(winners, entry)
+			total -= uint64(entry.Amount)
+			pool = append(pool[:j], pool[j+1:]...)
+			break
 		}
+	}
+	if len(winners) == 0 {
+		return nil
+	}
+	return sortedEntries(winners)
+}
 
-		num = int64(baseNum) % luckyNumMol
+//本轮所有购买号码
+func drawEntries(lott *LotteryDB) []DrawEntry {
+	var entries []DrawEntry
+	for addr, records := range lott.Records {
+		for _, rec := range records.Record {
+			entries = append(entries, DrawEntry{Addr: addr, Index: rec.Index, Number: rec.Number, Way: rec.Way, Amount: rec.Amount})
+		}
 	}
-	return num
+	return entries
 }
 
 func checkFundAmount(luckynum int64, guessnum int64, way int64) (int64, int64) {
@@ -574,10 +2275,9 @@ func checkFundAmount(luckynum int64, guessnum int64, way int64) (int64, int64) {
 	}
 }
 
-func (action *Action) checkDraw(lott *LotteryDB) (*types.Receipt, *pty.LotteryUpdateBuyInfo, error) {
+func (action *Action) checkDraw(accDB *account.DB, lott *LotteryDB, luckynum int64) (*types.Receipt, *pty.LotteryUpdateBuyInfo, error) {
 	llog.Debug("checkDraw")
 
-	luckynum := action.findLuckyNum(false, lott)
 	if luckynum < 0 || luckynum >= luckyNumMol {
 		return nil, nil, pty.ErrLotteryErrLuckyNum
 	}
@@ -591,62 +2291,110 @@ func (action *Action) checkDraw(lott *LotteryDB) (*types.Receipt, *pty.LotteryUp
 	//calculate fund for all participant showed their number
 	var updateInfo pty.LotteryUpdateBuyInfo
 	updateInfo.BuyInfo = make(map[string]*pty.LotteryUpdateRecs)
-	var tempFund int64 = 0
 	var totalFund int64 = 0
-	addrkeys := make([]string, len(lott.Records))
-	i := 0
+	//每张中奖彩票的奖金, 等确定奖池调整系数之后再计算实际金额
+	winFunds := make(map[*pty.LotteryUpdateRec]int64)
+	//每张中奖彩票购买的数量, 自定义奖级时按数量分配该奖级的奖金
+	winAmounts := make(map[*pty.LotteryUpdateRec]int64)
+	addrkeys := make([]string, 0, len(lott.Records))
 	for addr := range lott.Records {
-		addrkeys[i] = addr
-		i++
-		for _, rec := range lott.Records[addr].Record {
-			fund, fundType := checkFundAmount(luckynum, rec.Number, rec.Way)
-			if fund != 0 {
-				newUpdateRec := &pty.LotteryUpdateRec{rec.Index, fundType}
-				if update, ok := updateInfo.BuyInfo[addr]; ok {
-					update.Records = append(update.Records, newUpdateRec)
-				} else {
-					initrecord := &pty.LotteryUpdateRecs{}
-					initrecord.Records = append(initrecord.Records, newUpdateRec)
-					updateInfo.BuyInfo[addr] = initrecord
-				}
-			}
-			tempFund = fund * rec.Amount
-			lott.Records[addr].FundWin += tempFund
-			totalFund += tempFund
+		addrkeys = append(addrkeys, addr)
+	}
+	for _, winner := range selectRoundWinners(drawEntries(lott), luckynum, 0, lott.Weighted) {
+		newUpdateRec := &pty.LotteryUpdateRec{Index: winner.Index, Type: winner.Level}
+		fund, err := safeMul(winner.Prize, winner.Amount)
+		if err != nil {
+			llog.Error("checkDraw", "prize", winner.Prize, "amount", winner.Amount)
+			return nil, nil, err
+		}
+		winFunds[newUpdateRec] = fund
+		winAmounts[newUpdateRec] = winner.Amount
+		if update, ok := updateInfo.BuyInfo[winner.Addr]; ok {
+			update.Records = append(update.Records, newUpdateRec)
+		} else {
+			initrecord := &pty.LotteryUpdateRecs{}
+			initrecord.Records = append(initrecord.Records, newUpdateRec)
+			updateInfo.BuyInfo[winner.Addr] = initrecord
+		}
+		fundWin, err := safeAdd(lott.Records[winner.Addr].FundWin, fund)
+		if err != nil {
+			return nil, nil, err
+		}
+		lott.Records[winner.Addr].FundWin = fundWin
+		if totalFund, err = safeAdd(totalFund, fund); err != nil {
+			return nil, nil, err
 		}
 	}
 	llog.Debug("checkDraw", "lenofupdate", len(updateInfo.BuyInfo))
 	llog.Debug("checkDraw", "update", updateInfo.BuyInfo)
-	var factor float64 = 0
-	if totalFund > lott.GetFund()/2 {
-		llog.Debug("checkDraw ajust fund", "lott.Fund", lott.Fund, "totalFund", totalFund)
-		factor = (float64)(lott.GetFund()) / 2 / (float64)(totalFund)
-		lott.Fund = lott.Fund / 2
+	payouts := make(map[string]int64)
+	precision := assetPrecision(lott)
+	if len(lott.PrizeRatio) > 0 {
+		totalPayout, err := calcTierPayouts(lott, &updateInfo, winAmounts, payouts)
+		if err != nil {
+			return nil, nil, err
+		}
+		//protection for rollback
+		if !action.checkPool(accDB, lott, totalPayout) {
+			return nil, nil, pty.ErrLotteryFundNotEnough
+		}
 	} else {
-		factor = 1.0
-		lott.Fund -= totalFund
-	}
+		var factor float64 = 0
+		if totalFund > lott.GetFund()/2 {
+			llog.Debug("checkDraw ajust fund", "lott.Fund", lott.Fund, "totalFund", totalFund)
+			factor = (float64)(lott.GetFund()) / 2 / (float64)(totalFund)
+			lott.Fund = lott.Fund / 2
+		} else {
+			factor = 1.0
+			lott.Fund -= totalFund
+		}
 
-	llog.Debug("checkDraw", "factor", factor, "totalFund", totalFund)
+		llog.Debug("checkDraw", "factor", factor, "totalFund", totalFund)
 
-	//protection for rollback
-	if factor == 1.0 {
-		if !action.CheckExecAccount(lott.CreateAddr, totalFund, true) {
-			return nil, nil, pty.ErrLotteryFundNotEnough
+		//factor 先放大exciting 倍取整, 乘以精度之后再除回去
+		scale, err := safeMul(int64(factor*exciting), precision)
+		if err != nil {
+			return nil, nil, err
 		}
-	} else {
-		if !action.CheckExecAccount(lott.CreateAddr, decimal*lott.Fund/2+1, true) {
-			return nil, nil, pty.ErrLotteryFundNotEnough
+		for rec, fund := range winFunds {
+			if rec.Amount, err = safeMulDiv(fund, scale, exciting); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		for _, addr := range addrkeys {
+			//any problem when too little?
+			if payouts[addr], err = safeMulDiv(lott.Records[addr].FundWin, scale, exciting); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		//protection for rollback
+		if factor == 1.0 {
+			if !action.checkPool(accDB, lott, totalFund) {
+				return nil, nil, pty.ErrLotteryFundNotEnough
+			}
+		} else {
+			if !action.checkPool(accDB, lott, precision*lott.Fund/2+1) {
+				return nil, nil, pty.ErrLotteryFundNotEnough
+			}
 		}
 	}
 
 	sort.Strings(addrkeys)
 
 	for _, addr := range addrkeys {
-		fund := (lott.Records[addr].FundWin * int64(factor*exciting)) * decimal / exciting //any problem when too little?
+		fund := payouts[addr]
 		llog.Debug("checkDraw", "fund", fund)
-		if fund > 0 {
-			receipt, err := action.coinsAccount.ExecTransferFrozen(lott.CreateAddr, addr, action.execaddr, fund)
+		if fund > 0 && lott.PayoutMaturity > 0 {
+			receipt, err := action.lockPayout(lott, addr, fund)
+			if err != nil {
+				return nil, nil, err
+			}
+			kv = append(kv, receipt.KV...)
+			logs = append(logs, receipt.Logs...)
+		} else if fund > 0 {
+			receipt, err := action.payFromPool(accDB, lott, addr, fund)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -679,6 +2427,66 @@ func (action *Action) checkDraw(lott *LotteryDB) (*types.Receipt, *pty.LotteryUp
 
 	return &types.Receipt{types.ExecOk, kv, logs}, &updateInfo, nil
 }
+
+//自定义奖级时, 第i个奖级分得奖池的prizeRatio[i]%, 由该奖级的中奖彩票按购买数量平分
+//没有中奖的奖级和未分配的比例留在奖池中, 其中头奖滚入下一轮的头奖, 返回本次开奖发放的总奖金
+func calcTierPayouts(lott *LotteryDB, updateInfo *pty.LotteryUpdateBuyInfo, winAmounts map[*pty.LotteryUpdateRec]int64, payouts map[string]int64) (int64, error) {
+	tierAmounts := make(map[int64]int64)
+	for rec, amount := range winAmounts {
+		tierAmounts[rec.Type] += amount
+	}
+	//之前轮次没有发出的头奖累积在rolloverPool 中, 只加到本轮的头奖上
+	base := lott.Fund - lott.RolloverPool
+	tierFunds := make(map[int64]int64)
+	for i, ratio := range lott.PrizeRatio {
+		fund := base * ratio / 100
+		if prizeTiers[i] == FiveStar {
+			fund += lott.RolloverPool
+			lott.RolloverPool = 0
+			if tierAmounts[FiveStar] == 0 {
+				lott.RolloverPool = fund
+			}
+		}
+		if tierAmounts[prizeTiers[i]] > 0 {
+			tierFunds[prizeTiers[i]] = fund
+		}
+	}
+	var totalPayout int64
+	for _, addr := range sortedAddrs(updateInfo.BuyInfo) {
+		var records []*pty.LotteryUpdateRec
+		for _, rec := range updateInfo.BuyInfo[addr].Records {
+			tierFund := tierFunds[rec.Type]
+			if tierFund == 0 {
+				continue
+			}
+			tierTotal, err := safeMul(tierFund, assetPrecision(lott))
+			if err != nil {
+				return 0, err
+			}
+			if rec.Amount, err = safeMulDiv(tierTotal, winAmounts[rec], tierAmounts[rec.Type]); err != nil {
+				return 0, err
+			}
+			if payouts[addr], err = safeAdd(payouts[addr], rec.Amount); err != nil {
+				return 0, err
+			}
+			if totalPayout, err = safeAdd(totalPayout, rec.Amount); err != nil {
+				return 0, err
+			}
+			records = append(records, rec)
+		}
+		//没有奖金的奖级不算中奖
+		if len(records) == 0 {
+			delete(updateInfo.BuyInfo, addr)
+		} else {
+			updateInfo.BuyInfo[addr].Records = records
+		}
+	}
+	for _, fund := range tierFunds {
+		lott.Fund -= fund
+	}
+	return totalPayout, nil
+}
+
 func (action *Action) recordMissing(lott *LotteryDB) {
 	temp := int32(lott.LuckyNumber)
 	initNum := int32(10000)
@@ -741,6 +2549,90 @@ func isEableToClose() bool {
 	return true
 }
 
+//彩票使用的资产账户, assetExec 和tokenSymbol 都为空时使用coins
+func (action *Action) assetAccount(lott *LotteryDB) (*account.DB, error) {
+	return newAssetAccount(action.coinsAccount, action.db, lott.AssetExec, lott.TokenSymbol)
+}
+
+func newAssetAccount(coins *account.DB, db dbm.KV, exec, symbol string) (*account.DB, error) {
+	if exec == "" {
+		if symbol == "" {
+			return coins, nil
+		}
+		exec = tokenX
+	}
+	return account.NewAccountDB(exec, symbol, db)
+}
+
+//每个彩票独立的奖池地址, 由lotteryId 确定, 没有私钥, 只能由合约转出
+func escrowAddress(lotteryId string) string {
+	return address.ExecAddress(pty.LotteryX + "-escrow-" + lotteryId)
+}
+
+//购买的资金转入奖池, 没有奖池地址的旧彩票冻结在创建者的合约账户中
+func (action *Action) depositPool(accDB *account.DB, lott *LotteryDB, amount int64) (*types.Receipt, error) {
+	if lott.EscrowAddr != "" {
+		return accDB.ExecTransfer(action.fromaddr, lott.EscrowAddr, action.execaddr, amount)
+	}
+	receipt, err := accDB.ExecTransfer(action.fromaddr, lott.CreateAddr, action.execaddr, amount)
+	if err != nil {
+		return nil, err
+	}
+	frozen, err := accDB.ExecFrozen(lott.CreateAddr, action.execaddr, amount)
+	if err != nil {
+		return nil, err
+	}
+	receipt.KV = append(receipt.KV, frozen.KV...)
+	receipt.Logs = append(receipt.Logs, frozen.Logs...)
+	return receipt, nil
+}
+
+//从奖池中支付奖金, 退款和创建者的分成
+func (action *Action) payFromPool(accDB *account.DB, lott *LotteryDB, to string, amount int64) (*types.Receipt, error) {
+	if lott.EscrowAddr != "" {
+		return accDB.ExecTransfer(lott.EscrowAddr, to, action.execaddr, amount)
+	}
+	if to == lott.CreateAddr {
+		return accDB.ExecActive(lott.CreateAddr, action.execaddr, amount)
+	}
+	return accDB.ExecTransferFrozen(lott.CreateAddr, to, action.execaddr, amount)
+}
+
+func (action *Action) checkPool(accDB *account.DB, lott *LotteryDB, amount int64) bool {
+	if lott.EscrowAddr != "" {
+		return action.CheckExecAccount(accDB, lott.EscrowAddr, amount, false)
+	}
+	return action.CheckExecAccount(accDB, lott.CreateAddr, amount, true)
+}
+
+//购买数量的单位, coins 和token 按各自的精度换算成账户中的金额
+func assetPrecision(lott *LotteryDB) int64 {
+	if lott.TokenSymbol == "" {
+		return decimal
+	}
+	return types.TokenPrecision
+}
+
+func assetMaxAmount(lott *LotteryDB) int64 {
+	if lott.TokenSymbol == "" || (lott.AssetExec == paraX && strings.HasPrefix(lott.TokenSymbol, "coins.")) {
+		return types.MaxCoin
+	}
+	return types.MaxTokenBalance
+}
+
+//token 执行器创建完成之后才会保存token 信息
+func isTokenExist(db dbm.KV, symbol string) bool {
+	data, err := db.Get([]byte(tokenKeyPrefix + symbol))
+	if err != nil {
+		return false
+	}
+	var token tokenty.Token
+	if err := types.Decode(data, &token); err != nil {
+		return false
+	}
+	return token.Status == tokenty.TokenStatusCreated
+}
+
 func findLottery(db dbm.KV, lotteryId string) (*pty.Lottery, error) {
 	data, err := db.Get(Key(lotteryId))
 	if err != nil {
@@ -757,8 +2649,8 @@ func findLottery(db dbm.KV, lotteryId string) (*pty.Lottery, error) {
 	return &lott, nil
 }
 
-func (action *Action) CheckExecAccount(addr string, amount int64, isFrozen bool) bool {
-	acc := action.coinsAccount.LoadExecAccount(addr, action.execaddr)
+func (action *Action) CheckExecAccount(accDB *account.DB, addr string, amount int64, isFrozen bool) bool {
+	acc := accDB.LoadExecAccount(addr, action.execaddr)
 	if isFrozen {
 		if acc.GetFrozen() >= amount {
 			return true
@@ -772,7 +2664,7 @@ func (action *Action) CheckExecAccount(addr string, amount int64, isFrozen bool)
 	return false
 }
 
-func ListLotteryLuckyHistory(db dbm.Lister, stateDB dbm.KV, param *pty.ReqLotteryLuckyHistory) (types.Message, error) {
+func ListLotteryLuckyHistory(db dbm.Lister, stateDB dbm.KV, localPrefix string, param *pty.ReqLotteryLuckyHistory) (types.Message, error) {
 	direction := ListDESC
 	if param.GetDirection() == ListASC {
 		direction = ListASC
@@ -786,8 +2678,8 @@ func ListLotteryLuckyHistory(db dbm.Lister, stateDB dbm.KV, param *pty.ReqLotter
 	var values [][]byte
 	var err error
 
-	prefix = calcLotteryDrawPrefix(param.LotteryId)
-	key = calcLotteryDrawKey(param.LotteryId, param.GetRound())
+	prefix = calcLotteryDrawPrefix(localPrefix, param.LotteryId)
+	key = calcLotteryDrawKey(localPrefix, param.LotteryId, param.GetRound())
 
 	if param.GetRound() == 0 { //第一次查询
 		values, err = db.List(prefix, nil, count, direction)
@@ -811,7 +2703,7 @@ func ListLotteryLuckyHistory(db dbm.Lister, stateDB dbm.KV, param *pty.ReqLotter
 	return &records, nil
 }
 
-func ListLotteryBuyRecords(db dbm.Lister, stateDB dbm.KV, param *pty.ReqLotteryBuyHistory) (types.Message, error) {
+func ListLotteryBuyRecords(db dbm.Lister, stateDB dbm.KV, localPrefix string, param *pty.ReqLotteryBuyHistory) (types.Message, error) {
 	direction := ListDESC
 	if param.GetDirection() == ListASC {
 		direction = ListASC
@@ -825,8 +2717,8 @@ func ListLotteryBuyRecords(db dbm.Lister, stateDB dbm.KV, param *pty.ReqLotteryB
 	var values [][]byte
 	var err error
 
-	prefix = calcLotteryBuyPrefix(param.LotteryId, param.Addr)
-	key = calcLotteryBuyKey(param.LotteryId, param.Addr, param.GetRound(), param.GetIndex())
+	prefix = calcLotteryBuyPrefix(localPrefix, param.LotteryId, param.Addr)
+	key = calcLotteryBuyKey(localPrefix, param.LotteryId, param.Addr, param.GetRound(), param.GetIndex())
 
 	if param.GetRound() == 0 { //第一次查询
 		values, err = db.List(prefix, nil, count, direction)
@@ -847,7 +2739,304 @@ func ListLotteryBuyRecords(db dbm.Lister, stateDB dbm.KV, param *pty.ReqLotteryB
 		}
 		records.Records = append(records.Records, &record)
 	}
+	if lottery, err := findLottery(stateDB, param.LotteryId); err == nil {
+		records.TokenSymbol = lottery.TokenSymbol
+	}
 
 	return &records, nil
 
 }
+
+//按地址分页查询购买记录, round为0时查询所有轮次, primaryKey为上一页最后一条记录的key
+func ListLotteryBuyRecordsByAddr(db dbm.KVDB, localPrefix string, param *pty.ReqLotteryBuyRecordsByAddr) (types.Message, error) {
+	direction := ListDESC
+	if param.GetDirection() == ListASC {
+		direction = ListASC
+	}
+	count := DefultCount
+	if 0 < param.GetCount() && param.GetCount() <= MaxCount {
+		count = param.GetCount()
+	}
+	var prefix []byte
+	if param.GetRound() == 0 {
+		prefix = calcLotteryBuyPrefix(localPrefix, param.LotteryId, param.Addr)
+	} else {
+		prefix = calcLotteryBuyRoundPrefix(localPrefix, param.LotteryId, param.Addr, param.GetRound())
+	}
+	var key []byte
+	if param.GetPrimaryKey() != "" {
+		key = []byte(param.GetPrimaryKey())
+		if !bytes.HasPrefix(key, prefix) {
+			llog.Error("ListLotteryBuyRecordsByAddr", "primaryKey", param.GetPrimaryKey())
+			return nil, types.ErrInvalidParam
+		}
+	}
+
+	values, err := db.List(prefix, key, count, direction)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+
+	var reply pty.ReplyLotteryBuyRecordsByAddr
+	drawn := make(map[int64]bool)
+	for _, value := range values {
+		var record pty.LotteryBuyRecord
+		err := types.Decode(value, &record)
+		if err != nil {
+			continue
+		}
+		reply.Records = append(reply.Records, newLotteryBuyEntry(db, localPrefix, param.LotteryId, param.Addr, &record, drawn))
+	}
+	//不足一页说明已经取完
+	if int32(len(reply.Records)) == count {
+		reply.PrimaryKey = reply.Records[len(reply.Records)-1].PrimaryKey
+	}
+	return &reply, nil
+}
+
+//drawn 缓存每一轮是否已经开奖, 避免重复查询, 开奖时已经标记结果的记录不需要查询
+func newLotteryBuyEntry(db dbm.KV, localPrefix string, lotteryId string, addr string, record *pty.LotteryBuyRecord, drawn map[int64]bool) *pty.LotteryBuyEntry {
+	if record.Result != pty.LotteryBuyPending {
+		drawn[record.Round] = true
+	}
+	if _, ok := drawn[record.Round]; !ok {
+		value, err := db.Get(calcLotteryDrawKey(localPrefix, lotteryId, record.Round))
+		drawn[record.Round] = err == nil && len(value) > 0
+	}
+	return &pty.LotteryBuyEntry{
+		Number:     record.Number,
+		Amount:     record.Amount,
+		Way:        record.Way,
+		Round:      record.Round,
+		Index:      record.Index,
+		Time:       record.Time,
+		TxHash:     record.TxHash,
+		Type:       record.Type,
+		Drawn:      drawn[record.Round],
+		PrimaryKey: string(calcLotteryBuyKey(localPrefix, lotteryId, addr, record.Round, record.Index)),
+		CommitHash: record.CommitHash,
+		Revealed:   record.Revealed,
+		Result:     record.Result,
+	}
+}
+
+//按购买交易hash 查询这笔交易购买的所有号码, 以及是否已经开奖和中奖的奖级
+func GetLotteryBuyByTxHash(db dbm.KV, localPrefix string, param *pty.ReqLotteryBuyByTxHash) (types.Message, error) {
+	hash, err := common.FromHex(param.GetTxHash())
+	if err != nil || len(hash) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	value, err := db.Get(calcLotteryBuyTxKey(localPrefix, param.GetLotteryId(), common.ToHex(hash)))
+	if err != nil {
+		return nil, err
+	}
+	var index pty.LotteryBuyTxIndex
+	if err := types.Decode(value, &index); err != nil {
+		return nil, err
+	}
+	reply := &pty.ReplyLotteryBuyByTxHash{Addr: index.Addr}
+	drawn := make(map[int64]bool)
+	for _, i := range index.Indexes {
+		value, err := db.Get(calcLotteryBuyKey(localPrefix, param.GetLotteryId(), index.Addr, index.Round, i))
+		//已经转让给其他地址的购买记录不在购买地址下面, 不返回
+		if err == dbm.ErrNotFoundInDb || err == types.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var record pty.LotteryBuyRecord
+		if err := types.Decode(value, &record); err != nil {
+			return nil, err
+		}
+		reply.Records = append(reply.Records, newLotteryBuyEntry(db, localPrefix, param.GetLotteryId(), index.Addr, &record, drawn))
+	}
+	return reply, nil
+}
+
+//按轮次分页查询彩票的历史汇总, fromRound为上一页最后一轮, 为0时从头开始
+//正在购买中的轮次从状态数据中计算, 和历史轮次一起返回
+//彩票当前的参数, 格式和创建交易相同
+func lotteryConfig(lottery *pty.Lottery) *pty.LotteryCreate {
+	return &pty.LotteryCreate{
+		PurBlockNum:        lottery.PurBlockNum,
+		DrawBlockNum:       lottery.DrawBlockNum,
+		OpPurchaseLimit:    lottery.OpPurchaseLimit,
+		CreatorFeeRatio:    lottery.CreatorFeeRatio,
+		PrizeRatio:         lottery.PrizeRatio,
+		MaxRounds:          lottery.MaxRounds,
+		RevealBlockNum:     lottery.RevealBlockNum,
+		RevealTimeout:      lottery.RevealTimeout,
+		TimeoutRefund:      lottery.TimeoutRefund,
+		RolloverToBuyers:   lottery.RolloverToBuyers,
+		TokenSymbol:        lottery.TokenSymbol,
+		DrawDeadlineBlocks: lottery.DrawDeadlineBlocks,
+		DrawRewardRatio:    lottery.DrawRewardRatio,
+		MinPurchaseNum:     lottery.MinPurchaseNum,
+		MinSalesAmount:     lottery.MinSalesAmount,
+		MaxWaitBlocks:      lottery.MaxWaitBlocks,
+		RefundBelowMin:     lottery.RefundBelowMin,
+		Drawers:            lottery.Drawers,
+		ReclaimBlockNum:    lottery.ReclaimBlockNum,
+		Blacklist:          lottery.Blacklist,
+		MaxBuyPerTx:        lottery.MaxBuyPerTx,
+		CommissionRatio:    lottery.CommissionRatio,
+		ForbidSelfDealing:  lottery.ForbidSelfDealing,
+		AssetExec:          lottery.AssetExec,
+		Weighted:           lottery.Weighted,
+		PayoutMaturity:     lottery.PayoutMaturity,
+	}
+}
+
+func ListLotteryRoundsInfo(db dbm.Lister, stateDB dbm.KV, localPrefix string, param *pty.ReqLotteryRoundsInfo) (types.Message, error) {
+	direction := ListDESC
+	if param.GetDirection() == ListASC {
+		direction = ListASC
+	}
+	count := DefultCount
+	if 0 < param.GetCount() && param.GetCount() <= MaxCount {
+		count = param.GetCount()
+	}
+	lottery, err := findLottery(stateDB, param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	//已经写入localdb的最后一轮
+	lastRound := lottery.Round
+	var current *pty.LotteryRoundInfo
+	from := param.GetFromRound()
+	if lottery.Status == pty.LotteryPurchase || lottery.Status == pty.LotteryCommitted {
+		lastRound--
+		if from == 0 || (direction == ListDESC && from > lottery.Round) || (direction == ListASC && from < lottery.Round) {
+			current = &pty.LotteryRoundInfo{
+				Round:      lottery.Round,
+				Status:     lottery.Status,
+				TotalSales: roundSales(&LotteryDB{*lottery}),
+				Rollover:   lottery.RolloverPool,
+			}
+		}
+	}
+
+	reply := pty.ReplyLotteryRoundsInfo{TokenSymbol: lottery.TokenSymbol}
+	if current != nil && direction == ListDESC {
+		reply.Rounds = append(reply.Rounds, current)
+		count--
+	}
+	//游标必须是localdb中存在的轮次
+	var key []byte
+	if from > 0 && from <= lastRound {
+		key = calcLotteryRoundKey(localPrefix, param.GetLotteryId(), from)
+	}
+	if count > 0 && (direction == ListDESC || from < lastRound) {
+		values, err := db.List(calcLotteryRoundPrefix(localPrefix, param.GetLotteryId()), key, count, direction)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+		for _, value := range values {
+			var record pty.LotteryRoundInfo
+			err := types.Decode(value, &record)
+			if err != nil {
+				continue
+			}
+			reply.Rounds = append(reply.Rounds, &record)
+		}
+	}
+	//历史轮次已经取完, 再加上当前轮次
+	if current != nil && direction == ListASC && int32(len(reply.Rounds)) < count {
+		reply.Rounds = append(reply.Rounds, current)
+	}
+	return &reply, nil
+}
+
+//按状态分页查询彩票, 同一状态下按创建高度排序, primaryKey为上一页最后一条记录的key
+//升级之前写入的旧格式索引也会返回, 见calcLotteryLegacyStatusKey
+//轮次和销售额等会变化的字段从状态数据中读取
+func ListLotteries(db dbm.Lister, stateDB dbm.KV, localPrefix string, param *pty.ReqLotteryList) (types.Message, error) {
+	if param.GetStatus() < pty.LotteryCreated || param.GetStatus() > pty.LotteryCommitted {
+		return nil, types.ErrInvalidParam
+	}
+	direction := ListDESC
+	if param.GetDirection() == ListASC {
+		direction = ListASC
+	}
+	count := DefultCount
+	if 0 < param.GetCount() && param.GetCount() <= MaxCount {
+		count = param.GetCount()
+	}
+	//升级之前的索引没有创建高度, 降序时排在新的索引之后, 升序时排在之前
+	prefixes := [][]byte{calcLotteryStatusPrefix(localPrefix, param.GetStatus())}
+	legacy := calcLotteryLegacyStatusPrefix(param.GetStatus())
+	if localPrefix == calcLocalPrefix(pty.LotteryX) {
+		if direction == ListASC {
+			prefixes = [][]byte{legacy, prefixes[0]}
+		} else {
+			prefixes = append(prefixes, legacy)
+		}
+	}
+	start := 0
+	var key []byte
+	if param.GetPrimaryKey() != "" {
+		key = []byte(param.GetPrimaryKey())
+		start = -1
+		for i, prefix := range prefixes {
+			if bytes.HasPrefix(key, prefix) {
+				start = i
+			}
+		}
+		if start < 0 {
+			llog.Error("ListLotteries", "primaryKey", param.GetPrimaryKey())
+			return nil, types.ErrInvalidParam
+		}
+	}
+
+	var reply pty.ReplyLotteryList
+	var last []byte
+	var listed int32
+	for i := start; i < len(prefixes) && listed < count; i++ {
+		var seek []byte
+		if i == start {
+			seek = key
+		}
+		values, err := db.List(prefixes[i], seek, count-listed, direction)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+		for _, value := range values {
+			listed++
+			lotteryId := string(value)
+			if bytes.Equal(prefixes[i], legacy) {
+				last = calcLotteryLegacyStatusKey(param.GetStatus(), lotteryId)
+			} else {
+				var index pty.LotteryListItem
+				err := types.Decode(value, &index)
+				if err != nil {
+					continue
+				}
+				lotteryId = index.LotteryId
+				last = calcLotteryStatusKey(localPrefix, param.GetStatus(), index.CreateHeight, index.LotteryId)
+			}
+			lottery, err := findLottery(stateDB, lotteryId)
+			if err != nil {
+				llog.Error("ListLotteries", "lotteryId", lotteryId, "err", err)
+				continue
+			}
+			reply.Lotteries = append(reply.Lotteries, &pty.LotteryListItem{
+				LotteryId:    lottery.LotteryId,
+				Status:       lottery.Status,
+				CreateAddr:   lottery.CreateAddr,
+				CreateHeight: lottery.CreateHeight,
+				Round:        lottery.Round,
+				PurBlockNum:  lottery.PurBlockNum,
+				DrawBlockNum: lottery.DrawBlockNum,
+				RoundSales:   roundSales(&LotteryDB{*lottery}),
+				TotalSales:   lottery.TotalSales,
+				TokenSymbol:  lottery.TokenSymbol,
+			})
+		}
+	}
+	//不足一页说明已经取完
+	if listed == count {
+		reply.PrimaryKey = string(last)
+	}
+	return &reply, nil
+}
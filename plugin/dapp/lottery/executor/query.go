@@ -5,6 +5,7 @@
 package executor
 
 import (
+	drivers "github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
 	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
 )
@@ -14,10 +15,21 @@ func (l *Lottery) Query_GetLotteryNormalInfo(param *pty.ReqLotteryInfo) (types.M
 	if err != nil {
 		return nil, err
 	}
-	return &pty.ReplyLotteryNormalInfo{lottery.CreateHeight,
-		lottery.PurBlockNum,
-		lottery.DrawBlockNum,
-		lottery.CreateAddr}, nil
+	return &pty.ReplyLotteryNormalInfo{
+		CreateHeight:   lottery.CreateHeight,
+		PurBlockNum:    lottery.PurBlockNum,
+		DrawBlockNum:   lottery.DrawBlockNum,
+		CreateAddr:     lottery.CreateAddr,
+		TokenSymbol:    lottery.TokenSymbol,
+		MinPurchaseNum: lottery.MinPurchaseNum,
+		MinSalesAmount: lottery.MinSalesAmount,
+		MaxWaitBlocks:  lottery.MaxWaitBlocks,
+		RefundBelowMin: lottery.RefundBelowMin,
+		Drawers:        lottery.Drawers,
+		Admin:          lotteryAdmin(&LotteryDB{*lottery}),
+		Blacklist:      lottery.Blacklist,
+		AssetExec:      lottery.AssetExec,
+	}, nil
 }
 
 func (l *Lottery) Query_GetLotteryPurchaseAddr(param *pty.ReqLotteryInfo) (types.Message, error) {
@@ -50,12 +62,13 @@ func (l *Lottery) Query_GetLotteryCurrentInfo(param *pty.ReqLotteryInfo) (types.
 		PurBlockNum:                lottery.PurBlockNum,
 		DrawBlockNum:               lottery.DrawBlockNum,
 		MissingRecords:             lottery.MissingRecords,
+		TokenSymbol:                lottery.TokenSymbol,
 	}
 	return reply, nil
 }
 
 func (l *Lottery) Query_GetLotteryHistoryLuckyNumber(param *pty.ReqLotteryLuckyHistory) (types.Message, error) {
-	return ListLotteryLuckyHistory(l.GetLocalDB(), l.GetStateDB(), param)
+	return ListLotteryLuckyHistory(l.GetLocalDB(), l.GetStateDB(), l.localPrefix(), param)
 }
 
 func (l *Lottery) Query_GetLotteryRoundLuckyNumber(param *pty.ReqLotteryLuckyInfo) (types.Message, error) {
@@ -66,7 +79,7 @@ func (l *Lottery) Query_GetLotteryRoundLuckyNumber(param *pty.ReqLotteryLuckyInf
 	//	return nil, err
 	//}
 	for _, round := range param.Round {
-		key := calcLotteryDrawKey(param.LotteryId, round)
+		key := calcLotteryDrawKey(l.localPrefix(), param.LotteryId, round)
 		record, err := l.findLotteryDrawRecord(key)
 		if err != nil {
 			return nil, err
@@ -78,14 +91,364 @@ func (l *Lottery) Query_GetLotteryRoundLuckyNumber(param *pty.ReqLotteryLuckyInf
 }
 
 func (l *Lottery) Query_GetLotteryHistoryBuyInfo(param *pty.ReqLotteryBuyHistory) (types.Message, error) {
-	return ListLotteryBuyRecords(l.GetLocalDB(), l.GetStateDB(), param)
+	return ListLotteryBuyRecords(l.GetLocalDB(), l.GetStateDB(), l.localPrefix(), param)
 }
 
 func (l *Lottery) Query_GetLotteryBuyRoundInfo(param *pty.ReqLotteryBuyInfo) (types.Message, error) {
-	key := calcLotteryBuyRoundPrefix(param.LotteryId, param.Addr, param.Round)
+	key := calcLotteryBuyRoundPrefix(l.localPrefix(), param.LotteryId, param.Addr, param.Round)
 	record, err := l.findLotteryBuyRecords(key)
 	if err != nil {
 		return nil, err
 	}
+	record.TokenSymbol = l.tokenSymbol(param.LotteryId)
 	return record, nil
 }
+
+func (l *Lottery) Query_GetBuyRecordsByAddr(param *pty.ReqLotteryBuyRecordsByAddr) (types.Message, error) {
+	reply, err := ListLotteryBuyRecordsByAddr(l.GetLocalDB(), l.localPrefix(), param)
+	if err != nil {
+		return nil, err
+	}
+	records := reply.(*pty.ReplyLotteryBuyRecordsByAddr)
+	records.TokenSymbol = l.tokenSymbol(param.LotteryId)
+	return records, nil
+}
+
+func (l *Lottery) Query_GetBuyByTxHash(param *pty.ReqLotteryBuyByTxHash) (types.Message, error) {
+	reply, err := GetLotteryBuyByTxHash(l.GetLocalDB(), l.localPrefix(), param)
+	if err != nil {
+		return nil, err
+	}
+	records := reply.(*pty.ReplyLotteryBuyByTxHash)
+	records.TokenSymbol = l.tokenSymbol(param.LotteryId)
+	return records, nil
+}
+
+func (l *Lottery) Query_GetWinnersByRound(param *pty.ReqLotteryRoundWinners) (types.Message, error) {
+	winners, err := l.findLotteryRoundWinners(param.LotteryId, param.Round)
+	if err != nil {
+		return nil, err
+	}
+	winners.TokenSymbol = l.tokenSymbol(param.LotteryId)
+	return winners, nil
+}
+
+func (l *Lottery) Query_GetRoundsInfo(param *pty.ReqLotteryRoundsInfo) (types.Message, error) {
+	return ListLotteryRoundsInfo(l.GetLocalDB(), l.GetStateDB(), l.localPrefix(), param)
+}
+
+func (l *Lottery) Query_ListLotteries(param *pty.ReqLotteryList) (types.Message, error) {
+	return ListLotteries(l.GetLocalDB(), l.GetStateDB(), l.localPrefix(), param)
+}
+
+//累计统计, 还没有购买的彩票返回全部为0的统计
+func (l *Lottery) Query_GetLotteryStats(param *pty.ReqLotteryInfo) (types.Message, error) {
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	stats := l.findLotteryStats(lottery.LotteryId)
+	stats.TokenSymbol = lottery.TokenSymbol
+	return stats, nil
+}
+
+//Query_GetAddrWinnings 地址在彩票中累计的中奖金额和购买金额
+func (l *Lottery) Query_GetAddrWinnings(param *pty.ReqLotteryAddrWinnings) (types.Message, error) {
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	won := l.findLocalInt64(calcLotteryAddrWonKey(l.localPrefix(), lottery.LotteryId, param.GetAddr()))
+	spent := l.findLocalInt64(calcLotteryAddrSpentKey(l.localPrefix(), lottery.LotteryId, param.GetAddr())) * assetPrecision(&LotteryDB{*lottery})
+	return &pty.LotteryAddrWinnings{
+		LotteryId:   lottery.LotteryId,
+		Addr:        param.GetAddr(),
+		TotalWon:    won,
+		TotalSpent:  spent,
+		Net:         won - spent,
+		TokenSymbol: lottery.TokenSymbol,
+	}, nil
+}
+
+//Query_GetLeaderboard 按购买金额或者中奖金额排名的前topN 个地址, 最多maxBoardSize 个
+func (l *Lottery) Query_GetLeaderboard(param *pty.ReqLotteryLeaderboard) (types.Message, error) {
+	if param.GetMetric() != pty.LotteryBoardSpent && param.GetMetric() != pty.LotteryBoardWinnings {
+		return nil, types.ErrInvalidParam
+	}
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	topN := int(param.GetTopN())
+	if topN <= 0 {
+		topN = int(DefultCount)
+	}
+	if topN > maxBoardSize {
+		topN = maxBoardSize
+	}
+	entries := l.findLeaderboard(calcLotteryBoardKey(l.localPrefix(), lottery.LotteryId, param.GetMetric())).Entries
+	if len(entries) > topN {
+		entries = entries[:topN]
+	}
+	for i, entry := range entries {
+		entry.Rank = int32(i + 1)
+	}
+	return &pty.ReplyLotteryLeaderboard{
+		LotteryId:   lottery.LotteryId,
+		Metric:      param.GetMetric(),
+		Entries:     entries,
+		TokenSymbol: lottery.TokenSymbol,
+	}, nil
+}
+
+//Query_GetNumberHeat 一轮中每个号码的购买数量, 只返回有人购买的号码
+func (l *Lottery) Query_GetNumberHeat(param *pty.ReqLotteryNumberHeat) (types.Message, error) {
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	reply := &pty.ReplyLotteryNumberHeat{LotteryId: lottery.LotteryId, Round: param.GetRound()}
+	values, err := l.GetLocalDB().List(calcLotteryHeatPrefix(l.localPrefix(), lottery.LotteryId, param.GetRound()), nil, 0, ListASC)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	for _, value := range values {
+		var heat pty.LotteryNumberHeat
+		if err := types.Decode(value, &heat); err != nil {
+			continue
+		}
+		reply.Numbers = append(reply.Numbers, &heat)
+	}
+	return reply, nil
+}
+
+//Query_GetCurrentPool 进行中的轮次累计的购买数量, 从localdb 的计数中读取, 不需要遍历购买记录
+func (l *Lottery) Query_GetCurrentPool(param *pty.ReqLotteryInfo) (types.Message, error) {
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	//开奖之后下一笔购买才开始新的一轮
+	round := lottery.Round
+	if lottery.Status == pty.LotteryCreated || lottery.Status == pty.LotteryDrawed {
+		round++
+	}
+	return &pty.ReplyLotteryCurrentPool{
+		LotteryId:   lottery.LotteryId,
+		Round:       round,
+		Amount:      l.findLocalInt64(calcLotteryRoundPoolKey(l.localPrefix(), lottery.LotteryId, round)),
+		TokenSymbol: lottery.TokenSymbol,
+	}, nil
+}
+
+//Query_GetLotteryFullInfo 彩票的参数, 当前轮次和最近一次开奖, addr 不为空时加上该地址在当前轮次的购买
+func (l *Lottery) Query_GetLotteryFullInfo(param *pty.ReqLotteryFullInfo) (types.Message, error) {
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	lott := &LotteryDB{*lottery}
+	reply := &pty.ReplyLotteryFullInfo{
+		LotteryId:    lottery.LotteryId,
+		Status:       lottery.Status,
+		CreateAddr:   lottery.CreateAddr,
+		Admin:        lotteryAdmin(lott),
+		CreateHeight: lottery.CreateHeight,
+		Config:       lotteryConfig(lottery),
+		Round:        lottery.Round,
+		Fund:         lottery.Fund,
+		EscrowAddr:   lottery.EscrowAddr,
+		TokenSymbol:  lottery.TokenSymbol,
+	}
+	//开奖之后的购买记录属于上一轮, 下一笔购买才开始新的一轮
+	inRound := lottery.Status == pty.LotteryPurchase || lottery.Status == pty.LotteryCommitted
+	if lottery.Status == pty.LotteryCreated || lottery.Status == pty.LotteryDrawed {
+		reply.Round++
+	}
+	reply.RoundSales = l.findLocalInt64(calcLotteryRoundPoolKey(l.localPrefix(), lottery.LotteryId, reply.Round))
+	if inRound {
+		reply.RoundTxNum = lottery.TotalPurchasedTxNum
+		reply.RoundBuyerNum = int32(len(lottery.Records))
+	}
+	reply.LastDraw, err = l.findLastDrawRound(lottery.LotteryId)
+	if err != nil {
+		return nil, err
+	}
+	if param.GetAddr() != "" {
+		//开奖之后到下一笔购买之前, 返回刚开奖的轮次, 可以看到中奖金额
+		addrRound := reply.Round
+		if lottery.Status == pty.LotteryDrawed {
+			addrRound = lottery.Round
+		}
+		reply.AddrInfo, err = l.findAddrRoundInfo(lott, param.GetAddr(), addrRound, inRound)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reply, nil
+}
+
+//Query_GetAgentSales 代理的销售数量和佣金, round 为0时返回所有轮次的累计
+func (l *Lottery) Query_GetAgentSales(param *pty.ReqLotteryAgentSales) (types.Message, error) {
+	if param.GetAgentAddr() == "" || param.GetRound() < 0 {
+		return nil, types.ErrInvalidParam
+	}
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	return l.findAgentSales(lottery.LotteryId, param.GetAgentAddr(), param.GetRound()), nil
+}
+
+//Query_GetModifyRecords 开奖地址的修改历史, 最新的在前
+func (l *Lottery) Query_GetModifyRecords(param *pty.ReqLotteryInfo) (types.Message, error) {
+	values, err := l.GetLocalDB().List(calcLotteryModifyPrefix(l.localPrefix(), param.GetLotteryId()), nil, MaxCount, ListDESC)
+	if err != nil {
+		return nil, err
+	}
+	var records pty.ReplyLotteryModifyRecords
+	for _, value := range values {
+		var record pty.ReceiptLotteryModify
+		err := types.Decode(value, &record)
+		if err != nil {
+			continue
+		}
+		records.Records = append(records.Records, &record)
+	}
+	return &records, nil
+}
+
+//Query_GetTransferRecords 管理地址的移交历史, 最新的在前
+func (l *Lottery) Query_GetTransferRecords(param *pty.ReqLotteryInfo) (types.Message, error) {
+	values, err := l.GetLocalDB().List(calcLotteryTransferPrefix(l.localPrefix(), param.GetLotteryId()), nil, MaxCount, ListDESC)
+	if err != nil {
+		return nil, err
+	}
+	var records pty.ReplyLotteryTransferRecords
+	for _, value := range values {
+		var record pty.ReceiptLotteryTransfer
+		err := types.Decode(value, &record)
+		if err != nil {
+			continue
+		}
+		records.Records = append(records.Records, &record)
+	}
+	return &records, nil
+}
+
+//Query_GetConfigHistory 开奖地址, 管理地址和黑名单的修改历史, 按高度从早到晚, 需要打开saveConfigHistory
+func (l *Lottery) Query_GetConfigHistory(param *pty.ReqLotteryInfo) (types.Message, error) {
+	if !cfg.SaveConfigHistory {
+		return nil, types.ErrActionNotSupport
+	}
+	values, err := l.GetLocalDB().List(calcLotteryConfigPrefix(l.localPrefix(), param.GetLotteryId()), nil, MaxCount, ListASC)
+	if err != nil {
+		return nil, err
+	}
+	var records pty.ReplyLotteryConfigHistory
+	for _, value := range values {
+		var record pty.LotteryConfigChange
+		err := types.Decode(value, &record)
+		if err != nil {
+			continue
+		}
+		records.Records = append(records.Records, &record)
+	}
+	return &records, nil
+}
+
+//Query_GetDrawProof 查询某一轮开奖号码的推导输入, 可以用 LotteryDrawProofNumber 在链下重新计算
+func (l *Lottery) Query_GetDrawProof(param *pty.ReqLotteryDrawProof) (types.Message, error) {
+	value, err := l.GetLocalDB().Get(calcLotteryDrawProofKey(l.localPrefix(), param.GetLotteryId(), param.GetRound()))
+	if err != nil {
+		return nil, err
+	}
+	var proof pty.LotteryDrawProof
+	err = types.Decode(value, &proof)
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+//Query_VerifyDraw 用轮次信息中的seed 重新选出中奖号码, 检查和记录的中奖号码是否一致
+func (l *Lottery) Query_VerifyDraw(param *pty.ReqLotteryDrawProof) (types.Message, error) {
+	if param.GetLotteryId() == "" || param.GetRound() <= 0 {
+		return nil, types.ErrInvalidParam
+	}
+	return l.verifyLotteryDraw(param.GetLotteryId(), param.GetRound())
+}
+
+func (l *Lottery) Query_GetRefundRecords(param *pty.ReqLotteryRefundRecords) (types.Message, error) {
+	key := calcLotteryRefundPrefix(l.localPrefix(), param.LotteryId, param.Addr)
+	values, err := l.GetLocalDB().List(key, nil, MaxCount, ListDESC)
+	if err != nil {
+		return nil, err
+	}
+	var records pty.ReplyLotteryRefundRecords
+	for _, value := range values {
+		var record pty.ReceiptLotteryRefund
+		err := types.Decode(value, &record)
+		if err != nil {
+			continue
+		}
+		records.Records = append(records.Records, &record)
+	}
+	records.TokenSymbol = l.tokenSymbol(param.LotteryId)
+	return &records, nil
+}
+
+//地址在一个彩票中锁定的奖金和可以领取的高度
+func (l *Lottery) Query_GetPayouts(param *pty.ReqLotteryPayouts) (types.Message, error) {
+	if param.GetLotteryId() == "" || param.GetAddr() == "" {
+		return nil, types.ErrInvalidParam
+	}
+	key := calcLotteryPayoutPrefix(l.localPrefix(), param.LotteryId, param.Addr)
+	values, err := l.GetLocalDB().List(key, nil, MaxCount, ListASC)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	var reply pty.ReplyLotteryPayouts
+	for _, value := range values {
+		var payout pty.LotteryPayout
+		if err := types.Decode(value, &payout); err != nil {
+			continue
+		}
+		reply.Payouts = append(reply.Payouts, &payout)
+	}
+	reply.TokenSymbol = l.tokenSymbol(param.LotteryId)
+	return &reply, nil
+}
+
+//奖池地址在合约中的余额, 没有奖池地址的旧彩票按奖池数量返回
+func (l *Lottery) Query_GetPrizePool(param *pty.ReqLotteryInfo) (types.Message, error) {
+	lottery, err := findLottery(l.GetStateDB(), param.GetLotteryId())
+	if err != nil {
+		return nil, err
+	}
+	reply := &pty.ReplyLotteryPrizePool{
+		LotteryId:   lottery.LotteryId,
+		EscrowAddr:  lottery.EscrowAddr,
+		TokenSymbol: lottery.TokenSymbol,
+	}
+	if lottery.EscrowAddr == "" {
+		reply.Balance = lottery.Fund * assetPrecision(&LotteryDB{*lottery})
+		return reply, nil
+	}
+	accDB, err := newAssetAccount(l.GetCoinsAccount(), l.GetStateDB(), lottery.AssetExec, lottery.TokenSymbol)
+	if err != nil {
+		return nil, err
+	}
+	execaddr := drivers.ExecAddress(types.ExecName(pty.LotteryX))
+	reply.Balance = accDB.LoadExecAccount(lottery.EscrowAddr, execaddr).GetBalance()
+	return reply, nil
+}
+
+//金额使用的token, 为空时表示coins
+func (l *Lottery) tokenSymbol(lotteryId string) string {
+	lottery, err := findLottery(l.GetStateDB(), lotteryId)
+	if err != nil {
+		return ""
+	}
+	return lottery.TokenSymbol
+}
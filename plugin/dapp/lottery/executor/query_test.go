@@ -0,0 +1,371 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+	"testing"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testLotteryId = "0xlottery"
+	testBuyer     = "1KSBd17H7ZK8iT37aJztFB22XGwsPTdwE4"
+	testOther     = "1JRNjdEqp4LJ5fqycUBm9ayCKSeeskgMKR"
+)
+
+func newTestLottery(t *testing.T) *Lottery {
+	db, err := dbm.NewGoMemDB("lottery", "lottery", 128)
+	assert.Nil(t, err)
+	l := newLottery().(*Lottery)
+	l.SetLocalDB(dbm.NewKVDB(db))
+	statedb, err := dbm.NewGoMemDB("lotterystate", "lotterystate", 128)
+	assert.Nil(t, err)
+	l.SetStateDB(&testStateDB{dbm.NewKVDB(statedb)})
+	return l
+}
+
+func setLocalKVs(t *testing.T, l *Lottery, kvs []*types.KeyValue) {
+	for _, kv := range kvs {
+		if kv.Value == nil {
+			assert.Nil(t, l.GetLocalDB().(*dbm.KVDBList).Delete(kv.Key))
+			continue
+		}
+		assert.Nil(t, l.GetLocalDB().Set(kv.Key, kv.Value))
+	}
+}
+
+//每轮50条, 共3轮150条购买记录
+func saveTestBuys(t *testing.T, l *Lottery) {
+	for i := int64(0); i < 150; i++ {
+		buy := &pty.ReceiptLottery{
+			LotteryId: testLotteryId,
+			Addr:      testBuyer,
+			Round:     i/50 + 1,
+			Number:    i,
+			Amount:    1,
+			Way:       FiveStar,
+			Index:     i * 10,
+			TxHash:    fmt.Sprintf("0xbuy%d", i),
+		}
+		setLocalKVs(t, l, l.saveLotteryBuy(buy))
+	}
+	//干扰数据: 其他地址的购买记录
+	other := &pty.ReceiptLottery{LotteryId: testLotteryId, Addr: testOther, Round: 1, Number: 7, Amount: 1, Index: 5}
+	setLocalKVs(t, l, l.saveLotteryBuy(other))
+}
+
+func listAllBuys(t *testing.T, l *Lottery, round int64, count int32, direction int32) []*pty.LotteryBuyEntry {
+	var all []*pty.LotteryBuyEntry
+	req := &pty.ReqLotteryBuyRecordsByAddr{LotteryId: testLotteryId, Addr: testBuyer, Round: round, Count: count, Direction: direction}
+	for {
+		msg, err := l.Query_GetBuyRecordsByAddr(req)
+		assert.Nil(t, err)
+		reply := msg.(*pty.ReplyLotteryBuyRecordsByAddr)
+		assert.True(t, int32(len(reply.Records)) <= count)
+		all = append(all, reply.Records...)
+		if reply.PrimaryKey == "" {
+			break
+		}
+		req.PrimaryKey = reply.PrimaryKey
+	}
+	return all
+}
+
+func TestQueryBuyRecordsByAddr(t *testing.T) {
+	l := newTestLottery(t)
+	saveTestBuys(t, l)
+
+	asc := listAllBuys(t, l, 0, 20, ListASC)
+	assert.Equal(t, 150, len(asc))
+	for i, entry := range asc {
+		assert.Equal(t, int64(i), entry.Number)
+		assert.Equal(t, int64(i)*10, entry.Index)
+		assert.Equal(t, int64(i)/50+1, entry.Round)
+		assert.Equal(t, fmt.Sprintf("0xbuy%d", i), entry.TxHash)
+	}
+
+	desc := listAllBuys(t, l, 0, 100, ListDESC)
+	assert.Equal(t, 150, len(desc))
+	for i, entry := range desc {
+		assert.Equal(t, int64(149-i), entry.Number)
+	}
+
+	round := listAllBuys(t, l, 2, 30, ListASC)
+	assert.Equal(t, 50, len(round))
+	for i, entry := range round {
+		assert.Equal(t, int64(2), entry.Round)
+		assert.Equal(t, int64(50+i), entry.Number)
+	}
+}
+
+func TestQueryBuyRecordsByAddrDrawn(t *testing.T) {
+	l := newTestLottery(t)
+	saveTestBuys(t, l)
+	draw := &pty.ReceiptLottery{LotteryId: testLotteryId, Round: 1, LuckyNumber: 12345}
+	setLocalKVs(t, l, l.saveLotteryDraw(draw))
+
+	for _, entry := range listAllBuys(t, l, 0, 100, ListASC) {
+		assert.Equal(t, entry.Round == 1, entry.Drawn)
+	}
+}
+
+func TestQueryBuyRecordsByAddrBadKey(t *testing.T) {
+	l := newTestLottery(t)
+	saveTestBuys(t, l)
+	req := &pty.ReqLotteryBuyRecordsByAddr{
+		LotteryId:  testLotteryId,
+		Addr:       testBuyer,
+		Round:      1,
+		PrimaryKey: string(calcLotteryBuyKey(calcLocalPrefix(driverName), testLotteryId, testOther, 1, 5)),
+	}
+	_, err := l.Query_GetBuyRecordsByAddr(req)
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	req = &pty.ReqLotteryBuyRecordsByAddr{LotteryId: testLotteryId, Addr: "nobody"}
+	msg, err := l.Query_GetBuyRecordsByAddr(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(msg.(*pty.ReplyLotteryBuyRecordsByAddr).Records))
+}
+
+func drawReceipt(round int64, updateInfo *pty.LotteryUpdateBuyInfo) *types.ReceiptData {
+	draw := &pty.ReceiptLottery{
+		LotteryId:   testLotteryId,
+		Status:      pty.LotteryDrawed,
+		PrevStatus:  pty.LotteryPurchase,
+		Round:       round,
+		LuckyNumber: 12345,
+		UpdateInfo:  updateInfo,
+	}
+	log := &types.ReceiptLog{Ty: pty.TyLogLotteryDraw, Log: types.Encode(draw)}
+	return &types.ReceiptData{Ty: types.ExecOk, Logs: []*types.ReceiptLog{log}}
+}
+
+func TestQueryWinnersByRound(t *testing.T) {
+	l := newTestLottery(t)
+	updateInfo := &pty.LotteryUpdateBuyInfo{
+		BuyInfo: map[string]*pty.LotteryUpdateRecs{
+			testBuyer: {Records: []*pty.LotteryUpdateRec{
+				{Index: 10, Type: FiveStar, Amount: exciting * decimal},
+				{Index: 20, Type: OneStar, Amount: 2 * notbad * decimal},
+			}},
+			testOther: {Records: []*pty.LotteryUpdateRec{
+				{Index: 15, Type: ThreeStar, Amount: lucky * decimal},
+			}},
+		},
+	}
+	receipt := drawReceipt(1, updateInfo)
+	set, err := l.ExecLocal_Draw(nil, nil, receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, l, set.KV)
+
+	msg, err := l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: testLotteryId, Round: 1})
+	assert.Nil(t, err)
+	reply := msg.(*pty.ReplyLotteryRoundWinners)
+	assert.Equal(t, int64(1), reply.Round)
+	assert.Equal(t, 3, len(reply.Records))
+	var total int64
+	for _, record := range reply.Records {
+		var found bool
+		for _, rec := range updateInfo.BuyInfo[record.Addr].Records {
+			if rec.Index == record.Index {
+				found = true
+				assert.Equal(t, rec.Type, record.Level)
+				assert.Equal(t, rec.Amount, record.Amount)
+			}
+		}
+		assert.True(t, found)
+		total += record.Amount
+	}
+	assert.Equal(t, total, reply.TotalPayout)
+	assert.Equal(t, (exciting+lucky+2*notbad)*int64(decimal), reply.TotalPayout)
+
+	//回滚之后中奖记录应该被删除
+	set, err = l.ExecDelLocal_Draw(nil, nil, receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, l, set.KV)
+	msg, err = l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: testLotteryId, Round: 1})
+	assert.Nil(t, err)
+	reply = msg.(*pty.ReplyLotteryRoundWinners)
+	assert.Equal(t, 0, len(reply.Records))
+	assert.Equal(t, int64(0), reply.TotalPayout)
+}
+
+func TestQueryWinnersByRoundNoWinner(t *testing.T) {
+	l := newTestLottery(t)
+	set, err := l.ExecLocal_Draw(nil, nil, drawReceipt(2, nil), 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, l, set.KV)
+
+	msg, err := l.Query_GetWinnersByRound(&pty.ReqLotteryRoundWinners{LotteryId: testLotteryId, Round: 2})
+	assert.Nil(t, err)
+	reply := msg.(*pty.ReplyLotteryRoundWinners)
+	assert.Equal(t, int64(2), reply.Round)
+	assert.Equal(t, 0, len(reply.Records))
+	assert.Equal(t, int64(0), reply.TotalPayout)
+}
+
+//模拟彩票在状态数据库中的变化, 并执行对应回执的ExecLocal
+type roundsEnv struct {
+	t      *testing.T
+	l      *Lottery
+	lott   *LotteryDB
+	action *Action
+}
+
+func newRoundsEnv(t *testing.T) *roundsEnv {
+	l := newTestLottery(t)
+	db, err := dbm.NewGoMemDB("lotterystate", "lotterystate", 128)
+	assert.Nil(t, err)
+	l.SetStateDB(dbm.NewKVDB(db))
+	env := &roundsEnv{
+		t:      t,
+		l:      l,
+		lott:   &LotteryDB{pty.Lottery{LotteryId: testLotteryId, Status: pty.LotteryCreated}},
+		action: &Action{txhash: []byte("lottery"), blocktime: 1000},
+	}
+	env.exec(0, pty.TyLogLotteryCreate, 0, 0, nil)
+	return env
+}
+
+func (env *roundsEnv) exec(preStatus int32, logTy int32, amount int64, luckyNum int64, updateInfo *pty.LotteryUpdateBuyInfo) {
+	env.lott.Save(env.l.GetStateDB())
+	log := env.action.GetReceiptLog(&env.lott.Lottery, preStatus, logTy, env.lott.Round, 0, amount, 0, luckyNum, updateInfo)
+	receipt := &types.ReceiptData{Ty: types.ExecOk, Logs: []*types.ReceiptLog{log}}
+	set, err := env.l.execLocal(nil, receipt)
+	assert.Nil(env.t, err)
+	setLocalKVs(env.t, env.l, set.KV)
+	env.action.blocktime++
+}
+
+func (env *roundsEnv) buy(addr string, amount int64) {
+	preStatus := env.lott.Status
+	if env.lott.Status != pty.LotteryPurchase {
+		env.lott.Status = pty.LotteryPurchase
+		env.lott.Round++
+	}
+	if env.lott.Records == nil {
+		env.lott.Records = make(map[string]*pty.PurchaseRecords)
+	}
+	if _, ok := env.lott.Records[addr]; !ok {
+		env.lott.Records[addr] = &pty.PurchaseRecords{}
+	}
+	env.lott.Records[addr].AmountOneRound += amount
+	env.lott.Fund += amount
+	env.exec(preStatus, pty.TyLogLotteryBuy, amount, 0, nil)
+}
+
+func (env *roundsEnv) draw(luckyNum int64, payout int64) {
+	sales := roundSales(env.lott)
+	env.lott.Records = nil
+	env.lott.Status = pty.LotteryDrawed
+	env.lott.LuckyNumber = luckyNum
+	updateInfo := &pty.LotteryUpdateBuyInfo{BuyInfo: map[string]*pty.LotteryUpdateRecs{}}
+	if payout > 0 {
+		updateInfo.BuyInfo[testBuyer] = &pty.LotteryUpdateRecs{Records: []*pty.LotteryUpdateRec{{Index: 1, Type: OneStar, Amount: payout}}}
+	}
+	env.exec(pty.LotteryPurchase, pty.TyLogLotteryDraw, sales, luckyNum, updateInfo)
+}
+
+func (env *roundsEnv) close() {
+	preStatus := env.lott.Status
+	sales := roundSales(env.lott)
+	env.lott.Records = nil
+	env.lott.Status = pty.LotteryClosed
+	env.exec(preStatus, pty.TyLogLotteryClose, sales, 0, nil)
+}
+
+func (env *roundsEnv) rounds(fromRound int64, count int32, direction int32) []*pty.LotteryRoundInfo {
+	req := &pty.ReqLotteryRoundsInfo{LotteryId: testLotteryId, FromRound: fromRound, Count: count, Direction: direction}
+	msg, err := env.l.Query_GetRoundsInfo(req)
+	assert.Nil(env.t, err)
+	return msg.(*pty.ReplyLotteryRoundsInfo).Rounds
+}
+
+func roundNumbers(rounds []*pty.LotteryRoundInfo) []int64 {
+	var numbers []int64
+	for _, round := range rounds {
+		numbers = append(numbers, round.Round)
+	}
+	return numbers
+}
+
+func TestQueryRoundsInfo(t *testing.T) {
+	env := newRoundsEnv(t)
+	assert.Equal(t, 0, len(env.rounds(0, 0, ListDESC)))
+
+	//3轮购买和开奖, 第i轮销售额为10*i
+	for i := int64(1); i <= 3; i++ {
+		env.buy(testBuyer, 5*i)
+		env.buy(testOther, 5*i)
+		env.draw(i, i*decimal)
+	}
+	//第4轮还在购买中
+	env.buy(testBuyer, 7)
+
+	rounds := env.rounds(0, 0, ListDESC)
+	assert.Equal(t, []int64{4, 3, 2, 1}, roundNumbers(rounds))
+	assert.Equal(t, int32(pty.LotteryPurchase), rounds[0].Status)
+	assert.Equal(t, int64(7), rounds[0].TotalSales)
+	for _, round := range rounds[1:] {
+		assert.Equal(t, int32(pty.LotteryDrawed), round.Status)
+		assert.Equal(t, 10*round.Round, round.TotalSales)
+		assert.Equal(t, round.Round, round.LuckyNumber)
+		assert.Equal(t, round.Round*decimal, round.TotalPayout)
+		assert.NotEqual(t, "", round.TxHash)
+	}
+
+	//分页
+	assert.Equal(t, []int64{4, 3}, roundNumbers(env.rounds(0, 2, ListDESC)))
+	assert.Equal(t, []int64{2, 1}, roundNumbers(env.rounds(3, 2, ListDESC)))
+	assert.Equal(t, []int64{3, 2}, roundNumbers(env.rounds(4, 2, ListDESC)))
+	assert.Equal(t, []int64{4}, roundNumbers(env.rounds(3, 2, ListASC)))
+	assert.Equal(t, []int64{1, 2}, roundNumbers(env.rounds(0, 2, ListASC)))
+	assert.Equal(t, []int64{3, 4}, roundNumbers(env.rounds(2, 2, ListASC)))
+	assert.Equal(t, 0, len(env.rounds(4, 2, ListASC)))
+
+	//购买期间关闭, 当前轮次记为关闭状态
+	env.close()
+	rounds = env.rounds(0, 0, ListDESC)
+	assert.Equal(t, []int64{4, 3, 2, 1}, roundNumbers(rounds))
+	assert.Equal(t, int32(pty.LotteryClosed), rounds[0].Status)
+	assert.Equal(t, int64(7), rounds[0].TotalSales)
+	assert.Equal(t, int64(0), rounds[0].TotalPayout)
+}
+
+func TestQueryRoundsInfoRollback(t *testing.T) {
+	env := newRoundsEnv(t)
+	env.buy(testBuyer, 10)
+	env.draw(1, 0)
+
+	env.lott.Status = pty.LotteryClosed
+	env.lott.Save(env.l.GetStateDB())
+	log := env.action.GetReceiptLog(&env.lott.Lottery, pty.LotteryDrawed, pty.TyLogLotteryClose, env.lott.Round, 0, 0, 0, 0, nil)
+	receipt := &types.ReceiptData{Ty: types.ExecOk, Logs: []*types.ReceiptLog{log}}
+	set, err := env.l.ExecLocal_Close(nil, nil, receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	//开奖之后再关闭, 不会覆盖已经开奖的轮次
+	rounds := env.rounds(0, 0, ListDESC)
+	assert.Equal(t, []int64{1}, roundNumbers(rounds))
+	assert.Equal(t, int32(pty.LotteryDrawed), rounds[0].Status)
+	set, err = env.l.ExecDelLocal_Close(nil, nil, receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, 1, len(env.rounds(0, 0, ListDESC)))
+
+	//回滚开奖之后历史轮次被删除
+	env.lott.Status = pty.LotteryDrawed
+	log = env.action.GetReceiptLog(&env.lott.Lottery, pty.LotteryPurchase, pty.TyLogLotteryDraw, 1, 0, 10, 0, 1, &pty.LotteryUpdateBuyInfo{})
+	receipt = &types.ReceiptData{Ty: types.ExecOk, Logs: []*types.ReceiptLog{log}}
+	set, err = env.l.ExecDelLocal_Draw(nil, nil, receipt, 0)
+	assert.Nil(t, err)
+	setLocalKVs(t, env.l, set.KV)
+	assert.Equal(t, 0, len(env.rounds(0, 0, ListDESC)))
+}
@@ -0,0 +1,551 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//Package replay 按JSON 场景文件重放彩票交易: 依次调用Exec/ExecLocal, 检查状态数据, 账户和查询结果,
+//回滚时调用ExecDelLocal, 检查状态数据和localdb 恢复到执行这些区块之前.
+//状态数据和localdb 都在内存中, 开奖需要的区块由固定的假数据提供, 相同的场景总是得到相同的结果.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/lottery/executor"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	tickettypes "github.com/33cn/plugin/plugin/dapp/ticket/types"
+)
+
+//没有指定时每个账户在彩票合约中的coins 余额
+const defaultBalance = 10000 * 100000000
+
+//manage 中配置的彩票创建者, 和executor 中的creatorKey 相同
+const creatorKey = "lottery-creator"
+
+//Scenario 一个重放场景, 账户用别名表示, 步骤依次执行
+type Scenario struct {
+	Name     string            `json:"name"`
+	Accounts map[string]string `json:"accounts"` //别名 -> 16进制私钥
+	Balance  int64             `json:"balance"`  //每个账户在彩票合约中的coins 余额, 0时使用默认值
+	Creators []string          `json:"creators"` //可以创建彩票的账户别名
+	Steps    []*Step           `json:"steps"`
+}
+
+//Step 每一步最多执行一笔交易, 每笔交易单独一个区块.
+//payload 和expect 中的字符串 "$别名" 替换为保存的彩票id, "@别名" 替换为账户地址
+type Step struct {
+	Name      string          `json:"name"`
+	Rollback  int             `json:"rollback"` //先回滚最近的n 个区块, 检查状态数据和localdb 和执行这些区块之前相同
+	Blocks    int64           `json:"blocks"`   //执行交易之前前进的空区块数
+	Signer    string          `json:"signer"`
+	Action    string          `json:"action"`  //和rpc 构造交易的action 相同, 例如LotteryCreate, LotteryBuy, 为空时不执行交易
+	Payload   json.RawMessage `json:"payload"` //action 对应的参数, 例如LotteryBuyTx
+	Save      string          `json:"save"`    //把交易hash 保存为彩票别名
+	ExpectErr string          `json:"expectErr"`
+	Expect    json.RawMessage `json:"expect"` //格式见Expect
+}
+
+//Expect 只检查列出的字段. 和proto json 一样int64 是字符串, 比较时数字统一转成字符串
+type Expect struct {
+	Lottery map[string]map[string]interface{} `json:"lottery"` //彩票别名 -> 状态数据中的字段
+	Balance map[string]map[string]interface{} `json:"balance"` //账户别名 -> 在彩票合约中的coins 账户
+	Query   []*QueryExpect                    `json:"query"`
+}
+
+//QueryExpect 调用执行器的Query_ 函数, 检查返回的字段或者错误
+type QueryExpect struct {
+	FuncName string                 `json:"funcName"`
+	Param    json.RawMessage        `json:"param"`
+	Result   map[string]interface{} `json:"result"`
+	Err      string                 `json:"err"`
+}
+
+//LoadScenario 读取场景文件
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Scenario
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &s, nil
+}
+
+//Run 执行场景中的全部步骤, 返回第一个不符合期望的步骤
+func Run(s *Scenario) error {
+	r, err := NewRunner(s)
+	if err != nil {
+		return err
+	}
+	for i, step := range s.Steps {
+		if err := r.Step(step); err != nil {
+			return fmt.Errorf("%s: step %d %s: %v", s.Name, i, step.Name, err)
+		}
+	}
+	return nil
+}
+
+//Runner 保存重放过程中的数据库和已经执行的区块
+type Runner struct {
+	l         *executor.Lottery
+	state     *memKV
+	localdb   dbm.KVDB
+	local     map[string][]byte //ExecLocal/ExecDelLocal 返回的kv 写入之后的localdb
+	coins     *account.DB
+	privs     map[string]crypto.PrivKey
+	addrs     map[string]string
+	lotteries map[string]string
+	height    int64
+	blocktime int64
+	blocks    []*block
+}
+
+//执行成功的区块, 以及执行之前的状态数据和localdb, 回滚时用来比较
+type block struct {
+	height  int64
+	tx      *types.Transaction
+	receipt *types.ReceiptData
+	state   map[string][]byte
+	local   map[string][]byte
+}
+
+//NewRunner 创建账户和创建者配置, 每个账户在彩票合约中充值
+func NewRunner(s *Scenario) (*Runner, error) {
+	mem, err := dbm.NewGoMemDB("replaylocal", "replaylocal", 128)
+	if err != nil {
+		return nil, err
+	}
+	r := &Runner{
+		state:     &memKV{data: make(map[string][]byte)},
+		localdb:   dbm.NewKVDB(mem),
+		local:     make(map[string][]byte),
+		privs:     make(map[string]crypto.PrivKey),
+		addrs:     make(map[string]string),
+		lotteries: make(map[string]string),
+		height:    100,
+		blocktime: 1539918074,
+	}
+	r.l = executor.NewLotteryWithDB(r.state, r.localdb, &fakeAPI{})
+	r.coins = account.NewCoinsAccount()
+	r.coins.SetDB(r.state)
+
+	c, err := crypto.New(types.GetSignName(pty.LotteryX, types.SECP256K1))
+	if err != nil {
+		return nil, err
+	}
+	balance := s.Balance
+	if balance == 0 {
+		balance = defaultBalance
+	}
+	execaddr := address.ExecAddress(pty.LotteryX)
+	for _, name := range sortedKeys(s.Accounts) {
+		data, err := common.FromHex(s.Accounts[name])
+		if err != nil {
+			return nil, err
+		}
+		priv, err := c.PrivKeyFromBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		addr := address.PubKeyToAddress(priv.PubKey().Bytes()).String()
+		r.privs[name] = priv
+		r.addrs[name] = addr
+		r.coins.SaveExecAccount(execaddr, &types.Account{Addr: addr, Balance: balance})
+	}
+	var creators []string
+	for _, name := range s.Creators {
+		addr, ok := r.addrs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown creator %s", name)
+		}
+		creators = append(creators, addr)
+	}
+	item := &types.ConfigItem{
+		Key:   creatorKey,
+		Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: creators}},
+	}
+	r.state.Set([]byte(types.ManageKey(creatorKey)), types.Encode(item))
+	return r, nil
+}
+
+//Step 执行一个步骤
+func (r *Runner) Step(step *Step) error {
+	if step.Rollback > 0 {
+		if err := r.rollback(step.Rollback); err != nil {
+			return err
+		}
+	}
+	r.height += step.Blocks
+	r.blocktime += step.Blocks
+	if step.Action != "" {
+		tx, err := r.createTx(step)
+		if err != nil {
+			return err
+		}
+		err = r.exec(tx)
+		if step.ExpectErr != "" {
+			if err == nil || err.Error() != step.ExpectErr {
+				return fmt.Errorf("expect error %s, got %v", step.ExpectErr, err)
+			}
+		} else if err != nil {
+			return err
+		}
+		if step.Save != "" {
+			r.lotteries[step.Save] = common.ToHex(tx.Hash())
+		}
+	}
+	if len(step.Expect) == 0 {
+		return nil
+	}
+	var expect Expect
+	if err := json.Unmarshal(r.replace(step.Expect), &expect); err != nil {
+		return err
+	}
+	return r.check(&expect)
+}
+
+//交易的nonce 使用区块高度, 同一个场景中交易hash 和彩票id 保持不变
+func (r *Runner) createTx(step *Step) (*types.Transaction, error) {
+	priv, ok := r.privs[step.Signer]
+	if !ok {
+		return nil, fmt.Errorf("unknown signer %s", step.Signer)
+	}
+	payload := []byte("{}")
+	if len(step.Payload) > 0 {
+		payload = r.replace(step.Payload)
+	}
+	tx, err := types.LoadExecutorType(pty.LotteryX).CreateTx(step.Action, payload)
+	if err != nil {
+		return nil, err
+	}
+	tx.Nonce = r.height + 1
+	tx.Sign(types.SECP256K1, priv)
+	return tx, nil
+}
+
+//每笔交易单独一个区块, 执行失败时区块中没有交易
+func (r *Runner) exec(tx *types.Transaction) error {
+	r.height++
+	r.blocktime++
+	b := &block{height: r.height, tx: tx, state: copyMap(r.state.data), local: copyMap(r.local)}
+	r.l.SetEnv(r.height, r.blocktime, 0)
+	receipt, err := r.l.Exec(tx, 0)
+	if err != nil {
+		return err
+	}
+	for _, kv := range receipt.KV {
+		r.state.Set(kv.Key, kv.Value)
+	}
+	b.receipt = &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+	set, err := r.l.ExecLocal(tx, b.receipt, 0)
+	if err != nil {
+		return err
+	}
+	if err := r.setLocal(set.KV); err != nil {
+		return err
+	}
+	r.blocks = append(r.blocks, b)
+	return nil
+}
+
+//从最新的区块开始调用ExecDelLocal, 状态数据恢复为执行之前的快照.
+//回滚之后localdb 中执行过程写入的每个key 都要和第一个回滚的区块执行之前相同
+func (r *Runner) rollback(n int) error {
+	if n > len(r.blocks) {
+		return fmt.Errorf("rollback %d blocks, only %d executed", n, len(r.blocks))
+	}
+	touched := copyMap(r.local)
+	var first *block
+	for i := 0; i < n; i++ {
+		first = r.blocks[len(r.blocks)-1]
+		r.l.SetEnv(first.height, r.blocktime, 0)
+		set, err := r.l.ExecDelLocal(first.tx, first.receipt, 0)
+		if err != nil {
+			return err
+		}
+		if err := r.setLocal(set.KV); err != nil {
+			return err
+		}
+		r.state.data = first.state
+		r.blocks = r.blocks[:len(r.blocks)-1]
+	}
+	r.height = first.height - 1
+	for key := range first.local {
+		touched[key] = nil
+	}
+	count := len(r.local)
+	for _, key := range sortedKeys(touched) {
+		value, err := r.localdb.Get([]byte(key))
+		if err != nil {
+			value = nil
+		}
+		if _, ok := first.local[key]; !ok && zeroCounter(key, value) {
+			//计数类的key 回滚时减回去, 不删除, 见executor/doc.go
+			if _, ok := r.local[key]; ok {
+				count--
+			}
+			continue
+		}
+		if !bytes.Equal(value, first.local[key]) {
+			return fmt.Errorf("rollback: localdb key %s not restored", key)
+		}
+	}
+	if count != len(first.local) {
+		return fmt.Errorf("rollback: localdb has %d keys, expect %d", count, len(first.local))
+	}
+	return nil
+}
+
+//zeroCounter 计数减回0 之后的值, int64 的0 编码为空, 统计信息只剩下lotteryId 和tokenSymbol
+func zeroCounter(key string, value []byte) bool {
+	if len(value) == 0 {
+		return true
+	}
+	if !strings.Contains(key, "-stats:") {
+		return false
+	}
+	var stats pty.LotteryStats
+	if err := types.Decode(value, &stats); err != nil {
+		return false
+	}
+	stats.LotteryId = ""
+	stats.TokenSymbol = ""
+	return types.Size(&stats) == 0
+}
+
+func (r *Runner) setLocal(kvs []*types.KeyValue) error {
+	for _, kv := range kvs {
+		if kv.Value == nil {
+			if err := r.localdb.(*dbm.KVDBList).Delete(kv.Key); err != nil {
+				return err
+			}
+			delete(r.local, string(kv.Key))
+			continue
+		}
+		if err := r.localdb.Set(kv.Key, kv.Value); err != nil {
+			return err
+		}
+		r.local[string(kv.Key)] = kv.Value
+	}
+	return nil
+}
+
+func (r *Runner) check(expect *Expect) error {
+	for _, name := range sortedKeys(expect.Lottery) {
+		id, ok := r.lotteries[name]
+		if !ok {
+			return fmt.Errorf("unknown lottery %s", name)
+		}
+		data, err := r.state.Get(executor.Key(id))
+		if err != nil {
+			return fmt.Errorf("lottery %s: %v", name, err)
+		}
+		var lottery pty.Lottery
+		if err := types.Decode(data, &lottery); err != nil {
+			return err
+		}
+		if err := matchMessage("lottery "+name, expect.Lottery[name], &lottery); err != nil {
+			return err
+		}
+	}
+	execaddr := address.ExecAddress(pty.LotteryX)
+	for _, name := range sortedKeys(expect.Balance) {
+		addr, ok := r.addrs[name]
+		if !ok {
+			return fmt.Errorf("unknown account %s", name)
+		}
+		acc := r.coins.LoadExecAccount(addr, execaddr)
+		if err := matchMessage("balance "+name, expect.Balance[name], acc); err != nil {
+			return err
+		}
+	}
+	for _, query := range expect.Query {
+		if err := r.checkQuery(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) checkQuery(query *QueryExpect) error {
+	param := json.RawMessage("{}")
+	if len(query.Param) > 0 {
+		param = query.Param
+	}
+	req, err := types.LoadExecutorType(pty.LotteryX).CreateQuery(query.FuncName, param)
+	if err != nil {
+		return fmt.Errorf("query %s: %v", query.FuncName, err)
+	}
+	reply, err := r.l.Query(query.FuncName, types.Encode(req))
+	if query.Err != "" {
+		if err == nil || err.Error() != query.Err {
+			return fmt.Errorf("query %s: expect error %s, got %v", query.FuncName, query.Err, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("query %s: %v", query.FuncName, err)
+	}
+	return matchMessage("query "+query.FuncName, query.Result, reply)
+}
+
+//把"$别名" 和"@别名" 替换为彩票id 和地址, 只替换完整的json 字符串
+func (r *Runner) replace(data []byte) []byte {
+	s := string(data)
+	for name, id := range r.lotteries {
+		s = strings.Replace(s, strconv.Quote("$"+name), strconv.Quote(id), -1)
+	}
+	for name, addr := range r.addrs {
+		s = strings.Replace(s, strconv.Quote("@"+name), strconv.Quote(addr), -1)
+	}
+	return []byte(s)
+}
+
+func matchMessage(path string, expect map[string]interface{}, msg types.Message) error {
+	data, err := types.PBToJson(msg)
+	if err != nil {
+		return err
+	}
+	var actual interface{}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		return err
+	}
+	return match(path, expect, actual)
+}
+
+//期望中的map 只检查列出的key, 数组的长度和每个元素都要相同
+func match(path string, expect, actual interface{}) error {
+	switch e := expect.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expect object, actual %v", path, actual)
+		}
+		for _, key := range sortedKeys(e) {
+			if err := match(path+"."+key, e[key], a[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		a, _ := actual.([]interface{})
+		if len(a) != len(e) {
+			return fmt.Errorf("%s: expect %d items, actual %v", path, len(e), actual)
+		}
+		for i := range e {
+			if err := match(fmt.Sprintf("%s[%d]", path, i), e[i], a[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if scalar(expect) != scalar(actual) {
+		return fmt.Errorf("%s: expect %v, actual %v", path, scalar(expect), scalar(actual))
+	}
+	return nil
+}
+
+func scalar(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]string:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string][]byte:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]interface{}:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]map[string]interface{}:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func copyMap(m map[string][]byte) map[string][]byte {
+	c := make(map[string][]byte, len(m))
+	for key, value := range m {
+		c[key] = value
+	}
+	return c
+}
+
+//状态数据, 和执行器中的StateDB 一样找不到时返回types.ErrNotFound
+type memKV struct {
+	data map[string][]byte
+}
+
+func (db *memKV) Get(key []byte) ([]byte, error) {
+	value, ok := db.data[string(key)]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	return value, nil
+}
+
+func (db *memKV) BatchGet(keys [][]byte) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = db.data[string(key)]
+	}
+	return values, nil
+}
+
+func (db *memKV) Set(key []byte, value []byte) error {
+	if value == nil {
+		delete(db.data, string(key))
+		return nil
+	}
+	db.data[string(key)] = value
+	return nil
+}
+
+func (db *memKV) Begin()    {}
+func (db *memKV) Rollback() {}
+func (db *memKV) Commit()   {}
+
+//开奖时读取的区块: 每个高度都返回时间为1, 带有同一笔挖矿交易的区块, 开奖号码只和取样的区块数有关
+type fakeAPI struct {
+	client.QueueProtocolAPI
+}
+
+func (api *fakeAPI) GetBlocks(req *types.ReqBlocks) (*types.BlockDetails, error) {
+	miner := &tickettypes.TicketAction{
+		Ty:    tickettypes.TicketActionMiner,
+		Value: &tickettypes.TicketAction_Miner{Miner: &tickettypes.TicketMiner{Bits: 1, TicketId: "ticket", Modify: []byte("modify")}},
+	}
+	block := &types.Block{Height: req.Start, BlockTime: 1, Txs: []*types.Transaction{{Execer: []byte("ticket"), Payload: types.Encode(miner)}}}
+	return &types.BlockDetails{Items: []*types.BlockDetail{{Block: block}}}, nil
+}
+
+func (api *fakeAPI) GetBlockHash(req *types.ReqInt) (*types.ReplyHash, error) {
+	return &types.ReplyHash{Hash: common.Sha256([]byte(strconv.FormatInt(req.Height, 10)))}, nil
+}
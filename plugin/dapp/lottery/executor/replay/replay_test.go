@@ -0,0 +1,55 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package replay
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+//local 的fork 高度都为0, 场景按升级之后的规则执行
+func init() {
+	types.Init("local", nil)
+}
+
+func TestScenarios(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.json")
+	assert.Nil(t, err)
+	assert.True(t, len(files) >= 3)
+	for _, file := range files {
+		s, err := LoadScenario(file)
+		assert.Nil(t, err)
+		t.Run(s.Name, func(t *testing.T) {
+			assert.Nil(t, Run(s))
+		})
+	}
+}
+
+//期望不符时返回错误, 不能因为检查没有执行而通过
+func TestWrongExpect(t *testing.T) {
+	s, err := LoadScenario("testdata/happy_path.json")
+	assert.Nil(t, err)
+	r, err := NewRunner(s)
+	assert.Nil(t, err)
+	assert.Nil(t, r.Step(s.Steps[0]))
+	assert.Nil(t, r.Step(s.Steps[1]))
+
+	step := &Step{
+		Name:    "wrong fund",
+		Signer:  "A",
+		Action:  "LotteryBuy",
+		Payload: json.RawMessage(`{"lotteryId": "$L", "amount": 1, "number": 1}`),
+		Expect:  json.RawMessage(`{"lottery": {"L": {"fund": 2}}}`),
+	}
+	assert.NotNil(t, r.Step(step))
+
+	//只执行了两个区块, 不能回滚3 个
+	step = &Step{Name: "rollback", Rollback: 3}
+	assert.NotNil(t, r.Step(step))
+}
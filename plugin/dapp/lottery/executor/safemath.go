@@ -0,0 +1,45 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"math"
+	"math/big"
+
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+)
+
+//购买和开奖的金额计算都使用下面的函数, 溢出时返回错误, 不能让金额回绕成负数或者很小的值
+
+func safeAdd(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, pty.ErrLotteryAmountOverflow
+	}
+	return a + b, nil
+}
+
+func safeMul(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, pty.ErrLotteryAmountOverflow
+	}
+	return c, nil
+}
+
+//safeMulDiv 计算a*b/c, 中间结果不会溢出, 只检查最后的结果
+func safeMulDiv(a, b, c int64) (int64, error) {
+	if c == 0 {
+		return 0, pty.ErrLotteryAmountOverflow
+	}
+	x := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	x.Quo(x, big.NewInt(c))
+	if !x.IsInt64() {
+		return 0, pty.ErrLotteryAmountOverflow
+	}
+	return x.Int64(), nil
+}
@@ -7,6 +7,7 @@ package lottery
 import (
 	"github.com/33cn/chain33/pluginmgr"
 	"github.com/33cn/plugin/plugin/dapp/lottery/executor"
+	"github.com/33cn/plugin/plugin/dapp/lottery/rpc"
 	"github.com/33cn/plugin/plugin/dapp/lottery/types"
 )
 
@@ -16,6 +17,6 @@ func init() {
 		ExecName: executor.GetName(),
 		Exec:     executor.Init,
 		Cmd:      nil,
-		RPC:      nil,
+		RPC:      rpc.Init,
 	})
 }
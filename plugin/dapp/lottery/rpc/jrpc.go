@@ -0,0 +1,106 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/hex"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+)
+
+func (c *Jrpc) CreateRawLotteryCreateTx(in *pty.LotteryCreateTx, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	param := &pty.LotteryCreate{
+		PurBlockNum:        in.PurBlockNum,
+		DrawBlockNum:       in.DrawBlockNum,
+		OpPurchaseLimit:    in.OpPurchaseLimit,
+		CreatorFeeRatio:    in.CreatorFeeRatio,
+		PrizeRatio:         in.PrizeRatio,
+		MaxRounds:          in.MaxRounds,
+		RevealBlockNum:     in.RevealBlockNum,
+		RevealTimeout:      in.RevealTimeout,
+		TimeoutRefund:      in.TimeoutRefund,
+		RolloverToBuyers:   in.RolloverToBuyers,
+		TokenSymbol:        in.TokenSymbol,
+		DrawDeadlineBlocks: in.DrawDeadlineBlocks,
+		DrawRewardRatio:    in.DrawRewardRatio,
+		MinPurchaseNum:     in.MinPurchaseNum,
+		MinSalesAmount:     in.MinSalesAmount,
+		MaxWaitBlocks:      in.MaxWaitBlocks,
+		RefundBelowMin:     in.RefundBelowMin,
+		Drawers:            in.Drawers,
+		ReclaimBlockNum:    in.ReclaimBlockNum,
+		Blacklist:          in.Blacklist,
+		MaxBuyPerTx:        in.MaxBuyPerTx,
+		CommissionRatio:    in.CommissionRatio,
+		ForbidSelfDealing:  in.ForbidSelfDealing,
+		AssetExec:          in.AssetExec,
+		Weighted:           in.Weighted,
+		PayoutMaturity:     in.PayoutMaturity,
+	}
+	reply, err := c.cli.createTx(param, in.Fee)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(reply.Data)
+	return nil
+}
+
+func (c *Jrpc) CreateRawLotteryBuyTx(in *pty.LotteryBuyTx, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	var commitHash []byte
+	if in.CommitHash != "" {
+		var err error
+		commitHash, err = common.FromHex(in.CommitHash)
+		if err != nil {
+			return types.ErrInvalidParam
+		}
+	}
+	param := &pty.LotteryBuy{
+		LotteryId:  in.LotteryId,
+		Amount:     in.Amount,
+		Number:     in.Number,
+		Way:        in.Way,
+		Items:      in.Items,
+		CommitHash: commitHash,
+		AgentAddr:  in.AgentAddr,
+	}
+	reply, err := c.cli.buyTx(param, in.Fee)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(reply.Data)
+	return nil
+}
+
+func (c *Jrpc) CreateRawLotteryDrawTx(in *pty.LotteryDrawTx, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	reply, err := c.cli.drawTx(&pty.LotteryDraw{LotteryId: in.LotteryId}, in.Fee)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(reply.Data)
+	return nil
+}
+
+func (c *Jrpc) CreateRawLotteryCloseTx(in *pty.LotteryCloseTx, result *interface{}) error {
+	if in == nil {
+		return types.ErrInvalidParam
+	}
+	reply, err := c.cli.closeTx(&pty.LotteryClose{LotteryId: in.LotteryId}, in.Fee)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(reply.Data)
+	return nil
+}
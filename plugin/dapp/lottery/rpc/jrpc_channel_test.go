@@ -0,0 +1,71 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc_test
+
+import (
+	"testing"
+
+	commonlog "github.com/33cn/chain33/common/log"
+	"github.com/33cn/chain33/rpc/jsonclient"
+	"github.com/33cn/chain33/util/testnode"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/33cn/chain33/system"
+	_ "github.com/33cn/plugin/plugin"
+)
+
+func init() {
+	commonlog.SetLogLevel("error")
+}
+
+func TestJRPCChannel(t *testing.T) {
+	// 启动RPCmocker
+	mocker := testnode.New("--notset--", nil)
+	defer func() {
+		mocker.Close()
+	}()
+	mocker.Listen()
+
+	jrpcClient := mocker.GetJsonC()
+	assert.NotNil(t, jrpcClient)
+
+	testCases := []struct {
+		fn func(*testing.T, *jsonclient.JSONClient) error
+	}{
+		{fn: testCreateRawLotteryCreateTx},
+		{fn: testCreateRawLotteryBuyTx},
+		{fn: testCreateRawLotteryDrawTx},
+		{fn: testCreateRawLotteryCloseTx},
+	}
+	for index, testCase := range testCases {
+		err := testCase.fn(t, jrpcClient)
+		assert.Nilf(t, err, "test index %d", index)
+	}
+}
+
+func testCreateRawLotteryCreateTx(t *testing.T, jrpc *jsonclient.JSONClient) error {
+	params := &pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40}
+	var res string
+	return jrpc.Call("lottery.CreateRawLotteryCreateTx", params, &res)
+}
+
+func testCreateRawLotteryBuyTx(t *testing.T, jrpc *jsonclient.JSONClient) error {
+	params := &pty.LotteryBuyTx{LotteryId: "0x1", Amount: 1, Number: 1, Way: 5}
+	var res string
+	return jrpc.Call("lottery.CreateRawLotteryBuyTx", params, &res)
+}
+
+func testCreateRawLotteryDrawTx(t *testing.T, jrpc *jsonclient.JSONClient) error {
+	params := &pty.LotteryDrawTx{LotteryId: "0x1"}
+	var res string
+	return jrpc.Call("lottery.CreateRawLotteryDrawTx", params, &res)
+}
+
+func testCreateRawLotteryCloseTx(t *testing.T, jrpc *jsonclient.JSONClient) error {
+	params := &pty.LotteryCloseTx{LotteryId: "0x1"}
+	var res string
+	return jrpc.Call("lottery.CreateRawLotteryCloseTx", params, &res)
+}
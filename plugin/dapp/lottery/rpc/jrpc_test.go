@@ -0,0 +1,126 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/address"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJrpc() *Jrpc {
+	cli := &channelClient{
+		ChannelClient: rpctypes.ChannelClient{
+			QueueProtocolAPI: &mocks.QueueProtocolAPI{},
+		},
+	}
+	return &Jrpc{cli: cli}
+}
+
+//解码返回的交易, 检查执行器, 合约地址和手续费
+func decodeTx(t *testing.T, result interface{}, fee int64) *pty.LotteryAction {
+	data, err := hex.DecodeString(result.(string))
+	assert.Nil(t, err)
+	var tx types.Transaction
+	assert.Nil(t, types.Decode(data, &tx))
+	assert.Equal(t, pty.LotteryX, string(tx.Execer))
+	assert.Equal(t, address.ExecAddress(pty.LotteryX), tx.To)
+	assert.Nil(t, tx.Signature)
+	if fee > 0 {
+		assert.Equal(t, fee, tx.Fee)
+	} else {
+		assert.True(t, tx.Fee > 0)
+	}
+	var action pty.LotteryAction
+	assert.Nil(t, types.Decode(tx.Payload, &action))
+	return &action
+}
+
+func TestJrpcCreateRawLotteryCreateTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryCreateTx(nil, &result))
+	assert.Equal(t, pty.ErrLotteryPurBlockLimit, client.CreateRawLotteryCreateTx(&pty.LotteryCreateTx{PurBlockNum: 1, DrawBlockNum: 40}, &result))
+	assert.Equal(t, pty.ErrLotteryCreatorFeeRatio, client.CreateRawLotteryCreateTx(&pty.LotteryCreateTx{PurBlockNum: 30, DrawBlockNum: 40, CreatorFeeRatio: 101}, &result))
+	assert.Nil(t, result)
+
+	param := &pty.LotteryCreateTx{
+		PurBlockNum:     30,
+		DrawBlockNum:    40,
+		OpPurchaseLimit: 10,
+		CreatorFeeRatio: 5,
+		PrizeRatio:      []int64{50, 20},
+		MaxRounds:       3,
+		TokenSymbol:     "TEST",
+		Fee:             2000000,
+	}
+	assert.Nil(t, client.CreateRawLotteryCreateTx(param, &result))
+	action := decodeTx(t, result, param.Fee)
+	assert.Equal(t, int32(pty.LotteryActionCreate), action.Ty)
+	create := action.GetCreate()
+	assert.Equal(t, int64(30), create.PurBlockNum)
+	assert.Equal(t, int64(40), create.DrawBlockNum)
+	assert.Equal(t, int64(10), create.OpPurchaseLimit)
+	assert.Equal(t, int64(5), create.CreatorFeeRatio)
+	assert.Equal(t, []int64{50, 20}, create.PrizeRatio)
+	assert.Equal(t, int64(3), create.MaxRounds)
+	assert.Equal(t, "TEST", create.TokenSymbol)
+}
+
+func TestJrpcCreateRawLotteryBuyTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{Amount: 1, Way: 5}, &result))
+	assert.Equal(t, pty.ErrLotteryBuyAmount, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: "0x1", Way: 5}, &result))
+	assert.Equal(t, pty.ErrLotteryBuyNumber, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: "0x1", Amount: 1, Number: 100000, Way: 5}, &result))
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: "0x1", Amount: 1, Way: 4}, &result))
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: "0x1", Amount: 1, Way: 5, Fee: -1}, &result))
+	assert.Nil(t, result)
+
+	assert.Nil(t, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: "0x1", Amount: 2, Number: 12345, Way: 3}, &result))
+	action := decodeTx(t, result, 0)
+	assert.Equal(t, int32(pty.LotteryActionBuy), action.Ty)
+	buy := action.GetBuy()
+	assert.Equal(t, "0x1", buy.LotteryId)
+	assert.Equal(t, int64(2), buy.Amount)
+	assert.Equal(t, int64(12345), buy.Number)
+	assert.Equal(t, int64(3), buy.Way)
+
+	items := []*pty.LotteryBuyItem{{Number: 1, Amount: 1, Way: 1}, {Number: 99999, Amount: 3, Way: 5}}
+	assert.Nil(t, client.CreateRawLotteryBuyTx(&pty.LotteryBuyTx{LotteryId: "0x1", Items: items}, &result))
+	action = decodeTx(t, result, 0)
+	assert.Equal(t, 2, len(action.GetBuy().Items))
+	assert.Equal(t, int64(99999), action.GetBuy().Items[1].Number)
+	assert.Equal(t, int64(3), action.GetBuy().Items[1].Amount)
+}
+
+func TestJrpcCreateRawLotteryDrawTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{}, &result))
+
+	assert.Nil(t, client.CreateRawLotteryDrawTx(&pty.LotteryDrawTx{LotteryId: "0x1", Fee: 1000000}, &result))
+	action := decodeTx(t, result, 1000000)
+	assert.Equal(t, int32(pty.LotteryActionDraw), action.Ty)
+	assert.Equal(t, "0x1", action.GetDraw().LotteryId)
+}
+
+func TestJrpcCreateRawLotteryCloseTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryCloseTx(nil, &result))
+	assert.Equal(t, types.ErrInvalidParam, client.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{}, &result))
+
+	assert.Nil(t, client.CreateRawLotteryCloseTx(&pty.LotteryCloseTx{LotteryId: "0x1"}, &result))
+	action := decodeTx(t, result, 0)
+	assert.Equal(t, int32(pty.LotteryActionClose), action.Ty)
+	assert.Equal(t, "0x1", action.GetClose().LotteryId)
+}
@@ -0,0 +1,137 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/lottery/executor"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+)
+
+func (c *channelClient) CreateRawLotteryCreateTx(ctx context.Context, in *pty.LotteryCreate) (*types.UnsignTx, error) {
+	return c.createTx(in, 0)
+}
+
+func (c *channelClient) CreateRawLotteryBuyTx(ctx context.Context, in *pty.LotteryBuy) (*types.UnsignTx, error) {
+	return c.buyTx(in, 0)
+}
+
+func (c *channelClient) CreateRawLotteryDrawTx(ctx context.Context, in *pty.LotteryDraw) (*types.UnsignTx, error) {
+	return c.drawTx(in, 0)
+}
+
+func (c *channelClient) CreateRawLotteryCloseTx(ctx context.Context, in *pty.LotteryClose) (*types.UnsignTx, error) {
+	return c.closeTx(in, 0)
+}
+
+func (c *channelClient) createTx(in *pty.LotteryCreate, fee int64) (*types.UnsignTx, error) {
+	if in == nil {
+		return nil, types.ErrInvalidParam
+	}
+	if err := executor.CheckLotteryCreate(in); err != nil {
+		return nil, err
+	}
+	create := &pty.LotteryAction{
+		Ty:    pty.LotteryActionCreate,
+		Value: &pty.LotteryAction_Create{Create: in},
+	}
+	return formatTx(create, fee)
+}
+
+func (c *channelClient) buyTx(in *pty.LotteryBuy, fee int64) (*types.UnsignTx, error) {
+	if in == nil {
+		return nil, types.ErrInvalidParam
+	}
+	if err := executor.CheckLotteryBuy(in); err != nil {
+		return nil, err
+	}
+	buy := &pty.LotteryAction{
+		Ty:    pty.LotteryActionBuy,
+		Value: &pty.LotteryAction_Buy{Buy: in},
+	}
+	return formatTx(buy, fee)
+}
+
+func (c *channelClient) drawTx(in *pty.LotteryDraw, fee int64) (*types.UnsignTx, error) {
+	if in == nil || in.LotteryId == "" {
+		return nil, types.ErrInvalidParam
+	}
+	draw := &pty.LotteryAction{
+		Ty:    pty.LotteryActionDraw,
+		Value: &pty.LotteryAction_Draw{Draw: in},
+	}
+	return formatTx(draw, fee)
+}
+
+func (c *channelClient) closeTx(in *pty.LotteryClose, fee int64) (*types.UnsignTx, error) {
+	if in == nil || in.LotteryId == "" {
+		return nil, types.ErrInvalidParam
+	}
+	close := &pty.LotteryAction{
+		Ty:    pty.LotteryActionClose,
+		Value: &pty.LotteryAction_Close{Close: in},
+	}
+	return formatTx(close, fee)
+}
+
+//查询转发到执行器的Query_ 函数, 平行链上使用平行链的执行器名字
+func (c *channelClient) GetLotteryInfo(ctx context.Context, in *pty.ReqLotteryInfo) (*pty.ReplyLotteryNormalInfo, error) {
+	data, err := c.Query(types.ExecName(pty.LotteryX), "GetLotteryNormalInfo", in)
+	if err != nil {
+		return nil, err
+	}
+	if resp, ok := data.(*pty.ReplyLotteryNormalInfo); ok {
+		return resp, nil
+	}
+	return nil, types.ErrDecode
+}
+
+func (c *channelClient) GetBuyRecords(ctx context.Context, in *pty.ReqLotteryBuyHistory) (*pty.LotteryBuyRecords, error) {
+	data, err := c.Query(types.ExecName(pty.LotteryX), "GetLotteryHistoryBuyInfo", in)
+	if err != nil {
+		return nil, err
+	}
+	if resp, ok := data.(*pty.LotteryBuyRecords); ok {
+		return resp, nil
+	}
+	return nil, types.ErrDecode
+}
+
+func (c *channelClient) GetDrawResults(ctx context.Context, in *pty.ReqLotteryLuckyHistory) (*pty.LotteryDrawRecords, error) {
+	data, err := c.Query(types.ExecName(pty.LotteryX), "GetLotteryHistoryLuckyNumber", in)
+	if err != nil {
+		return nil, err
+	}
+	if resp, ok := data.(*pty.LotteryDrawRecords); ok {
+		return resp, nil
+	}
+	return nil, types.ErrDecode
+}
+
+func (c *channelClient) ListLotteries(ctx context.Context, in *pty.ReqLotteryList) (*pty.ReplyLotteryList, error) {
+	data, err := c.Query(types.ExecName(pty.LotteryX), "ListLotteries", in)
+	if err != nil {
+		return nil, err
+	}
+	if resp, ok := data.(*pty.ReplyLotteryList); ok {
+		return resp, nil
+	}
+	return nil, types.ErrDecode
+}
+
+//fee 为0时按交易大小计算最低手续费
+func formatTx(action *pty.LotteryAction, fee int64) (*types.UnsignTx, error) {
+	if fee < 0 {
+		return nil, types.ErrInvalidParam
+	}
+	tx := &types.Transaction{Payload: types.Encode(action), Fee: fee}
+	tx, err := types.FormatTx(types.ExecName(pty.LotteryX), tx)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UnsignTx{Data: types.Encode(tx)}, nil
+}
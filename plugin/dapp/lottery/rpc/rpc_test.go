@@ -0,0 +1,107 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/client/mocks"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestGrpc() *Grpc {
+	cli := &channelClient{
+		ChannelClient: rpctypes.ChannelClient{
+			QueueProtocolAPI: &mocks.QueueProtocolAPI{},
+		},
+	}
+	return &Grpc{channelClient: cli}
+}
+
+func decodeUnsignTx(t *testing.T, unsign *types.UnsignTx) *pty.LotteryAction {
+	var tx types.Transaction
+	assert.Nil(t, types.Decode(unsign.Data, &tx))
+	assert.Equal(t, pty.LotteryX, string(tx.Execer))
+	var action pty.LotteryAction
+	assert.Nil(t, types.Decode(tx.Payload, &action))
+	return &action
+}
+
+func TestGrpcCreateRawLotteryTx(t *testing.T) {
+	client := newTestGrpc()
+	ctx := context.Background()
+
+	_, err := client.CreateRawLotteryCreateTx(ctx, nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = client.CreateRawLotteryCreateTx(ctx, &pty.LotteryCreate{PurBlockNum: 50, DrawBlockNum: 40})
+	assert.Equal(t, pty.ErrLotteryDrawBlockLimit, err)
+	unsign, err := client.CreateRawLotteryCreateTx(ctx, &pty.LotteryCreate{PurBlockNum: 30, DrawBlockNum: 40})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(40), decodeUnsignTx(t, unsign).GetCreate().DrawBlockNum)
+
+	_, err = client.CreateRawLotteryBuyTx(ctx, &pty.LotteryBuy{LotteryId: "0x1", Amount: -1, Way: 5})
+	assert.Equal(t, pty.ErrLotteryBuyAmount, err)
+	unsign, err = client.CreateRawLotteryBuyTx(ctx, &pty.LotteryBuy{LotteryId: "0x1", Amount: 1, Number: 7, Way: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), decodeUnsignTx(t, unsign).GetBuy().Number)
+
+	_, err = client.CreateRawLotteryDrawTx(ctx, nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	unsign, err = client.CreateRawLotteryDrawTx(ctx, &pty.LotteryDraw{LotteryId: "0x1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0x1", decodeUnsignTx(t, unsign).GetDraw().LotteryId)
+
+	_, err = client.CreateRawLotteryCloseTx(ctx, &pty.LotteryClose{})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	unsign, err = client.CreateRawLotteryCloseTx(ctx, &pty.LotteryClose{LotteryId: "0x1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "0x1", decodeUnsignTx(t, unsign).GetClose().LotteryId)
+}
+
+//通过内存连接调用grpc 服务, 查询转发到mock 的执行器查询
+func TestGrpcQueryBufconn(t *testing.T) {
+	api := &mocks.QueueProtocolAPI{}
+	info := &pty.ReplyLotteryNormalInfo{CreateHeight: 10, PurBlockNum: 30, DrawBlockNum: 40, CreateAddr: "creator"}
+	api.On("Query", pty.LotteryX, "GetLotteryNormalInfo", mock.MatchedBy(func(req *pty.ReqLotteryInfo) bool {
+		return req.LotteryId == "0x1"
+	})).Return(info, nil)
+	api.On("Query", pty.LotteryX, "GetLotteryNormalInfo", mock.Anything).Return(nil, types.ErrNotFound)
+	api.On("Query", pty.LotteryX, "ListLotteries", mock.Anything).Return(&types.ReplyString{}, nil)
+	cli := &channelClient{ChannelClient: rpctypes.ChannelClient{QueueProtocolAPI: api}}
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pty.RegisterLotteryServer(server, &Grpc{channelClient: cli})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	assert.Nil(t, err)
+	defer conn.Close()
+	client := pty.NewLotteryClient(conn)
+
+	reply, err := client.GetLotteryInfo(context.Background(), &pty.ReqLotteryInfo{LotteryId: "0x1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(40), reply.DrawBlockNum)
+	assert.Equal(t, "creator", reply.CreateAddr)
+	_, err = client.GetLotteryInfo(context.Background(), &pty.ReqLotteryInfo{LotteryId: "0x2"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), types.ErrNotFound.Error())
+
+	//执行器返回的类型不对
+	_, err = cli.ListLotteries(context.Background(), &pty.ReqLotteryList{})
+	assert.Equal(t, types.ErrDecode, err)
+}
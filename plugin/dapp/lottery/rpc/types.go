@@ -0,0 +1,30 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	pty "github.com/33cn/plugin/plugin/dapp/lottery/types"
+)
+
+type channelClient struct {
+	rpctypes.ChannelClient
+}
+
+type Jrpc struct {
+	cli *channelClient
+}
+
+type Grpc struct {
+	*channelClient
+}
+
+//插件注册之后才会调用, 服务注册在chain33 的grpc 服务上, 由它的拦截器检查grpcFuncWhitelist 和grpcFuncBlacklist
+func Init(name string, s rpctypes.RPCServer) {
+	cli := &channelClient{}
+	grpc := &Grpc{channelClient: cli}
+	cli.Init(name, s, &Jrpc{cli: cli}, grpc)
+	pty.RegisterLotteryServer(s.GRPC(), grpc)
+}
@@ -7,21 +7,60 @@ package types
 import "errors"
 
 var (
-	ErrNoPrivilege              = errors.New("ErrNoPrivilege")
-	ErrLotteryStatus            = errors.New("ErrLotteryStatus")
-	ErrLotteryDrawActionInvalid = errors.New("ErrLotteryDrawActionInvalid")
-	ErrLotteryFundNotEnough     = errors.New("ErrLotteryFundNotEnough")
-	ErrLotteryCreatorBuy        = errors.New("ErrLotteryCreatorBuy")
-	ErrLotteryBuyAmount         = errors.New("ErrLotteryBuyAmount")
-	ErrLotteryRepeatHash        = errors.New("ErrLotteryRepeatHash")
-	ErrLotteryPurBlockLimit     = errors.New("ErrLotteryPurBlockLimit")
-	ErrLotteryDrawBlockLimit    = errors.New("ErrLotteryDrawBlockLimit")
-	ErrLotteryBuyNumber         = errors.New("ErrLotteryBuyNumber")
-	ErrLotteryShowRepeated      = errors.New("ErrLotteryShowRepeated")
-	ErrLotteryShowError         = errors.New("ErrLotteryShowError")
-	ErrLotteryErrLuckyNum       = errors.New("ErrLotteryErrLuckyNum")
-	ErrLotteryErrCloser         = errors.New("ErrLotteryErrCloser")
-	ErrLotteryErrUnableClose    = errors.New("ErrLotteryErrUnableClose")
-	ErrNodeNotExist             = errors.New("ErrNodeNotExist")
-	ErrEmptyMinerTx             = errors.New("ErrEmptyMinerTx")
+	ErrNoPrivilege                  = errors.New("ErrNoPrivilege")
+	ErrLotteryStatus                = errors.New("ErrLotteryStatus")
+	ErrLotteryDrawActionInvalid     = errors.New("ErrLotteryDrawActionInvalid")
+	ErrLotteryFundNotEnough         = errors.New("ErrLotteryFundNotEnough")
+	ErrLotteryCreatorBuy            = errors.New("ErrLotteryCreatorBuy")
+	ErrLotteryBuyAmount             = errors.New("ErrLotteryBuyAmount")
+	ErrLotteryRepeatHash            = errors.New("ErrLotteryRepeatHash")
+	ErrLotteryPurBlockLimit         = errors.New("ErrLotteryPurBlockLimit")
+	ErrLotteryDrawBlockLimit        = errors.New("ErrLotteryDrawBlockLimit")
+	ErrLotteryBuyNumber             = errors.New("ErrLotteryBuyNumber")
+	ErrLotteryShowRepeated          = errors.New("ErrLotteryShowRepeated")
+	ErrLotteryShowError             = errors.New("ErrLotteryShowError")
+	ErrLotteryErrLuckyNum           = errors.New("ErrLotteryErrLuckyNum")
+	ErrLotteryErrCloser             = errors.New("ErrLotteryErrCloser")
+	ErrLotteryErrUnableClose        = errors.New("ErrLotteryErrUnableClose")
+	ErrNodeNotExist                 = errors.New("ErrNodeNotExist")
+	ErrEmptyMinerTx                 = errors.New("ErrEmptyMinerTx")
+	ErrLotteryPurchaseLimit         = errors.New("ErrLotteryPurchaseLimit")
+	ErrLotteryCreatorFeeRatio       = errors.New("ErrLotteryCreatorFeeRatio")
+	ErrLotteryPrizeRatio            = errors.New("ErrLotteryPrizeRatio")
+	ErrLotteryInvalidState          = errors.New("ErrLotteryInvalidState")
+	ErrLotteryBuyItems              = errors.New("ErrLotteryBuyItems")
+	ErrLotteryMaxRounds             = errors.New("ErrLotteryMaxRounds")
+	ErrLotteryRevealParam           = errors.New("ErrLotteryRevealParam")
+	ErrLotteryCommitRequired        = errors.New("ErrLotteryCommitRequired")
+	ErrLotteryCommitDisabled        = errors.New("ErrLotteryCommitDisabled")
+	ErrLotteryRevealHash            = errors.New("ErrLotteryRevealHash")
+	ErrLotteryRevealTimeout         = errors.New("ErrLotteryRevealTimeout")
+	ErrLotteryTokenNotExist         = errors.New("ErrLotteryTokenNotExist")
+	ErrLotteryBuyCommit             = errors.New("ErrLotteryBuyCommit")
+	ErrLotteryRevealNumber          = errors.New("ErrLotteryRevealNumber")
+	ErrLotteryDrawDeadline          = errors.New("ErrLotteryDrawDeadline")
+	ErrLotteryDrawRewardRatio       = errors.New("ErrLotteryDrawRewardRatio")
+	ErrLotteryMinimumParam          = errors.New("ErrLotteryMinimumParam")
+	ErrLotteryBelowMinimum          = errors.New("ErrLotteryBelowMinimum")
+	ErrLotteryDrawerAddr            = errors.New("ErrLotteryDrawerAddr")
+	ErrLotteryPurchasePeriodExpired = errors.New("ErrLotteryPurchasePeriodExpired")
+	ErrLotteryAdminAddr             = errors.New("ErrLotteryAdminAddr")
+	ErrLotteryReclaimBlockNum       = errors.New("ErrLotteryReclaimBlockNum")
+	ErrLotteryReclaimed             = errors.New("ErrLotteryReclaimed")
+	ErrLotteryNoEscrow              = errors.New("ErrLotteryNoEscrow")
+	ErrLotteryAddrBlacklisted       = errors.New("ErrLotteryAddrBlacklisted")
+	ErrLotteryBlacklistAddr         = errors.New("ErrLotteryBlacklistAddr")
+	ErrLotteryAmountOverflow        = errors.New("ErrLotteryAmountOverflow")
+	ErrLotteryCommissionRatio       = errors.New("ErrLotteryCommissionRatio")
+	ErrLotteryAgentAddr             = errors.New("ErrLotteryAgentAddr")
+	ErrLotteryAssetExec             = errors.New("ErrLotteryAssetExec")
+	ErrLotteryDrawSeed              = errors.New("ErrLotteryDrawSeed")
+	ErrLotteryAlgoVersion           = errors.New("ErrLotteryAlgoVersion")
+	ErrLotteryPayoutMaturity        = errors.New("ErrLotteryPayoutMaturity")
+	ErrLotteryNoPayout              = errors.New("ErrLotteryNoPayout")
+	ErrLotteryPayoutClaimed         = errors.New("ErrLotteryPayoutClaimed")
+	ErrLotteryPayoutImmature        = errors.New("ErrLotteryPayoutImmature")
+	ErrLotteryTicketNotFound        = errors.New("ErrLotteryTicketNotFound")
+	ErrLotteryTicketResolved        = errors.New("ErrLotteryTicketResolved")
+	ErrLotteryTransferAddr          = errors.New("ErrLotteryTransferAddr")
 )
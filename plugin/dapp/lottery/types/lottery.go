@@ -5,9 +5,13 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strconv"
 
+	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/address"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/types"
@@ -21,6 +25,10 @@ func init() {
 	types.AllowUserExec = append(types.AllowUserExec, []byte(LotteryX))
 	types.RegistorExecutor(LotteryX, NewType())
 	types.RegisterDappFork(LotteryX, "Enable", 0)
+	//状态机检查, 之前的区块按原来的规则执行. 主链的升级高度确定之前为MaxHeight, 本地链从0 开始
+	types.RegisterDappFork(LotteryX, "ForkLotteryState", types.MaxHeight)
+	//购买期结束之后的购买返回ErrLotteryPurchasePeriodExpired, 之前为ErrLotteryStatus
+	types.RegisterDappFork(LotteryX, "ForkLotteryBuyWindow", types.MaxHeight)
 }
 
 type LotteryType struct {
@@ -35,10 +43,23 @@ func NewType() *LotteryType {
 
 func (at *LotteryType) GetLogMap() map[int64]*types.LogInfo {
 	return map[int64]*types.LogInfo{
-		TyLogLotteryCreate: {reflect.TypeOf(ReceiptLottery{}), "LogLotteryCreate"},
-		TyLogLotteryBuy:    {reflect.TypeOf(ReceiptLottery{}), "LogLotteryBuy"},
-		TyLogLotteryDraw:   {reflect.TypeOf(ReceiptLottery{}), "LogLotteryDraw"},
-		TyLogLotteryClose:  {reflect.TypeOf(ReceiptLottery{}), "LogLotteryClose"},
+		TyLogLotteryCreate:         {reflect.TypeOf(ReceiptLottery{}), "LogLotteryCreate"},
+		TyLogLotteryBuy:            {reflect.TypeOf(ReceiptLottery{}), "LogLotteryBuy"},
+		TyLogLotteryDraw:           {reflect.TypeOf(ReceiptLottery{}), "LogLotteryDraw"},
+		TyLogLotteryClose:          {reflect.TypeOf(ReceiptLottery{}), "LogLotteryClose"},
+		TyLogLotteryFee:            {reflect.TypeOf(ReceiptLotteryCreatorFee{}), "LogLotteryFee"},
+		TyLogLotteryRefund:         {reflect.TypeOf(ReceiptLotteryRefund{}), "LogLotteryRefund"},
+		TyLogLotteryCommit:         {reflect.TypeOf(ReceiptLottery{}), "LogLotteryCommit"},
+		TyLogLotteryRevealNumber:   {reflect.TypeOf(ReceiptLottery{}), "LogLotteryRevealNumber"},
+		TyLogLotteryDrawReward:     {reflect.TypeOf(ReceiptLotteryDrawReward{}), "LogLotteryDrawReward"},
+		TyLogLotteryModify:         {reflect.TypeOf(ReceiptLotteryModify{}), "LogLotteryModify"},
+		TyLogLotteryTransfer:       {reflect.TypeOf(ReceiptLotteryTransfer{}), "LogLotteryTransfer"},
+		TyLogLotteryReclaim:        {reflect.TypeOf(ReceiptLotteryReclaim{}), "LogLotteryReclaim"},
+		TyLogLotteryBlacklist:      {reflect.TypeOf(ReceiptLotteryBlacklist{}), "LogLotteryBlacklist"},
+		TyLogLotteryDrawEmpty:      {reflect.TypeOf(ReceiptLottery{}), "LogLotteryDrawEmpty"},
+		TyLogLotteryPayoutLock:     {reflect.TypeOf(ReceiptLotteryPayout{}), "LogLotteryPayoutLock"},
+		TyLogLotteryClaim:          {reflect.TypeOf(ReceiptLotteryPayout{}), "LogLotteryClaim"},
+		TyLogLotteryTicketTransfer: {reflect.TypeOf(ReceiptLotteryTicketTransfer{}), "LogLotteryTicketTransfer"},
 	}
 }
 
@@ -81,6 +102,78 @@ func (lottery LotteryType) CreateTx(action string, message json.RawMessage) (*ty
 			return nil, types.ErrInvalidParam
 		}
 		return CreateRawLotteryCloseTx(&param)
+	} else if action == "LotteryCommit" {
+		var param LotteryCommitTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryCommitTx(&param)
+	} else if action == "LotteryReveal" {
+		var param LotteryRevealTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryRevealTx(&param)
+	} else if action == "LotteryRevealNumber" {
+		var param LotteryRevealNumberTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryRevealNumberTx(&param)
+	} else if action == "LotteryModify" {
+		var param LotteryModifyTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryModifyTx(&param)
+	} else if action == "LotteryTransfer" {
+		var param LotteryTransferTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryTransferTx(&param)
+	} else if action == "LotteryReclaim" {
+		var param LotteryReclaimTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryReclaimTx(&param)
+	} else if action == "LotteryBlacklist" {
+		var param LotteryBlacklistTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryBlacklistTx(&param)
+	} else if action == "LotteryClaim" {
+		var param LotteryClaimTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryClaimTx(&param)
+	} else if action == "LotteryTransferTicket" {
+		var param LotteryTransferTicketTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			llog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateRawLotteryTransferTicketTx(&param)
 	} else {
 		return nil, types.ErrNotSupport
 	}
@@ -90,10 +183,19 @@ func (lottery LotteryType) CreateTx(action string, message json.RawMessage) (*ty
 
 func (lott LotteryType) GetTypeMap() map[string]int32 {
 	return map[string]int32{
-		"Create": LotteryActionCreate,
-		"Buy":    LotteryActionBuy,
-		"Draw":   LotteryActionDraw,
-		"Close":  LotteryActionClose,
+		"Create":         LotteryActionCreate,
+		"Buy":            LotteryActionBuy,
+		"Draw":           LotteryActionDraw,
+		"Close":          LotteryActionClose,
+		"Commit":         LotteryActionCommit,
+		"Reveal":         LotteryActionReveal,
+		"RevealNumber":   LotteryActionRevealNumber,
+		"Modify":         LotteryActionModify,
+		"Transfer":       LotteryActionTransfer,
+		"Reclaim":        LotteryActionReclaim,
+		"Blacklist":      LotteryActionBlacklist,
+		"Claim":          LotteryActionClaim,
+		"TransferTicket": LotteryActionTransferTicket,
 	}
 }
 
@@ -104,8 +206,32 @@ func CreateRawLotteryCreateTx(parm *LotteryCreateTx) (*types.Transaction, error)
 	}
 
 	v := &LotteryCreate{
-		PurBlockNum:  parm.PurBlockNum,
-		DrawBlockNum: parm.DrawBlockNum,
+		PurBlockNum:        parm.PurBlockNum,
+		DrawBlockNum:       parm.DrawBlockNum,
+		OpPurchaseLimit:    parm.OpPurchaseLimit,
+		CreatorFeeRatio:    parm.CreatorFeeRatio,
+		PrizeRatio:         parm.PrizeRatio,
+		MaxRounds:          parm.MaxRounds,
+		RevealBlockNum:     parm.RevealBlockNum,
+		RevealTimeout:      parm.RevealTimeout,
+		TimeoutRefund:      parm.TimeoutRefund,
+		RolloverToBuyers:   parm.RolloverToBuyers,
+		TokenSymbol:        parm.TokenSymbol,
+		DrawDeadlineBlocks: parm.DrawDeadlineBlocks,
+		DrawRewardRatio:    parm.DrawRewardRatio,
+		MinPurchaseNum:     parm.MinPurchaseNum,
+		MinSalesAmount:     parm.MinSalesAmount,
+		MaxWaitBlocks:      parm.MaxWaitBlocks,
+		RefundBelowMin:     parm.RefundBelowMin,
+		Drawers:            parm.Drawers,
+		ReclaimBlockNum:    parm.ReclaimBlockNum,
+		Blacklist:          parm.Blacklist,
+		MaxBuyPerTx:        parm.MaxBuyPerTx,
+		CommissionRatio:    parm.CommissionRatio,
+		ForbidSelfDealing:  parm.ForbidSelfDealing,
+		AssetExec:          parm.AssetExec,
+		Weighted:           parm.Weighted,
+		PayoutMaturity:     parm.PayoutMaturity,
 	}
 	create := &LotteryAction{
 		Ty:    LotteryActionCreate,
@@ -125,17 +251,30 @@ func CreateRawLotteryCreateTx(parm *LotteryCreateTx) (*types.Transaction, error)
 	return tx, nil
 }
 
+//commitHash 为CalcBuyCommitHash 结果的16进制编码, 为空时不是盲选购买
 func CreateRawLotteryBuyTx(parm *LotteryBuyTx) (*types.Transaction, error) {
 	if parm == nil {
 		llog.Error("CreateRawLotteryBuyTx", "parm", parm)
 		return nil, types.ErrInvalidParam
 	}
+	var commitHash []byte
+	if parm.CommitHash != "" {
+		var err error
+		commitHash, err = common.FromHex(parm.CommitHash)
+		if err != nil {
+			llog.Error("CreateRawLotteryBuyTx", "commitHash", parm.CommitHash)
+			return nil, types.ErrInvalidParam
+		}
+	}
 
 	v := &LotteryBuy{
-		LotteryId: parm.LotteryId,
-		Amount:    parm.Amount,
-		Number:    parm.Number,
-		Way:       parm.Way,
+		LotteryId:  parm.LotteryId,
+		Amount:     parm.Amount,
+		Number:     parm.Number,
+		Way:        parm.Way,
+		Items:      parm.Items,
+		CommitHash: commitHash,
+		AgentAddr:  parm.AgentAddr,
 	}
 	buy := &LotteryAction{
 		Ty:    LotteryActionBuy,
@@ -209,3 +348,341 @@ func CreateRawLotteryCloseTx(parm *LotteryCloseTx) (*types.Transaction, error) {
 	}
 	return tx, nil
 }
+
+//hash 为sha256(secret) 的16进制编码
+func CreateRawLotteryCommitTx(parm *LotteryCommitTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryCommitTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+	hash, err := common.FromHex(parm.Hash)
+	if err != nil {
+		llog.Error("CreateRawLotteryCommitTx", "hash", parm.Hash)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryCommit{
+		LotteryId: parm.LotteryId,
+		Hash:      hash,
+	}
+	commit := &LotteryAction{
+		Ty:    LotteryActionCommit,
+		Value: &LotteryAction_Commit{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(commit),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err = types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+//secret 为16进制编码
+func CreateRawLotteryRevealTx(parm *LotteryRevealTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryRevealTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+	secret, err := common.FromHex(parm.Secret)
+	if err != nil {
+		llog.Error("CreateRawLotteryRevealTx", "secret", parm.Secret)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryReveal{
+		LotteryId: parm.LotteryId,
+		Secret:    secret,
+	}
+	reveal := &LotteryAction{
+		Ty:    LotteryActionReveal,
+		Value: &LotteryAction_Reveal{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(reveal),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err = types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+//nonce 为16进制编码
+func CreateRawLotteryRevealNumberTx(parm *LotteryRevealNumberTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryRevealNumberTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+	nonce, err := common.FromHex(parm.Nonce)
+	if err != nil {
+		llog.Error("CreateRawLotteryRevealNumberTx", "nonce", parm.Nonce)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryRevealNumber{
+		LotteryId: parm.LotteryId,
+		Number:    parm.Number,
+		Nonce:     nonce,
+	}
+	reveal := &LotteryAction{
+		Ty:    LotteryActionRevealNumber,
+		Value: &LotteryAction_RevealNumber{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(reveal),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err = types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateRawLotteryModifyTx(parm *LotteryModifyTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryModifyTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryModify{
+		LotteryId:     parm.LotteryId,
+		AddDrawers:    parm.AddDrawers,
+		RemoveDrawers: parm.RemoveDrawers,
+	}
+	modify := &LotteryAction{
+		Ty:    LotteryActionModify,
+		Value: &LotteryAction_Modify{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(modify),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err := types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateRawLotteryTransferTx(parm *LotteryTransferTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryTransferTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryTransfer{
+		LotteryId: parm.LotteryId,
+		To:        parm.To,
+	}
+	transfer := &LotteryAction{
+		Ty:    LotteryActionTransfer,
+		Value: &LotteryAction_Transfer{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(transfer),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err := types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateRawLotteryReclaimTx(parm *LotteryReclaimTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryReclaimTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryReclaim{
+		LotteryId: parm.LotteryId,
+	}
+	reclaim := &LotteryAction{
+		Ty:    LotteryActionReclaim,
+		Value: &LotteryAction_Reclaim{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(reclaim),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err := types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateRawLotteryBlacklistTx(parm *LotteryBlacklistTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryBlacklistTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryBlacklist{
+		LotteryId: parm.LotteryId,
+		Add:       parm.Add,
+		Remove:    parm.Remove,
+	}
+	blacklist := &LotteryAction{
+		Ty:    LotteryActionBlacklist,
+		Value: &LotteryAction_Blacklist{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(blacklist),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err := types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateRawLotteryClaimTx(parm *LotteryClaimTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryClaimTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryClaim{
+		LotteryId: parm.LotteryId,
+		Round:     parm.Round,
+	}
+	claim := &LotteryAction{
+		Ty:    LotteryActionClaim,
+		Value: &LotteryAction_Claim{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(claim),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err := types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func CreateRawLotteryTransferTicketTx(parm *LotteryTransferTicketTx) (*types.Transaction, error) {
+	if parm == nil {
+		llog.Error("CreateRawLotteryTransferTicketTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+
+	v := &LotteryTransferTicket{
+		LotteryId: parm.LotteryId,
+		Index:     parm.Index,
+		To:        parm.To,
+	}
+	transfer := &LotteryAction{
+		Ty:    LotteryActionTransferTicket,
+		Value: &LotteryAction_TransferTicket{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(types.ExecName(LotteryX)),
+		Payload: types.Encode(transfer),
+		Fee:     parm.Fee,
+		To:      address.ExecAddress(types.ExecName(LotteryX)),
+	}
+
+	name := types.ExecName(LotteryX)
+	tx, err := types.FormatTx(name, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+//CalcBuyCommitHash 盲选购买的号码承诺, sha256(8字节大端号码 || nonce)
+func CalcBuyCommitHash(number int64, nonce []byte) []byte {
+	buf := make([]byte, 8, 8+len(nonce))
+	binary.BigEndian.PutUint64(buf, uint64(number))
+	return common.Sha256(append(buf, nonce...))
+}
+
+//LotteryDrawStep 区块数据开奖时取样区块时间的步长, 取值3~5
+func LotteryDrawStep(purchasedTxNum, drawHeight, beginHeight int64) int64 {
+	return (purchasedTxNum+drawHeight-beginHeight)%3 + 2
+}
+
+//LotteryDrawTimeHeights 区块数据开奖时在 [begin, end) 中按step 取样的区块高度
+func LotteryDrawTimeHeights(begin, end, step int64) []int64 {
+	var heights []int64
+	for i := begin; i < end; i += step {
+		heights = append(heights, i)
+	}
+	return heights
+}
+
+//LotteryDrawModify 区块数据开奖的seed, 由挖矿交易的modify, ticketId, bits 和取样区块时间之和计算
+func LotteryDrawModify(modifies []byte, ticketIds string, timeSum int64, bits uint32) []byte {
+	return common.Sha256([]byte(fmt.Sprintf("%s:%s:%d:%d", string(modifies), ticketIds, timeSum, bits)))
+}
+
+//LotteryRevealSeed commit/reveal 开奖的seed, sha256(secret || blockHash)
+func LotteryRevealSeed(secret []byte, blockHash []byte) []byte {
+	data := append(append([]byte{}, secret...), blockHash...)
+	return common.Sha256(data)
+}
+
+//LotteryLuckyNum 取seed 的前4个字节计算开奖号码, seed 不足4个字节时返回-1
+func LotteryLuckyNum(seed []byte) int64 {
+	if len(seed) < 4 {
+		return -1
+	}
+	baseNum, err := strconv.ParseUint(common.ToHex(seed[0:4]), 0, 64)
+	if err != nil {
+		llog.Error("LotteryLuckyNum", "err", err)
+		return -1
+	}
+	return int64(baseNum) % LotteryLuckyNumMol
+}
+
+//LotteryDrawProofNumber 由开奖证明中的输入重新计算开奖号码, 不依赖证明中记录的seed 和号码
+func LotteryDrawProofNumber(proof *LotteryDrawProof) int64 {
+	switch proof.GetMethod() {
+	case LotteryDrawByBlock:
+		return LotteryLuckyNum(LotteryDrawModify(proof.Modifies, proof.TicketIds, proof.TimeSum, proof.Bits))
+	case LotteryDrawByReveal:
+		return LotteryLuckyNum(LotteryRevealSeed(proof.Secret, proof.BlockHash))
+	}
+	return -1
+}
@@ -19,9 +19,36 @@ It has these top-level messages:
 	LotteryAction
 	LotteryCreate
 	LotteryBuy
+	LotteryBuyItem
 	LotteryDraw
 	LotteryClose
+	LotteryCommit
+	LotteryReveal
+	LotteryRevealNumber
+	LotteryModify
+	LotteryTransfer
+	LotteryReclaim
+	LotteryClaim
+	LotteryTransferTicket
+	LotteryBlacklist
 	ReceiptLottery
+	ReceiptLotteryCreatorFee
+	ReceiptLotteryDrawReward
+	ReceiptLotteryModify
+	ReplyLotteryModifyRecords
+	ReceiptLotteryTransfer
+	ReceiptLotteryTicketTransfer
+	ReceiptLotteryReclaim
+	ReceiptLotteryPayout
+	LotteryPayout
+	ReqLotteryPayouts
+	ReplyLotteryPayouts
+	ReceiptLotteryBlacklist
+	ReplyLotteryTransferRecords
+	LotteryConfigField
+	LotteryConfigChange
+	ReplyLotteryConfigHistory
+	ReceiptLotteryRefund
 	ReqLotteryInfo
 	ReqLotteryBuyInfo
 	ReqLotteryBuyHistory
@@ -40,12 +67,55 @@ It has these top-level messages:
 	LotteryUpdateRecs
 	LotteryUpdateBuyInfo
 	ReplyLotteryPurchaseAddr
+	ReqLotteryBuyRecordsByAddr
+	LotteryBuyEntry
+	LotteryBuyTxIndex
+	ReqLotteryBuyByTxHash
+	ReplyLotteryBuyByTxHash
+	ReplyLotteryBuyRecordsByAddr
+	LotteryWinnerRecord
+	ReqLotteryRoundWinners
+	ReplyLotteryRoundWinners
+	LotteryStats
+	LotteryNumberHeat
+	ReqLotteryNumberHeat
+	ReplyLotteryNumberHeat
+	ReplyLotteryCurrentPool
+	ReqLotteryAgentSales
+	LotteryAgentSales
+	ReqLotteryAddrWinnings
+	LotteryAddrWinnings
+	LotteryBoardEntry
+	LotteryLeaderboard
+	ReqLotteryLeaderboard
+	ReplyLotteryLeaderboard
+	LotteryDrawProof
+	ReqLotteryDrawProof
+	LotteryRoundInfo
+	ReqLotteryFullInfo
+	LotteryAddrRoundInfo
+	ReplyLotteryFullInfo
+	ReplyLotteryVerifyDraw
+	ReqLotteryRoundsInfo
+	ReplyLotteryRoundsInfo
+	ReplyLotteryPrizePool
+	ReqLotteryRefundRecords
+	ReplyLotteryRefundRecords
+	ReqLotteryList
+	LotteryListItem
+	ReplyLotteryList
 */
 package types
 
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import types2 "github.com/33cn/chain33/types"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -63,6 +133,11 @@ type PurchaseRecord struct {
 	Number int64 `protobuf:"varint,2,opt,name=number" json:"number,omitempty"`
 	Index  int64 `protobuf:"varint,3,opt,name=index" json:"index,omitempty"`
 	Way    int64 `protobuf:"varint,4,opt,name=way" json:"way,omitempty"`
+	// 盲选购买时提交的号码hash, 揭示之后revealed为true
+	CommitHash []byte `protobuf:"bytes,5,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	Revealed   bool   `protobuf:"varint,6,opt,name=revealed" json:"revealed,omitempty"`
+	// 付给销售代理的佣金, 退款时不退还
+	Commission int64 `protobuf:"varint,7,opt,name=commission" json:"commission,omitempty"`
 }
 
 func (m *PurchaseRecord) Reset()                    { *m = PurchaseRecord{} }
@@ -98,10 +173,32 @@ func (m *PurchaseRecord) GetWay() int64 {
 	return 0
 }
 
+func (m *PurchaseRecord) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
+func (m *PurchaseRecord) GetRevealed() bool {
+	if m != nil {
+		return m.Revealed
+	}
+	return false
+}
+
+func (m *PurchaseRecord) GetCommission() int64 {
+	if m != nil {
+		return m.Commission
+	}
+	return 0
+}
+
 type PurchaseRecords struct {
 	Record         []*PurchaseRecord `protobuf:"bytes,1,rep,name=record" json:"record,omitempty"`
 	FundWin        int64             `protobuf:"varint,2,opt,name=fundWin" json:"fundWin,omitempty"`
 	AmountOneRound int64             `protobuf:"varint,3,opt,name=amountOneRound" json:"amountOneRound,omitempty"`
+	Refunded       bool              `protobuf:"varint,4,opt,name=refunded" json:"refunded,omitempty"`
 }
 
 func (m *PurchaseRecords) Reset()                    { *m = PurchaseRecords{} }
@@ -130,6 +227,13 @@ func (m *PurchaseRecords) GetAmountOneRound() int64 {
 	return 0
 }
 
+func (m *PurchaseRecords) GetRefunded() bool {
+	if m != nil {
+		return m.Refunded
+	}
+	return false
+}
+
 type Lottery struct {
 	LotteryId                  string                      `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
 	Status                     int32                       `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
@@ -148,6 +252,46 @@ type Lottery struct {
 	LastTransToPurStateOnMain  int64                       `protobuf:"varint,15,opt,name=lastTransToPurStateOnMain" json:"lastTransToPurStateOnMain,omitempty"`
 	LastTransToDrawStateOnMain int64                       `protobuf:"varint,16,opt,name=lastTransToDrawStateOnMain" json:"lastTransToDrawStateOnMain,omitempty"`
 	MissingRecords             []*MissingRecord            `protobuf:"bytes,17,rep,name=missingRecords" json:"missingRecords,omitempty"`
+	OpPurchaseLimit            int64                       `protobuf:"varint,18,opt,name=opPurchaseLimit" json:"opPurchaseLimit,omitempty"`
+	CreatorFeeRatio            int64                       `protobuf:"varint,19,opt,name=creatorFeeRatio" json:"creatorFeeRatio,omitempty"`
+	PrizeRatio                 []int64                     `protobuf:"varint,20,rep,packed,name=prizeRatio" json:"prizeRatio,omitempty"`
+	MaxRounds                  int64                       `protobuf:"varint,21,opt,name=maxRounds" json:"maxRounds,omitempty"`
+	Closing                    bool                        `protobuf:"varint,22,opt,name=closing" json:"closing,omitempty"`
+	RevealBlockNum             int64                       `protobuf:"varint,23,opt,name=revealBlockNum" json:"revealBlockNum,omitempty"`
+	RevealTimeout              int64                       `protobuf:"varint,24,opt,name=revealTimeout" json:"revealTimeout,omitempty"`
+	TimeoutRefund              bool                        `protobuf:"varint,25,opt,name=timeoutRefund" json:"timeoutRefund,omitempty"`
+	CommitHash                 []byte                      `protobuf:"bytes,26,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	CommitHeight               int64                       `protobuf:"varint,27,opt,name=commitHeight" json:"commitHeight,omitempty"`
+	CommitAddr                 string                      `protobuf:"bytes,28,opt,name=commitAddr" json:"commitAddr,omitempty"`
+	RolloverPool               int64                       `protobuf:"varint,29,opt,name=rolloverPool" json:"rolloverPool,omitempty"`
+	RolloverToBuyers           bool                        `protobuf:"varint,30,opt,name=rolloverToBuyers" json:"rolloverToBuyers,omitempty"`
+	TokenSymbol                string                      `protobuf:"bytes,31,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+	EscrowAddr                 string                      `protobuf:"bytes,32,opt,name=escrowAddr" json:"escrowAddr,omitempty"`
+	// 累计销售额, 不包括退款的部分
+	TotalSales         int64 `protobuf:"varint,33,opt,name=totalSales" json:"totalSales,omitempty"`
+	DrawDeadlineBlocks int64 `protobuf:"varint,34,opt,name=drawDeadlineBlocks" json:"drawDeadlineBlocks,omitempty"`
+	DrawRewardRatio    int64 `protobuf:"varint,35,opt,name=drawRewardRatio" json:"drawRewardRatio,omitempty"`
+	MinPurchaseNum     int64 `protobuf:"varint,36,opt,name=minPurchaseNum" json:"minPurchaseNum,omitempty"`
+	MinSalesAmount     int64 `protobuf:"varint,37,opt,name=minSalesAmount" json:"minSalesAmount,omitempty"`
+	MaxWaitBlocks      int64 `protobuf:"varint,38,opt,name=maxWaitBlocks" json:"maxWaitBlocks,omitempty"`
+	RefundBelowMin     bool  `protobuf:"varint,39,opt,name=refundBelowMin" json:"refundBelowMin,omitempty"`
+	// 创建者以外可以开奖的地址
+	Drawers []string `protobuf:"bytes,40,rep,name=drawers" json:"drawers,omitempty"`
+	// 移交之后的管理地址, 为空时由创建者管理
+	Admin           string `protobuf:"bytes,41,opt,name=admin" json:"admin,omitempty"`
+	CloseHeight     int64  `protobuf:"varint,42,opt,name=closeHeight" json:"closeHeight,omitempty"`
+	ReclaimBlockNum int64  `protobuf:"varint,43,opt,name=reclaimBlockNum" json:"reclaimBlockNum,omitempty"`
+	Reclaimed       bool   `protobuf:"varint,44,opt,name=reclaimed" json:"reclaimed,omitempty"`
+	// 不能购买的地址, 按地址排序, 购买时二分查找
+	Blacklist         []string `protobuf:"bytes,45,rep,name=blacklist" json:"blacklist,omitempty"`
+	MaxBuyPerTx       int64    `protobuf:"varint,46,opt,name=maxBuyPerTx" json:"maxBuyPerTx,omitempty"`
+	CommissionRatio   int64    `protobuf:"varint,47,opt,name=commissionRatio" json:"commissionRatio,omitempty"`
+	ForbidSelfDealing bool     `protobuf:"varint,48,opt,name=forbidSelfDealing" json:"forbidSelfDealing,omitempty"`
+	AssetExec         string   `protobuf:"bytes,49,opt,name=assetExec" json:"assetExec,omitempty"`
+	Weighted          bool     `protobuf:"varint,50,opt,name=weighted" json:"weighted,omitempty"`
+	PayoutMaturity    int64    `protobuf:"varint,51,opt,name=payoutMaturity" json:"payoutMaturity,omitempty"`
+	// 已经开奖但是还没有领取的奖金, 账户中的金额, 回收奖池时保留
+	LockedPayout int64 `protobuf:"varint,52,opt,name=lockedPayout" json:"lockedPayout,omitempty"`
 }
 
 func (m *Lottery) Reset()                    { *m = Lottery{} }
@@ -274,6 +418,251 @@ func (m *Lottery) GetMissingRecords() []*MissingRecord {
 	return nil
 }
 
+func (m *Lottery) GetOpPurchaseLimit() int64 {
+	if m != nil {
+		return m.OpPurchaseLimit
+	}
+	return 0
+}
+
+func (m *Lottery) GetCreatorFeeRatio() int64 {
+	if m != nil {
+		return m.CreatorFeeRatio
+	}
+	return 0
+}
+
+func (m *Lottery) GetPrizeRatio() []int64 {
+	if m != nil {
+		return m.PrizeRatio
+	}
+	return nil
+}
+
+func (m *Lottery) GetMaxRounds() int64 {
+	if m != nil {
+		return m.MaxRounds
+	}
+	return 0
+}
+
+func (m *Lottery) GetClosing() bool {
+	if m != nil {
+		return m.Closing
+	}
+	return false
+}
+
+func (m *Lottery) GetRevealBlockNum() int64 {
+	if m != nil {
+		return m.RevealBlockNum
+	}
+	return 0
+}
+
+func (m *Lottery) GetRevealTimeout() int64 {
+	if m != nil {
+		return m.RevealTimeout
+	}
+	return 0
+}
+
+func (m *Lottery) GetTimeoutRefund() bool {
+	if m != nil {
+		return m.TimeoutRefund
+	}
+	return false
+}
+
+func (m *Lottery) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
+func (m *Lottery) GetCommitHeight() int64 {
+	if m != nil {
+		return m.CommitHeight
+	}
+	return 0
+}
+
+func (m *Lottery) GetCommitAddr() string {
+	if m != nil {
+		return m.CommitAddr
+	}
+	return ""
+}
+
+func (m *Lottery) GetRolloverPool() int64 {
+	if m != nil {
+		return m.RolloverPool
+	}
+	return 0
+}
+
+func (m *Lottery) GetRolloverToBuyers() bool {
+	if m != nil {
+		return m.RolloverToBuyers
+	}
+	return false
+}
+
+func (m *Lottery) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *Lottery) GetEscrowAddr() string {
+	if m != nil {
+		return m.EscrowAddr
+	}
+	return ""
+}
+
+func (m *Lottery) GetTotalSales() int64 {
+	if m != nil {
+		return m.TotalSales
+	}
+	return 0
+}
+
+func (m *Lottery) GetDrawDeadlineBlocks() int64 {
+	if m != nil {
+		return m.DrawDeadlineBlocks
+	}
+	return 0
+}
+
+func (m *Lottery) GetDrawRewardRatio() int64 {
+	if m != nil {
+		return m.DrawRewardRatio
+	}
+	return 0
+}
+
+func (m *Lottery) GetMinPurchaseNum() int64 {
+	if m != nil {
+		return m.MinPurchaseNum
+	}
+	return 0
+}
+
+func (m *Lottery) GetMinSalesAmount() int64 {
+	if m != nil {
+		return m.MinSalesAmount
+	}
+	return 0
+}
+
+func (m *Lottery) GetMaxWaitBlocks() int64 {
+	if m != nil {
+		return m.MaxWaitBlocks
+	}
+	return 0
+}
+
+func (m *Lottery) GetRefundBelowMin() bool {
+	if m != nil {
+		return m.RefundBelowMin
+	}
+	return false
+}
+
+func (m *Lottery) GetDrawers() []string {
+	if m != nil {
+		return m.Drawers
+	}
+	return nil
+}
+
+func (m *Lottery) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+func (m *Lottery) GetCloseHeight() int64 {
+	if m != nil {
+		return m.CloseHeight
+	}
+	return 0
+}
+
+func (m *Lottery) GetReclaimBlockNum() int64 {
+	if m != nil {
+		return m.ReclaimBlockNum
+	}
+	return 0
+}
+
+func (m *Lottery) GetReclaimed() bool {
+	if m != nil {
+		return m.Reclaimed
+	}
+	return false
+}
+
+func (m *Lottery) GetBlacklist() []string {
+	if m != nil {
+		return m.Blacklist
+	}
+	return nil
+}
+
+func (m *Lottery) GetMaxBuyPerTx() int64 {
+	if m != nil {
+		return m.MaxBuyPerTx
+	}
+	return 0
+}
+
+func (m *Lottery) GetCommissionRatio() int64 {
+	if m != nil {
+		return m.CommissionRatio
+	}
+	return 0
+}
+
+func (m *Lottery) GetForbidSelfDealing() bool {
+	if m != nil {
+		return m.ForbidSelfDealing
+	}
+	return false
+}
+
+func (m *Lottery) GetAssetExec() string {
+	if m != nil {
+		return m.AssetExec
+	}
+	return ""
+}
+
+func (m *Lottery) GetWeighted() bool {
+	if m != nil {
+		return m.Weighted
+	}
+	return false
+}
+
+func (m *Lottery) GetPayoutMaturity() int64 {
+	if m != nil {
+		return m.PayoutMaturity
+	}
+	return 0
+}
+
+func (m *Lottery) GetLockedPayout() int64 {
+	if m != nil {
+		return m.LockedPayout
+	}
+	return 0
+}
+
 type MissingRecord struct {
 	Times []int32 `protobuf:"varint,1,rep,packed,name=times" json:"times,omitempty"`
 }
@@ -296,6 +685,15 @@ type LotteryAction struct {
 	//	*LotteryAction_Buy
 	//	*LotteryAction_Draw
 	//	*LotteryAction_Close
+	//	*LotteryAction_Commit
+	//	*LotteryAction_Reveal
+	//	*LotteryAction_RevealNumber
+	//	*LotteryAction_Modify
+	//	*LotteryAction_Transfer
+	//	*LotteryAction_Reclaim
+	//	*LotteryAction_Blacklist
+	//	*LotteryAction_Claim
+	//	*LotteryAction_TransferTicket
 	Value isLotteryAction_Value `protobuf_oneof:"value"`
 	Ty    int32                 `protobuf:"varint,10,opt,name=ty" json:"ty,omitempty"`
 }
@@ -321,11 +719,47 @@ type LotteryAction_Draw struct {
 type LotteryAction_Close struct {
 	Close *LotteryClose `protobuf:"bytes,4,opt,name=close,oneof"`
 }
+type LotteryAction_Commit struct {
+	Commit *LotteryCommit `protobuf:"bytes,5,opt,name=commit,oneof"`
+}
+type LotteryAction_Reveal struct {
+	Reveal *LotteryReveal `protobuf:"bytes,6,opt,name=reveal,oneof"`
+}
+type LotteryAction_RevealNumber struct {
+	RevealNumber *LotteryRevealNumber `protobuf:"bytes,7,opt,name=revealNumber,oneof"`
+}
+type LotteryAction_Modify struct {
+	Modify *LotteryModify `protobuf:"bytes,8,opt,name=modify,oneof"`
+}
+type LotteryAction_Transfer struct {
+	Transfer *LotteryTransfer `protobuf:"bytes,9,opt,name=transfer,oneof"`
+}
+type LotteryAction_Reclaim struct {
+	Reclaim *LotteryReclaim `protobuf:"bytes,11,opt,name=reclaim,oneof"`
+}
+type LotteryAction_Blacklist struct {
+	Blacklist *LotteryBlacklist `protobuf:"bytes,12,opt,name=blacklist,oneof"`
+}
+type LotteryAction_Claim struct {
+	Claim *LotteryClaim `protobuf:"bytes,13,opt,name=claim,oneof"`
+}
+type LotteryAction_TransferTicket struct {
+	TransferTicket *LotteryTransferTicket `protobuf:"bytes,14,opt,name=transferTicket,oneof"`
+}
 
-func (*LotteryAction_Create) isLotteryAction_Value() {}
-func (*LotteryAction_Buy) isLotteryAction_Value()    {}
-func (*LotteryAction_Draw) isLotteryAction_Value()   {}
-func (*LotteryAction_Close) isLotteryAction_Value()  {}
+func (*LotteryAction_Create) isLotteryAction_Value()         {}
+func (*LotteryAction_Buy) isLotteryAction_Value()            {}
+func (*LotteryAction_Draw) isLotteryAction_Value()           {}
+func (*LotteryAction_Close) isLotteryAction_Value()          {}
+func (*LotteryAction_Commit) isLotteryAction_Value()         {}
+func (*LotteryAction_Reveal) isLotteryAction_Value()         {}
+func (*LotteryAction_RevealNumber) isLotteryAction_Value()   {}
+func (*LotteryAction_Modify) isLotteryAction_Value()         {}
+func (*LotteryAction_Transfer) isLotteryAction_Value()       {}
+func (*LotteryAction_Reclaim) isLotteryAction_Value()        {}
+func (*LotteryAction_Blacklist) isLotteryAction_Value()      {}
+func (*LotteryAction_Claim) isLotteryAction_Value()          {}
+func (*LotteryAction_TransferTicket) isLotteryAction_Value() {}
 
 func (m *LotteryAction) GetValue() isLotteryAction_Value {
 	if m != nil {
@@ -362,6 +796,69 @@ func (m *LotteryAction) GetClose() *LotteryClose {
 	return nil
 }
 
+func (m *LotteryAction) GetCommit() *LotteryCommit {
+	if x, ok := m.GetValue().(*LotteryAction_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetReveal() *LotteryReveal {
+	if x, ok := m.GetValue().(*LotteryAction_Reveal); ok {
+		return x.Reveal
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetRevealNumber() *LotteryRevealNumber {
+	if x, ok := m.GetValue().(*LotteryAction_RevealNumber); ok {
+		return x.RevealNumber
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetModify() *LotteryModify {
+	if x, ok := m.GetValue().(*LotteryAction_Modify); ok {
+		return x.Modify
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetTransfer() *LotteryTransfer {
+	if x, ok := m.GetValue().(*LotteryAction_Transfer); ok {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetReclaim() *LotteryReclaim {
+	if x, ok := m.GetValue().(*LotteryAction_Reclaim); ok {
+		return x.Reclaim
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetBlacklist() *LotteryBlacklist {
+	if x, ok := m.GetValue().(*LotteryAction_Blacklist); ok {
+		return x.Blacklist
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetClaim() *LotteryClaim {
+	if x, ok := m.GetValue().(*LotteryAction_Claim); ok {
+		return x.Claim
+	}
+	return nil
+}
+
+func (m *LotteryAction) GetTransferTicket() *LotteryTransferTicket {
+	if x, ok := m.GetValue().(*LotteryAction_TransferTicket); ok {
+		return x.TransferTicket
+	}
+	return nil
+}
+
 func (m *LotteryAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -376,6 +873,15 @@ func (*LotteryAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer)
 		(*LotteryAction_Buy)(nil),
 		(*LotteryAction_Draw)(nil),
 		(*LotteryAction_Close)(nil),
+		(*LotteryAction_Commit)(nil),
+		(*LotteryAction_Reveal)(nil),
+		(*LotteryAction_RevealNumber)(nil),
+		(*LotteryAction_Modify)(nil),
+		(*LotteryAction_Transfer)(nil),
+		(*LotteryAction_Reclaim)(nil),
+		(*LotteryAction_Blacklist)(nil),
+		(*LotteryAction_Claim)(nil),
+		(*LotteryAction_TransferTicket)(nil),
 	}
 }
 
@@ -403,6 +909,51 @@ func _LotteryAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Close); err != nil {
 			return err
 		}
+	case *LotteryAction_Commit:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Commit); err != nil {
+			return err
+		}
+	case *LotteryAction_Reveal:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Reveal); err != nil {
+			return err
+		}
+	case *LotteryAction_RevealNumber:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RevealNumber); err != nil {
+			return err
+		}
+	case *LotteryAction_Modify:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Modify); err != nil {
+			return err
+		}
+	case *LotteryAction_Transfer:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Transfer); err != nil {
+			return err
+		}
+	case *LotteryAction_Reclaim:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Reclaim); err != nil {
+			return err
+		}
+	case *LotteryAction_Blacklist:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Blacklist); err != nil {
+			return err
+		}
+	case *LotteryAction_Claim:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Claim); err != nil {
+			return err
+		}
+	case *LotteryAction_TransferTicket:
+		b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TransferTicket); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("LotteryAction.Value has unexpected type %T", x)
@@ -445,12 +996,84 @@ func _LotteryAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Value = &LotteryAction_Close{msg}
 		return true, err
-	default:
-		return false, nil
-	}
-}
-
-func _LotteryAction_OneofSizer(msg proto.Message) (n int) {
+	case 5: // value.commit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryCommit)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Commit{msg}
+		return true, err
+	case 6: // value.reveal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryReveal)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Reveal{msg}
+		return true, err
+	case 7: // value.revealNumber
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryRevealNumber)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_RevealNumber{msg}
+		return true, err
+	case 8: // value.modify
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryModify)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Modify{msg}
+		return true, err
+	case 9: // value.transfer
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryTransfer)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Transfer{msg}
+		return true, err
+	case 11: // value.reclaim
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryReclaim)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Reclaim{msg}
+		return true, err
+	case 12: // value.blacklist
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryBlacklist)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Blacklist{msg}
+		return true, err
+	case 13: // value.claim
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryClaim)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_Claim{msg}
+		return true, err
+	case 14: // value.transferTicket
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LotteryTransferTicket)
+		err := b.DecodeMessage(msg)
+		m.Value = &LotteryAction_TransferTicket{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _LotteryAction_OneofSizer(msg proto.Message) (n int) {
 	m := msg.(*LotteryAction)
 	// value
 	switch x := m.Value.(type) {
@@ -474,6 +1097,51 @@ func _LotteryAction_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(4<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *LotteryAction_Commit:
+		s := proto.Size(x.Commit)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_Reveal:
+		s := proto.Size(x.Reveal)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_RevealNumber:
+		s := proto.Size(x.RevealNumber)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_Modify:
+		s := proto.Size(x.Modify)
+		n += proto.SizeVarint(8<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_Transfer:
+		s := proto.Size(x.Transfer)
+		n += proto.SizeVarint(9<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_Reclaim:
+		s := proto.Size(x.Reclaim)
+		n += proto.SizeVarint(11<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_Blacklist:
+		s := proto.Size(x.Blacklist)
+		n += proto.SizeVarint(12<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_Claim:
+		s := proto.Size(x.Claim)
+		n += proto.SizeVarint(13<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LotteryAction_TransferTicket:
+		s := proto.Size(x.TransferTicket)
+		n += proto.SizeVarint(14<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -484,6 +1152,53 @@ func _LotteryAction_OneofSizer(msg proto.Message) (n int) {
 type LotteryCreate struct {
 	PurBlockNum  int64 `protobuf:"varint,1,opt,name=purBlockNum" json:"purBlockNum,omitempty"`
 	DrawBlockNum int64 `protobuf:"varint,2,opt,name=drawBlockNum" json:"drawBlockNum,omitempty"`
+	// 每个地址每轮最多购买的数量, 0表示不限制
+	OpPurchaseLimit int64 `protobuf:"varint,3,opt,name=opPurchaseLimit" json:"opPurchaseLimit,omitempty"`
+	// 开奖时从奖池中给创建者的分成比例, 按本轮销售额的百分比计算
+	CreatorFeeRatio int64 `protobuf:"varint,4,opt,name=creatorFeeRatio" json:"creatorFeeRatio,omitempty"`
+	// 依次为五星, 三星, 二星, 一星分得奖池的百分比, 总和不超过100, 为空时使用默认的奖金倍数
+	PrizeRatio []int64 `protobuf:"varint,5,rep,packed,name=prizeRatio" json:"prizeRatio,omitempty"`
+	// 最多进行的轮数, 最后一轮开奖之后自动关闭, 0表示不限制
+	MaxRounds int64 `protobuf:"varint,6,opt,name=maxRounds" json:"maxRounds,omitempty"`
+	// 大于0时开奖分为提交和揭示两步, 提交hash之后至少等待revealBlockNum个区块才能揭示
+	RevealBlockNum int64 `protobuf:"varint,7,opt,name=revealBlockNum" json:"revealBlockNum,omitempty"`
+	// 提交之后超过revealTimeout个区块没有揭示, 任何可以开奖的地址都可以按超时处理
+	RevealTimeout int64 `protobuf:"varint,8,opt,name=revealTimeout" json:"revealTimeout,omitempty"`
+	// 揭示超时之后关闭彩票并退款, 否则使用原来的方式计算开奖号码
+	TimeoutRefund bool `protobuf:"varint,9,opt,name=timeoutRefund" json:"timeoutRefund,omitempty"`
+	// 购买中关闭时累积的头奖按购买数量分给本轮的购买者, 否则退还给创建者
+	RolloverToBuyers bool `protobuf:"varint,10,opt,name=rolloverToBuyers" json:"rolloverToBuyers,omitempty"`
+	// 使用token 购买和开奖, 为空时使用coins
+	TokenSymbol string `protobuf:"bytes,11,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+	// 大于0时, 本轮开始超过drawDeadlineBlocks个区块之后任何地址都可以开奖
+	DrawDeadlineBlocks int64 `protobuf:"varint,12,opt,name=drawDeadlineBlocks" json:"drawDeadlineBlocks,omitempty"`
+	// 超过期限之后由创建者以外的地址开奖时, 按本轮销售额的百分比奖励开奖的地址
+	DrawRewardRatio int64 `protobuf:"varint,13,opt,name=drawRewardRatio" json:"drawRewardRatio,omitempty"`
+	// 本轮购买交易数量和销售额达到要求之后才能开奖, 0表示不限制
+	MinPurchaseNum int64 `protobuf:"varint,14,opt,name=minPurchaseNum" json:"minPurchaseNum,omitempty"`
+	MinSalesAmount int64 `protobuf:"varint,15,opt,name=minSalesAmount" json:"minSalesAmount,omitempty"`
+	// 大于0时, 本轮开始超过maxWaitBlocks个区块之后不再等待, 按refundBelowMin开奖或者关闭退款
+	MaxWaitBlocks  int64 `protobuf:"varint,16,opt,name=maxWaitBlocks" json:"maxWaitBlocks,omitempty"`
+	RefundBelowMin bool  `protobuf:"varint,17,opt,name=refundBelowMin" json:"refundBelowMin,omitempty"`
+	// 创建者以外可以开奖的地址
+	Drawers []string `protobuf:"bytes,18,rep,name=drawers" json:"drawers,omitempty"`
+	// 关闭之后至少等待reclaimBlockNum个区块, 管理地址才能回收奖池中剩余的资金, 0表示使用默认值
+	ReclaimBlockNum int64 `protobuf:"varint,19,opt,name=reclaimBlockNum" json:"reclaimBlockNum,omitempty"`
+	// 不能购买的地址
+	Blacklist []string `protobuf:"bytes,20,rep,name=blacklist" json:"blacklist,omitempty"`
+	// 每笔购买交易最多购买的数量, 0表示只受资产总量的限制
+	MaxBuyPerTx int64 `protobuf:"varint,21,opt,name=maxBuyPerTx" json:"maxBuyPerTx,omitempty"`
+	// 通过代理购买时, 代理从购买数量中分得的百分比, 0表示没有佣金
+	CommissionRatio int64 `protobuf:"varint,22,opt,name=commissionRatio" json:"commissionRatio,omitempty"`
+	// 代理地址不能和购买地址相同
+	ForbidSelfDealing bool `protobuf:"varint,23,opt,name=forbidSelfDealing" json:"forbidSelfDealing,omitempty"`
+	// tokenSymbol 所在的执行器, 为空时按tokenSymbol 使用coins 或者token,
+	// 平行链上使用主链跨链过来的资产时为paracross, tokenSymbol 为coins.bty 或者token.{symbol}
+	AssetExec string `protobuf:"bytes,24,opt,name=assetExec" json:"assetExec,omitempty"`
+	// 按购买数量加权随机抽取头奖, 购买越多中奖概率越大, 不再按号码匹配开奖
+	Weighted bool `protobuf:"varint,25,opt,name=weighted" json:"weighted,omitempty"`
+	// 大于0时开奖不直接发放奖金, 开奖之后payoutMaturity 个区块中奖地址才能通过LotteryClaim 领取
+	PayoutMaturity int64 `protobuf:"varint,26,opt,name=payoutMaturity" json:"payoutMaturity,omitempty"`
 }
 
 func (m *LotteryCreate) Reset()                    { *m = LotteryCreate{} }
@@ -505,775 +1220,4111 @@ func (m *LotteryCreate) GetDrawBlockNum() int64 {
 	return 0
 }
 
-type LotteryBuy struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
-	Amount    int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
-	Number    int64  `protobuf:"varint,3,opt,name=number" json:"number,omitempty"`
-	Way       int64  `protobuf:"varint,4,opt,name=way" json:"way,omitempty"`
+func (m *LotteryCreate) GetOpPurchaseLimit() int64 {
+	if m != nil {
+		return m.OpPurchaseLimit
+	}
+	return 0
 }
 
-func (m *LotteryBuy) Reset()                    { *m = LotteryBuy{} }
-func (m *LotteryBuy) String() string            { return proto.CompactTextString(m) }
-func (*LotteryBuy) ProtoMessage()               {}
-func (*LotteryBuy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
-
-func (m *LotteryBuy) GetLotteryId() string {
+func (m *LotteryCreate) GetCreatorFeeRatio() int64 {
 	if m != nil {
-		return m.LotteryId
+		return m.CreatorFeeRatio
 	}
-	return ""
+	return 0
 }
 
-func (m *LotteryBuy) GetAmount() int64 {
+func (m *LotteryCreate) GetPrizeRatio() []int64 {
 	if m != nil {
-		return m.Amount
+		return m.PrizeRatio
 	}
-	return 0
+	return nil
 }
 
-func (m *LotteryBuy) GetNumber() int64 {
+func (m *LotteryCreate) GetMaxRounds() int64 {
 	if m != nil {
-		return m.Number
+		return m.MaxRounds
 	}
 	return 0
 }
 
-func (m *LotteryBuy) GetWay() int64 {
+func (m *LotteryCreate) GetRevealBlockNum() int64 {
 	if m != nil {
-		return m.Way
+		return m.RevealBlockNum
 	}
 	return 0
 }
 
-type LotteryDraw struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+func (m *LotteryCreate) GetRevealTimeout() int64 {
+	if m != nil {
+		return m.RevealTimeout
+	}
+	return 0
 }
 
-func (m *LotteryDraw) Reset()                    { *m = LotteryDraw{} }
-func (m *LotteryDraw) String() string            { return proto.CompactTextString(m) }
-func (*LotteryDraw) ProtoMessage()               {}
-func (*LotteryDraw) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
-func (m *LotteryDraw) GetLotteryId() string {
+func (m *LotteryCreate) GetTimeoutRefund() bool {
 	if m != nil {
-		return m.LotteryId
+		return m.TimeoutRefund
 	}
-	return ""
+	return false
 }
 
-type LotteryClose struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+func (m *LotteryCreate) GetRolloverToBuyers() bool {
+	if m != nil {
+		return m.RolloverToBuyers
+	}
+	return false
 }
 
-func (m *LotteryClose) Reset()                    { *m = LotteryClose{} }
-func (m *LotteryClose) String() string            { return proto.CompactTextString(m) }
-func (*LotteryClose) ProtoMessage()               {}
-func (*LotteryClose) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
-
-func (m *LotteryClose) GetLotteryId() string {
+func (m *LotteryCreate) GetTokenSymbol() string {
 	if m != nil {
-		return m.LotteryId
+		return m.TokenSymbol
 	}
 	return ""
 }
 
-type ReceiptLottery struct {
-	LotteryId   string                `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
-	Status      int32                 `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
-	PrevStatus  int32                 `protobuf:"varint,3,opt,name=prevStatus" json:"prevStatus,omitempty"`
-	Addr        string                `protobuf:"bytes,4,opt,name=addr" json:"addr,omitempty"`
-	Round       int64                 `protobuf:"varint,5,opt,name=round" json:"round,omitempty"`
-	Number      int64                 `protobuf:"varint,6,opt,name=number" json:"number,omitempty"`
-	Amount      int64                 `protobuf:"varint,7,opt,name=amount" json:"amount,omitempty"`
-	LuckyNumber int64                 `protobuf:"varint,8,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
-	Time        int64                 `protobuf:"varint,9,opt,name=time" json:"time,omitempty"`
-	TxHash      string                `protobuf:"bytes,10,opt,name=txHash" json:"txHash,omitempty"`
-	UpdateInfo  *LotteryUpdateBuyInfo `protobuf:"bytes,11,opt,name=updateInfo" json:"updateInfo,omitempty"`
-	Way         int64                 `protobuf:"varint,12,opt,name=way" json:"way,omitempty"`
-	Index       int64                 `protobuf:"varint,13,opt,name=index" json:"index,omitempty"`
+func (m *LotteryCreate) GetDrawDeadlineBlocks() int64 {
+	if m != nil {
+		return m.DrawDeadlineBlocks
+	}
+	return 0
 }
 
-func (m *ReceiptLottery) Reset()                    { *m = ReceiptLottery{} }
-func (m *ReceiptLottery) String() string            { return proto.CompactTextString(m) }
-func (*ReceiptLottery) ProtoMessage()               {}
-func (*ReceiptLottery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *LotteryCreate) GetDrawRewardRatio() int64 {
+	if m != nil {
+		return m.DrawRewardRatio
+	}
+	return 0
+}
 
-func (m *ReceiptLottery) GetLotteryId() string {
+func (m *LotteryCreate) GetMinPurchaseNum() int64 {
 	if m != nil {
-		return m.LotteryId
+		return m.MinPurchaseNum
 	}
-	return ""
+	return 0
 }
 
-func (m *ReceiptLottery) GetStatus() int32 {
+func (m *LotteryCreate) GetMinSalesAmount() int64 {
 	if m != nil {
-		return m.Status
+		return m.MinSalesAmount
 	}
 	return 0
 }
 
-func (m *ReceiptLottery) GetPrevStatus() int32 {
+func (m *LotteryCreate) GetMaxWaitBlocks() int64 {
 	if m != nil {
-		return m.PrevStatus
+		return m.MaxWaitBlocks
 	}
 	return 0
 }
 
-func (m *ReceiptLottery) GetAddr() string {
+func (m *LotteryCreate) GetRefundBelowMin() bool {
 	if m != nil {
-		return m.Addr
+		return m.RefundBelowMin
 	}
-	return ""
+	return false
 }
 
-func (m *ReceiptLottery) GetRound() int64 {
+func (m *LotteryCreate) GetDrawers() []string {
 	if m != nil {
-		return m.Round
+		return m.Drawers
 	}
-	return 0
+	return nil
 }
 
-func (m *ReceiptLottery) GetNumber() int64 {
+func (m *LotteryCreate) GetReclaimBlockNum() int64 {
 	if m != nil {
-		return m.Number
+		return m.ReclaimBlockNum
 	}
 	return 0
 }
 
-func (m *ReceiptLottery) GetAmount() int64 {
+func (m *LotteryCreate) GetBlacklist() []string {
 	if m != nil {
-		return m.Amount
+		return m.Blacklist
 	}
-	return 0
+	return nil
 }
 
-func (m *ReceiptLottery) GetLuckyNumber() int64 {
+func (m *LotteryCreate) GetMaxBuyPerTx() int64 {
 	if m != nil {
-		return m.LuckyNumber
+		return m.MaxBuyPerTx
 	}
 	return 0
 }
 
-func (m *ReceiptLottery) GetTime() int64 {
+func (m *LotteryCreate) GetCommissionRatio() int64 {
 	if m != nil {
-		return m.Time
+		return m.CommissionRatio
 	}
 	return 0
 }
 
-func (m *ReceiptLottery) GetTxHash() string {
+func (m *LotteryCreate) GetForbidSelfDealing() bool {
 	if m != nil {
-		return m.TxHash
+		return m.ForbidSelfDealing
 	}
-	return ""
+	return false
 }
 
-func (m *ReceiptLottery) GetUpdateInfo() *LotteryUpdateBuyInfo {
+func (m *LotteryCreate) GetAssetExec() string {
 	if m != nil {
-		return m.UpdateInfo
+		return m.AssetExec
 	}
-	return nil
+	return ""
 }
 
-func (m *ReceiptLottery) GetWay() int64 {
+func (m *LotteryCreate) GetWeighted() bool {
 	if m != nil {
-		return m.Way
+		return m.Weighted
 	}
-	return 0
+	return false
 }
 
-func (m *ReceiptLottery) GetIndex() int64 {
+func (m *LotteryCreate) GetPayoutMaturity() int64 {
 	if m != nil {
-		return m.Index
+		return m.PayoutMaturity
 	}
 	return 0
 }
 
-type ReqLotteryInfo struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+type LotteryBuy struct {
+	LotteryId string            `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Amount    int64             `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Number    int64             `protobuf:"varint,3,opt,name=number" json:"number,omitempty"`
+	Way       int64             `protobuf:"varint,4,opt,name=way" json:"way,omitempty"`
+	Items     []*LotteryBuyItem `protobuf:"bytes,5,rep,name=items" json:"items,omitempty"`
+	// 盲选购买, 为sha256(号码 || nonce), 号码在开奖前通过LotteryRevealNumber揭示
+	CommitHash []byte `protobuf:"bytes,6,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	// 销售代理的地址, 可以为空
+	AgentAddr string `protobuf:"bytes,7,opt,name=agentAddr" json:"agentAddr,omitempty"`
 }
 
-func (m *ReqLotteryInfo) Reset()                    { *m = ReqLotteryInfo{} }
-func (m *ReqLotteryInfo) String() string            { return proto.CompactTextString(m) }
-func (*ReqLotteryInfo) ProtoMessage()               {}
-func (*ReqLotteryInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *LotteryBuy) Reset()                    { *m = LotteryBuy{} }
+func (m *LotteryBuy) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBuy) ProtoMessage()               {}
+func (*LotteryBuy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-func (m *ReqLotteryInfo) GetLotteryId() string {
+func (m *LotteryBuy) GetLotteryId() string {
 	if m != nil {
 		return m.LotteryId
 	}
 	return ""
 }
 
-type ReqLotteryBuyInfo struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
-	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
-	Round     int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
-}
-
-func (m *ReqLotteryBuyInfo) Reset()                    { *m = ReqLotteryBuyInfo{} }
-func (m *ReqLotteryBuyInfo) String() string            { return proto.CompactTextString(m) }
-func (*ReqLotteryBuyInfo) ProtoMessage()               {}
-func (*ReqLotteryBuyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
-
-func (m *ReqLotteryBuyInfo) GetLotteryId() string {
+func (m *LotteryBuy) GetAmount() int64 {
 	if m != nil {
-		return m.LotteryId
+		return m.Amount
 	}
-	return ""
+	return 0
 }
 
-func (m *ReqLotteryBuyInfo) GetAddr() string {
+func (m *LotteryBuy) GetNumber() int64 {
 	if m != nil {
-		return m.Addr
+		return m.Number
 	}
-	return ""
+	return 0
 }
 
-func (m *ReqLotteryBuyInfo) GetRound() int64 {
+func (m *LotteryBuy) GetWay() int64 {
 	if m != nil {
-		return m.Round
+		return m.Way
 	}
 	return 0
 }
 
-type ReqLotteryBuyHistory struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
-	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
-	Round     int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
-	Count     int32  `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
-	Direction int32  `protobuf:"varint,5,opt,name=direction" json:"direction,omitempty"`
-	Index     int64  `protobuf:"varint,6,opt,name=index" json:"index,omitempty"`
+func (m *LotteryBuy) GetItems() []*LotteryBuyItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
 }
 
-func (m *ReqLotteryBuyHistory) Reset()                    { *m = ReqLotteryBuyHistory{} }
-func (m *ReqLotteryBuyHistory) String() string            { return proto.CompactTextString(m) }
-func (*ReqLotteryBuyHistory) ProtoMessage()               {}
-func (*ReqLotteryBuyHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
-
-func (m *ReqLotteryBuyHistory) GetLotteryId() string {
+func (m *LotteryBuy) GetCommitHash() []byte {
 	if m != nil {
-		return m.LotteryId
+		return m.CommitHash
 	}
-	return ""
+	return nil
 }
 
-func (m *ReqLotteryBuyHistory) GetAddr() string {
+func (m *LotteryBuy) GetAgentAddr() string {
 	if m != nil {
-		return m.Addr
+		return m.AgentAddr
 	}
 	return ""
 }
 
-func (m *ReqLotteryBuyHistory) GetRound() int64 {
+type LotteryBuyItem struct {
+	Number int64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Way    int64 `protobuf:"varint,3,opt,name=way" json:"way,omitempty"`
+	Index  int64 `protobuf:"varint,4,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *LotteryBuyItem) Reset()                    { *m = LotteryBuyItem{} }
+func (m *LotteryBuyItem) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBuyItem) ProtoMessage()               {}
+func (*LotteryBuyItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *LotteryBuyItem) GetNumber() int64 {
 	if m != nil {
-		return m.Round
+		return m.Number
 	}
 	return 0
 }
 
-func (m *ReqLotteryBuyHistory) GetCount() int32 {
+func (m *LotteryBuyItem) GetAmount() int64 {
 	if m != nil {
-		return m.Count
+		return m.Amount
 	}
 	return 0
 }
 
-func (m *ReqLotteryBuyHistory) GetDirection() int32 {
+func (m *LotteryBuyItem) GetWay() int64 {
 	if m != nil {
-		return m.Direction
+		return m.Way
 	}
 	return 0
 }
 
-func (m *ReqLotteryBuyHistory) GetIndex() int64 {
+func (m *LotteryBuyItem) GetIndex() int64 {
 	if m != nil {
 		return m.Index
 	}
 	return 0
 }
 
-type ReqLotteryLuckyInfo struct {
-	LotteryId string  `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
-	Round     []int64 `protobuf:"varint,2,rep,packed,name=round" json:"round,omitempty"`
+type LotteryDraw struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
 }
 
-func (m *ReqLotteryLuckyInfo) Reset()                    { *m = ReqLotteryLuckyInfo{} }
-func (m *ReqLotteryLuckyInfo) String() string            { return proto.CompactTextString(m) }
-func (*ReqLotteryLuckyInfo) ProtoMessage()               {}
-func (*ReqLotteryLuckyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *LotteryDraw) Reset()                    { *m = LotteryDraw{} }
+func (m *LotteryDraw) String() string            { return proto.CompactTextString(m) }
+func (*LotteryDraw) ProtoMessage()               {}
+func (*LotteryDraw) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-func (m *ReqLotteryLuckyInfo) GetLotteryId() string {
+func (m *LotteryDraw) GetLotteryId() string {
 	if m != nil {
 		return m.LotteryId
 	}
 	return ""
 }
 
-func (m *ReqLotteryLuckyInfo) GetRound() []int64 {
-	if m != nil {
-		return m.Round
-	}
-	return nil
+type LotteryClose struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
 }
 
-type ReqLotteryLuckyHistory struct {
-	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
-	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
-	Count     int32  `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
-	Direction int32  `protobuf:"varint,4,opt,name=direction" json:"direction,omitempty"`
-}
-
-func (m *ReqLotteryLuckyHistory) Reset()                    { *m = ReqLotteryLuckyHistory{} }
-func (m *ReqLotteryLuckyHistory) String() string            { return proto.CompactTextString(m) }
-func (*ReqLotteryLuckyHistory) ProtoMessage()               {}
-func (*ReqLotteryLuckyHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *LotteryClose) Reset()                    { *m = LotteryClose{} }
+func (m *LotteryClose) String() string            { return proto.CompactTextString(m) }
+func (*LotteryClose) ProtoMessage()               {}
+func (*LotteryClose) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
-func (m *ReqLotteryLuckyHistory) GetLotteryId() string {
+func (m *LotteryClose) GetLotteryId() string {
 	if m != nil {
 		return m.LotteryId
 	}
 	return ""
 }
 
-func (m *ReqLotteryLuckyHistory) GetRound() int64 {
-	if m != nil {
-		return m.Round
-	}
-	return 0
+type LotteryCommit struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Hash      []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (m *ReqLotteryLuckyHistory) GetCount() int32 {
+func (m *LotteryCommit) Reset()                    { *m = LotteryCommit{} }
+func (m *LotteryCommit) String() string            { return proto.CompactTextString(m) }
+func (*LotteryCommit) ProtoMessage()               {}
+func (*LotteryCommit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *LotteryCommit) GetLotteryId() string {
 	if m != nil {
-		return m.Count
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReqLotteryLuckyHistory) GetDirection() int32 {
+func (m *LotteryCommit) GetHash() []byte {
 	if m != nil {
-		return m.Direction
+		return m.Hash
 	}
-	return 0
+	return nil
 }
 
-type ReplyLotteryNormalInfo struct {
-	CreateHeight int64  `protobuf:"varint,1,opt,name=createHeight" json:"createHeight,omitempty"`
-	PurBlockNum  int64  `protobuf:"varint,2,opt,name=purBlockNum" json:"purBlockNum,omitempty"`
-	DrawBlockNum int64  `protobuf:"varint,3,opt,name=drawBlockNum" json:"drawBlockNum,omitempty"`
-	CreateAddr   string `protobuf:"bytes,4,opt,name=createAddr" json:"createAddr,omitempty"`
+type LotteryReveal struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Secret    []byte `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
 }
 
-func (m *ReplyLotteryNormalInfo) Reset()                    { *m = ReplyLotteryNormalInfo{} }
-func (m *ReplyLotteryNormalInfo) String() string            { return proto.CompactTextString(m) }
-func (*ReplyLotteryNormalInfo) ProtoMessage()               {}
-func (*ReplyLotteryNormalInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (m *LotteryReveal) Reset()                    { *m = LotteryReveal{} }
+func (m *LotteryReveal) String() string            { return proto.CompactTextString(m) }
+func (*LotteryReveal) ProtoMessage()               {}
+func (*LotteryReveal) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-func (m *ReplyLotteryNormalInfo) GetCreateHeight() int64 {
+func (m *LotteryReveal) GetLotteryId() string {
 	if m != nil {
-		return m.CreateHeight
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryNormalInfo) GetPurBlockNum() int64 {
+func (m *LotteryReveal) GetSecret() []byte {
 	if m != nil {
-		return m.PurBlockNum
+		return m.Secret
 	}
-	return 0
+	return nil
 }
 
-func (m *ReplyLotteryNormalInfo) GetDrawBlockNum() int64 {
+type LotteryRevealNumber struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Number    int64  `protobuf:"varint,2,opt,name=number" json:"number,omitempty"`
+	Nonce     []byte `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (m *LotteryRevealNumber) Reset()                    { *m = LotteryRevealNumber{} }
+func (m *LotteryRevealNumber) String() string            { return proto.CompactTextString(m) }
+func (*LotteryRevealNumber) ProtoMessage()               {}
+func (*LotteryRevealNumber) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *LotteryRevealNumber) GetLotteryId() string {
 	if m != nil {
-		return m.DrawBlockNum
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryRevealNumber) GetNumber() int64 {
+	if m != nil {
+		return m.Number
 	}
 	return 0
 }
 
-func (m *ReplyLotteryNormalInfo) GetCreateAddr() string {
+func (m *LotteryRevealNumber) GetNonce() []byte {
 	if m != nil {
-		return m.CreateAddr
+		return m.Nonce
 	}
-	return ""
+	return nil
 }
 
-type ReplyLotteryCurrentInfo struct {
-	Status                     int32            `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
-	Fund                       int64            `protobuf:"varint,2,opt,name=fund" json:"fund,omitempty"`
-	LastTransToPurState        int64            `protobuf:"varint,3,opt,name=lastTransToPurState" json:"lastTransToPurState,omitempty"`
-	LastTransToDrawState       int64            `protobuf:"varint,4,opt,name=lastTransToDrawState" json:"lastTransToDrawState,omitempty"`
-	TotalPurchasedTxNum        int64            `protobuf:"varint,5,opt,name=totalPurchasedTxNum" json:"totalPurchasedTxNum,omitempty"`
-	Round                      int64            `protobuf:"varint,6,opt,name=round" json:"round,omitempty"`
-	LuckyNumber                int64            `protobuf:"varint,7,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
-	LastTransToPurStateOnMain  int64            `protobuf:"varint,8,opt,name=lastTransToPurStateOnMain" json:"lastTransToPurStateOnMain,omitempty"`
-	LastTransToDrawStateOnMain int64            `protobuf:"varint,9,opt,name=lastTransToDrawStateOnMain" json:"lastTransToDrawStateOnMain,omitempty"`
-	PurBlockNum                int64            `protobuf:"varint,10,opt,name=purBlockNum" json:"purBlockNum,omitempty"`
-	DrawBlockNum               int64            `protobuf:"varint,11,opt,name=drawBlockNum" json:"drawBlockNum,omitempty"`
-	MissingRecords             []*MissingRecord `protobuf:"bytes,12,rep,name=missingRecords" json:"missingRecords,omitempty"`
+// 创建者修改可以开奖的地址, 只能在一轮购买开始之前修改
+type LotteryModify struct {
+	LotteryId     string   `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	AddDrawers    []string `protobuf:"bytes,2,rep,name=addDrawers" json:"addDrawers,omitempty"`
+	RemoveDrawers []string `protobuf:"bytes,3,rep,name=removeDrawers" json:"removeDrawers,omitempty"`
 }
 
-func (m *ReplyLotteryCurrentInfo) Reset()                    { *m = ReplyLotteryCurrentInfo{} }
-func (m *ReplyLotteryCurrentInfo) String() string            { return proto.CompactTextString(m) }
-func (*ReplyLotteryCurrentInfo) ProtoMessage()               {}
-func (*ReplyLotteryCurrentInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (m *LotteryModify) Reset()                    { *m = LotteryModify{} }
+func (m *LotteryModify) String() string            { return proto.CompactTextString(m) }
+func (*LotteryModify) ProtoMessage()               {}
+func (*LotteryModify) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
-func (m *ReplyLotteryCurrentInfo) GetStatus() int32 {
+func (m *LotteryModify) GetLotteryId() string {
 	if m != nil {
-		return m.Status
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryCurrentInfo) GetFund() int64 {
+func (m *LotteryModify) GetAddDrawers() []string {
 	if m != nil {
-		return m.Fund
+		return m.AddDrawers
 	}
-	return 0
+	return nil
 }
 
-func (m *ReplyLotteryCurrentInfo) GetLastTransToPurState() int64 {
+func (m *LotteryModify) GetRemoveDrawers() []string {
 	if m != nil {
-		return m.LastTransToPurState
+		return m.RemoveDrawers
 	}
-	return 0
+	return nil
 }
 
-func (m *ReplyLotteryCurrentInfo) GetLastTransToDrawState() int64 {
-	if m != nil {
-		return m.LastTransToDrawState
-	}
-	return 0
+// 管理地址把开奖, 关闭和修改开奖地址的权限移交给另一个地址, 奖池和分成仍然属于创建者
+type LotteryTransfer struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	To        string `protobuf:"bytes,2,opt,name=to" json:"to,omitempty"`
 }
 
-func (m *ReplyLotteryCurrentInfo) GetTotalPurchasedTxNum() int64 {
+func (m *LotteryTransfer) Reset()                    { *m = LotteryTransfer{} }
+func (m *LotteryTransfer) String() string            { return proto.CompactTextString(m) }
+func (*LotteryTransfer) ProtoMessage()               {}
+func (*LotteryTransfer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *LotteryTransfer) GetLotteryId() string {
 	if m != nil {
-		return m.TotalPurchasedTxNum
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryCurrentInfo) GetRound() int64 {
+func (m *LotteryTransfer) GetTo() string {
 	if m != nil {
-		return m.Round
+		return m.To
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryCurrentInfo) GetLuckyNumber() int64 {
+// 管理地址回收关闭之后奖池中剩余的资金, 只能回收一次
+type LotteryReclaim struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+}
+
+func (m *LotteryReclaim) Reset()                    { *m = LotteryReclaim{} }
+func (m *LotteryReclaim) String() string            { return proto.CompactTextString(m) }
+func (*LotteryReclaim) ProtoMessage()               {}
+func (*LotteryReclaim) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *LotteryReclaim) GetLotteryId() string {
 	if m != nil {
-		return m.LuckyNumber
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryCurrentInfo) GetLastTransToPurStateOnMain() int64 {
+// 中奖地址领取一轮锁定的奖金, 开奖之后payoutMaturity 个区块才能领取, 关闭之后也可以领取
+type LotteryClaim struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+}
+
+func (m *LotteryClaim) Reset()                    { *m = LotteryClaim{} }
+func (m *LotteryClaim) String() string            { return proto.CompactTextString(m) }
+func (*LotteryClaim) ProtoMessage()               {}
+func (*LotteryClaim) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *LotteryClaim) GetLotteryId() string {
 	if m != nil {
-		return m.LastTransToPurStateOnMain
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryCurrentInfo) GetLastTransToDrawStateOnMain() int64 {
+func (m *LotteryClaim) GetRound() int64 {
 	if m != nil {
-		return m.LastTransToDrawStateOnMain
+		return m.Round
 	}
 	return 0
 }
 
-func (m *ReplyLotteryCurrentInfo) GetPurBlockNum() int64 {
+// 购买地址把本轮还没有开奖的一条购买记录转给另一个地址, index 是购买记录的序号
+type LotteryTransferTicket struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Index     int64  `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+	To        string `protobuf:"bytes,3,opt,name=to" json:"to,omitempty"`
+}
+
+func (m *LotteryTransferTicket) Reset()                    { *m = LotteryTransferTicket{} }
+func (m *LotteryTransferTicket) String() string            { return proto.CompactTextString(m) }
+func (*LotteryTransferTicket) ProtoMessage()               {}
+func (*LotteryTransferTicket) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *LotteryTransferTicket) GetLotteryId() string {
 	if m != nil {
-		return m.PurBlockNum
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *ReplyLotteryCurrentInfo) GetDrawBlockNum() int64 {
+func (m *LotteryTransferTicket) GetIndex() int64 {
 	if m != nil {
-		return m.DrawBlockNum
+		return m.Index
 	}
 	return 0
 }
 
-func (m *ReplyLotteryCurrentInfo) GetMissingRecords() []*MissingRecord {
+func (m *LotteryTransferTicket) GetTo() string {
 	if m != nil {
-		return m.MissingRecords
+		return m.To
 	}
-	return nil
+	return ""
 }
 
-type ReplyLotteryHistoryLuckyNumber struct {
-	LuckyNumber []int64 `protobuf:"varint,1,rep,packed,name=luckyNumber" json:"luckyNumber,omitempty"`
+// 管理地址修改不能购买的地址, 任何时候都可以修改, 下一笔购买开始生效
+type LotteryBlacklist struct {
+	LotteryId string   `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Add       []string `protobuf:"bytes,2,rep,name=add" json:"add,omitempty"`
+	Remove    []string `protobuf:"bytes,3,rep,name=remove" json:"remove,omitempty"`
 }
 
-func (m *ReplyLotteryHistoryLuckyNumber) Reset()                    { *m = ReplyLotteryHistoryLuckyNumber{} }
-func (m *ReplyLotteryHistoryLuckyNumber) String() string            { return proto.CompactTextString(m) }
-func (*ReplyLotteryHistoryLuckyNumber) ProtoMessage()               {}
-func (*ReplyLotteryHistoryLuckyNumber) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *LotteryBlacklist) Reset()                    { *m = LotteryBlacklist{} }
+func (m *LotteryBlacklist) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBlacklist) ProtoMessage()               {}
+func (*LotteryBlacklist) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
-func (m *ReplyLotteryHistoryLuckyNumber) GetLuckyNumber() []int64 {
+func (m *LotteryBlacklist) GetLotteryId() string {
 	if m != nil {
-		return m.LuckyNumber
+		return m.LotteryId
 	}
-	return nil
+	return ""
 }
 
-type ReplyLotteryShowInfo struct {
-	Records []*LotteryBuyRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+func (m *LotteryBlacklist) GetAdd() []string {
+	if m != nil {
+		return m.Add
+	}
+	return nil
 }
 
-func (m *ReplyLotteryShowInfo) Reset()                    { *m = ReplyLotteryShowInfo{} }
-func (m *ReplyLotteryShowInfo) String() string            { return proto.CompactTextString(m) }
-func (*ReplyLotteryShowInfo) ProtoMessage()               {}
-func (*ReplyLotteryShowInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
-
-func (m *ReplyLotteryShowInfo) GetRecords() []*LotteryBuyRecord {
+func (m *LotteryBlacklist) GetRemove() []string {
 	if m != nil {
-		return m.Records
+		return m.Remove
 	}
 	return nil
 }
 
-type LotteryNumberRecord struct {
-	Number int64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
-	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+type ReceiptLottery struct {
+	LotteryId    string                `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Status       int32                 `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
+	PrevStatus   int32                 `protobuf:"varint,3,opt,name=prevStatus" json:"prevStatus,omitempty"`
+	Addr         string                `protobuf:"bytes,4,opt,name=addr" json:"addr,omitempty"`
+	Round        int64                 `protobuf:"varint,5,opt,name=round" json:"round,omitempty"`
+	Number       int64                 `protobuf:"varint,6,opt,name=number" json:"number,omitempty"`
+	Amount       int64                 `protobuf:"varint,7,opt,name=amount" json:"amount,omitempty"`
+	LuckyNumber  int64                 `protobuf:"varint,8,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
+	Time         int64                 `protobuf:"varint,9,opt,name=time" json:"time,omitempty"`
+	TxHash       string                `protobuf:"bytes,10,opt,name=txHash" json:"txHash,omitempty"`
+	UpdateInfo   *LotteryUpdateBuyInfo `protobuf:"bytes,11,opt,name=updateInfo" json:"updateInfo,omitempty"`
+	Way          int64                 `protobuf:"varint,12,opt,name=way" json:"way,omitempty"`
+	Index        int64                 `protobuf:"varint,13,opt,name=index" json:"index,omitempty"`
+	BuyItems     []*LotteryBuyItem     `protobuf:"bytes,14,rep,name=buyItems" json:"buyItems,omitempty"`
+	Rollover     int64                 `protobuf:"varint,15,opt,name=rollover" json:"rollover,omitempty"`
+	CommitHash   []byte                `protobuf:"bytes,16,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	CreateHeight int64                 `protobuf:"varint,17,opt,name=createHeight" json:"createHeight,omitempty"`
+	DrawProof    *LotteryDrawProof     `protobuf:"bytes,18,opt,name=drawProof" json:"drawProof,omitempty"`
+	Height       int64                 `protobuf:"varint,19,opt,name=height" json:"height,omitempty"`
+	AgentAddr    string                `protobuf:"bytes,20,opt,name=agentAddr" json:"agentAddr,omitempty"`
+	Commission   int64                 `protobuf:"varint,21,opt,name=commission" json:"commission,omitempty"`
 }
 
-func (m *LotteryNumberRecord) Reset()                    { *m = LotteryNumberRecord{} }
-func (m *LotteryNumberRecord) String() string            { return proto.CompactTextString(m) }
-func (*LotteryNumberRecord) ProtoMessage()               {}
-func (*LotteryNumberRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (m *ReceiptLottery) Reset()                    { *m = ReceiptLottery{} }
+func (m *ReceiptLottery) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLottery) ProtoMessage()               {}
+func (*ReceiptLottery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
-func (m *LotteryNumberRecord) GetNumber() int64 {
+func (m *ReceiptLottery) GetLotteryId() string {
 	if m != nil {
-		return m.Number
+		return m.LotteryId
 	}
-	return 0
+	return ""
 }
 
-func (m *LotteryNumberRecord) GetAmount() int64 {
+func (m *ReceiptLottery) GetStatus() int32 {
 	if m != nil {
-		return m.Amount
+		return m.Status
 	}
 	return 0
 }
 
-// used for execlocal
-type LotteryBuyRecord struct {
-	Number int64  `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
-	Amount int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
-	Round  int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
-	Type   int64  `protobuf:"varint,4,opt,name=type" json:"type,omitempty"`
-	Way    int64  `protobuf:"varint,5,opt,name=way" json:"way,omitempty"`
-	Index  int64  `protobuf:"varint,6,opt,name=index" json:"index,omitempty"`
-	Time   int64  `protobuf:"varint,7,opt,name=time" json:"time,omitempty"`
-	TxHash string `protobuf:"bytes,8,opt,name=txHash" json:"txHash,omitempty"`
-}
-
-func (m *LotteryBuyRecord) Reset()                    { *m = LotteryBuyRecord{} }
-func (m *LotteryBuyRecord) String() string            { return proto.CompactTextString(m) }
-func (*LotteryBuyRecord) ProtoMessage()               {}
-func (*LotteryBuyRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
-
-func (m *LotteryBuyRecord) GetNumber() int64 {
+func (m *ReceiptLottery) GetPrevStatus() int32 {
 	if m != nil {
-		return m.Number
+		return m.PrevStatus
 	}
 	return 0
 }
 
-func (m *LotteryBuyRecord) GetAmount() int64 {
+func (m *ReceiptLottery) GetAddr() string {
 	if m != nil {
-		return m.Amount
+		return m.Addr
 	}
-	return 0
+	return ""
 }
 
-func (m *LotteryBuyRecord) GetRound() int64 {
+func (m *ReceiptLottery) GetRound() int64 {
 	if m != nil {
 		return m.Round
 	}
 	return 0
 }
 
-func (m *LotteryBuyRecord) GetType() int64 {
+func (m *ReceiptLottery) GetNumber() int64 {
 	if m != nil {
-		return m.Type
+		return m.Number
 	}
 	return 0
 }
 
-func (m *LotteryBuyRecord) GetWay() int64 {
+func (m *ReceiptLottery) GetAmount() int64 {
 	if m != nil {
-		return m.Way
+		return m.Amount
 	}
 	return 0
 }
 
-func (m *LotteryBuyRecord) GetIndex() int64 {
+func (m *ReceiptLottery) GetLuckyNumber() int64 {
 	if m != nil {
-		return m.Index
+		return m.LuckyNumber
 	}
 	return 0
 }
 
-func (m *LotteryBuyRecord) GetTime() int64 {
+func (m *ReceiptLottery) GetTime() int64 {
 	if m != nil {
 		return m.Time
 	}
 	return 0
 }
 
-func (m *LotteryBuyRecord) GetTxHash() string {
+func (m *ReceiptLottery) GetTxHash() string {
 	if m != nil {
 		return m.TxHash
 	}
 	return ""
 }
 
-type LotteryBuyRecords struct {
-	Records []*LotteryBuyRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
-}
-
-func (m *LotteryBuyRecords) Reset()                    { *m = LotteryBuyRecords{} }
-func (m *LotteryBuyRecords) String() string            { return proto.CompactTextString(m) }
-func (*LotteryBuyRecords) ProtoMessage()               {}
-func (*LotteryBuyRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
-
-func (m *LotteryBuyRecords) GetRecords() []*LotteryBuyRecord {
+func (m *ReceiptLottery) GetUpdateInfo() *LotteryUpdateBuyInfo {
 	if m != nil {
-		return m.Records
+		return m.UpdateInfo
 	}
 	return nil
 }
 
-type LotteryDrawRecord struct {
-	Number int64  `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
-	Round  int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
-	Time   int64  `protobuf:"varint,3,opt,name=time" json:"time,omitempty"`
-	TxHash string `protobuf:"bytes,4,opt,name=txHash" json:"txHash,omitempty"`
+func (m *ReceiptLottery) GetWay() int64 {
+	if m != nil {
+		return m.Way
+	}
+	return 0
 }
 
-func (m *LotteryDrawRecord) Reset()                    { *m = LotteryDrawRecord{} }
-func (m *LotteryDrawRecord) String() string            { return proto.CompactTextString(m) }
-func (*LotteryDrawRecord) ProtoMessage()               {}
-func (*LotteryDrawRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
-
-func (m *LotteryDrawRecord) GetNumber() int64 {
+func (m *ReceiptLottery) GetIndex() int64 {
 	if m != nil {
-		return m.Number
+		return m.Index
 	}
 	return 0
 }
 
-func (m *LotteryDrawRecord) GetRound() int64 {
+func (m *ReceiptLottery) GetBuyItems() []*LotteryBuyItem {
 	if m != nil {
-		return m.Round
+		return m.BuyItems
 	}
-	return 0
+	return nil
 }
 
-func (m *LotteryDrawRecord) GetTime() int64 {
+func (m *ReceiptLottery) GetRollover() int64 {
 	if m != nil {
-		return m.Time
+		return m.Rollover
 	}
 	return 0
 }
 
-func (m *LotteryDrawRecord) GetTxHash() string {
+func (m *ReceiptLottery) GetCommitHash() []byte {
 	if m != nil {
-		return m.TxHash
+		return m.CommitHash
+	}
+	return nil
+}
+
+func (m *ReceiptLottery) GetCreateHeight() int64 {
+	if m != nil {
+		return m.CreateHeight
+	}
+	return 0
+}
+
+func (m *ReceiptLottery) GetDrawProof() *LotteryDrawProof {
+	if m != nil {
+		return m.DrawProof
+	}
+	return nil
+}
+
+func (m *ReceiptLottery) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiptLottery) GetAgentAddr() string {
+	if m != nil {
+		return m.AgentAddr
+	}
+	return ""
+}
+
+func (m *ReceiptLottery) GetCommission() int64 {
+	if m != nil {
+		return m.Commission
+	}
+	return 0
+}
+
+type ReceiptLotteryCreatorFee struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr      string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Fee       int64  `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
+}
+
+func (m *ReceiptLotteryCreatorFee) Reset()                    { *m = ReceiptLotteryCreatorFee{} }
+func (m *ReceiptLotteryCreatorFee) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryCreatorFee) ProtoMessage()               {}
+func (*ReceiptLotteryCreatorFee) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ReceiptLotteryCreatorFee) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryCreatorFee) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryCreatorFee) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryCreatorFee) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+type ReceiptLotteryDrawReward struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr      string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Reward    int64  `protobuf:"varint,4,opt,name=reward" json:"reward,omitempty"`
+}
+
+func (m *ReceiptLotteryDrawReward) Reset()                    { *m = ReceiptLotteryDrawReward{} }
+func (m *ReceiptLotteryDrawReward) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryDrawReward) ProtoMessage()               {}
+func (*ReceiptLotteryDrawReward) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ReceiptLotteryDrawReward) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryDrawReward) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryDrawReward) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryDrawReward) GetReward() int64 {
+	if m != nil {
+		return m.Reward
+	}
+	return 0
+}
+
+type ReceiptLotteryModify struct {
+	LotteryId     string   `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round         int64    `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr          string   `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	AddDrawers    []string `protobuf:"bytes,4,rep,name=addDrawers" json:"addDrawers,omitempty"`
+	RemoveDrawers []string `protobuf:"bytes,5,rep,name=removeDrawers" json:"removeDrawers,omitempty"`
+	// 修改之后的全部开奖地址
+	Drawers []string `protobuf:"bytes,6,rep,name=drawers" json:"drawers,omitempty"`
+	Time    int64    `protobuf:"varint,7,opt,name=time" json:"time,omitempty"`
+	TxHash  string   `protobuf:"bytes,8,opt,name=txHash" json:"txHash,omitempty"`
+	Index   int64    `protobuf:"varint,9,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *ReceiptLotteryModify) Reset()                    { *m = ReceiptLotteryModify{} }
+func (m *ReceiptLotteryModify) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryModify) ProtoMessage()               {}
+func (*ReceiptLotteryModify) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ReceiptLotteryModify) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryModify) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryModify) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryModify) GetAddDrawers() []string {
+	if m != nil {
+		return m.AddDrawers
+	}
+	return nil
+}
+
+func (m *ReceiptLotteryModify) GetRemoveDrawers() []string {
+	if m != nil {
+		return m.RemoveDrawers
+	}
+	return nil
+}
+
+func (m *ReceiptLotteryModify) GetDrawers() []string {
+	if m != nil {
+		return m.Drawers
+	}
+	return nil
+}
+
+func (m *ReceiptLotteryModify) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryModify) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryModify) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type ReplyLotteryModifyRecords struct {
+	Records []*ReceiptLotteryModify `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ReplyLotteryModifyRecords) Reset()                    { *m = ReplyLotteryModifyRecords{} }
+func (m *ReplyLotteryModifyRecords) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryModifyRecords) ProtoMessage()               {}
+func (*ReplyLotteryModifyRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ReplyLotteryModifyRecords) GetRecords() []*ReceiptLotteryModify {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type ReceiptLotteryTransfer struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Status    int32  `protobuf:"varint,3,opt,name=status" json:"status,omitempty"`
+	PrevAdmin string `protobuf:"bytes,4,opt,name=prevAdmin" json:"prevAdmin,omitempty"`
+	Admin     string `protobuf:"bytes,5,opt,name=admin" json:"admin,omitempty"`
+	Time      int64  `protobuf:"varint,6,opt,name=time" json:"time,omitempty"`
+	TxHash    string `protobuf:"bytes,7,opt,name=txHash" json:"txHash,omitempty"`
+	Index     int64  `protobuf:"varint,8,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *ReceiptLotteryTransfer) Reset()                    { *m = ReceiptLotteryTransfer{} }
+func (m *ReceiptLotteryTransfer) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryTransfer) ProtoMessage()               {}
+func (*ReceiptLotteryTransfer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ReceiptLotteryTransfer) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTransfer) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTransfer) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTransfer) GetPrevAdmin() string {
+	if m != nil {
+		return m.PrevAdmin
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTransfer) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTransfer) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTransfer) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTransfer) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type ReceiptLotteryTicketTransfer struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Index     int64  `protobuf:"varint,3,opt,name=index" json:"index,omitempty"`
+	From      string `protobuf:"bytes,4,opt,name=from" json:"from,omitempty"`
+	To        string `protobuf:"bytes,5,opt,name=to" json:"to,omitempty"`
+	Number    int64  `protobuf:"varint,6,opt,name=number" json:"number,omitempty"`
+	Amount    int64  `protobuf:"varint,7,opt,name=amount" json:"amount,omitempty"`
+	Time      int64  `protobuf:"varint,8,opt,name=time" json:"time,omitempty"`
+	TxHash    string `protobuf:"bytes,9,opt,name=txHash" json:"txHash,omitempty"`
+}
+
+func (m *ReceiptLotteryTicketTransfer) Reset()                    { *m = ReceiptLotteryTicketTransfer{} }
+func (m *ReceiptLotteryTicketTransfer) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryTicketTransfer) ProtoMessage()               {}
+func (*ReceiptLotteryTicketTransfer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ReceiptLotteryTicketTransfer) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryTicketTransfer) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+type ReceiptLotteryReclaim struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr      string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Amount    int64  `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	Time      int64  `protobuf:"varint,5,opt,name=time" json:"time,omitempty"`
+	TxHash    string `protobuf:"bytes,6,opt,name=txHash" json:"txHash,omitempty"`
+	Index     int64  `protobuf:"varint,7,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *ReceiptLotteryReclaim) Reset()                    { *m = ReceiptLotteryReclaim{} }
+func (m *ReceiptLotteryReclaim) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryReclaim) ProtoMessage()               {}
+func (*ReceiptLotteryReclaim) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *ReceiptLotteryReclaim) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryReclaim) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryReclaim) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryReclaim) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryReclaim) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryReclaim) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryReclaim) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// 开奖时锁定和领取奖金的回执, 金额为账户中的金额
+type ReceiptLotteryPayout struct {
+	LotteryId       string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round           int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr            string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Amount          int64  `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	ClaimableHeight int64  `protobuf:"varint,5,opt,name=claimableHeight" json:"claimableHeight,omitempty"`
+	Time            int64  `protobuf:"varint,6,opt,name=time" json:"time,omitempty"`
+	TxHash          string `protobuf:"bytes,7,opt,name=txHash" json:"txHash,omitempty"`
+	Index           int64  `protobuf:"varint,8,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *ReceiptLotteryPayout) Reset()                    { *m = ReceiptLotteryPayout{} }
+func (m *ReceiptLotteryPayout) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryPayout) ProtoMessage()               {}
+func (*ReceiptLotteryPayout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *ReceiptLotteryPayout) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryPayout) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryPayout) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryPayout) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryPayout) GetClaimableHeight() int64 {
+	if m != nil {
+		return m.ClaimableHeight
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryPayout) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryPayout) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryPayout) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// 中奖地址在一轮中锁定的奖金, 状态数据库中用来检查领取, localdb 中用来查询
+type LotteryPayout struct {
+	LotteryId       string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round           int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr            string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Amount          int64  `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	ClaimableHeight int64  `protobuf:"varint,5,opt,name=claimableHeight" json:"claimableHeight,omitempty"`
+	Claimed         bool   `protobuf:"varint,6,opt,name=claimed" json:"claimed,omitempty"`
+	ClaimTxHash     string `protobuf:"bytes,7,opt,name=claimTxHash" json:"claimTxHash,omitempty"`
+}
+
+func (m *LotteryPayout) Reset()                    { *m = LotteryPayout{} }
+func (m *LotteryPayout) String() string            { return proto.CompactTextString(m) }
+func (*LotteryPayout) ProtoMessage()               {}
+func (*LotteryPayout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *LotteryPayout) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryPayout) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryPayout) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryPayout) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *LotteryPayout) GetClaimableHeight() int64 {
+	if m != nil {
+		return m.ClaimableHeight
+	}
+	return 0
+}
+
+func (m *LotteryPayout) GetClaimed() bool {
+	if m != nil {
+		return m.Claimed
+	}
+	return false
+}
+
+func (m *LotteryPayout) GetClaimTxHash() string {
+	if m != nil {
+		return m.ClaimTxHash
+	}
+	return ""
+}
+
+type ReqLotteryPayouts struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+}
+
+func (m *ReqLotteryPayouts) Reset()                    { *m = ReqLotteryPayouts{} }
+func (m *ReqLotteryPayouts) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryPayouts) ProtoMessage()               {}
+func (*ReqLotteryPayouts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ReqLotteryPayouts) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryPayouts) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+// 按轮次排序, 包括已经领取的
+type ReplyLotteryPayouts struct {
+	Payouts     []*LotteryPayout `protobuf:"bytes,1,rep,name=payouts" json:"payouts,omitempty"`
+	TokenSymbol string           `protobuf:"bytes,2,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryPayouts) Reset()                    { *m = ReplyLotteryPayouts{} }
+func (m *ReplyLotteryPayouts) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryPayouts) ProtoMessage()               {}
+func (*ReplyLotteryPayouts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ReplyLotteryPayouts) GetPayouts() []*LotteryPayout {
+	if m != nil {
+		return m.Payouts
+	}
+	return nil
+}
+
+func (m *ReplyLotteryPayouts) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type ReceiptLotteryBlacklist struct {
+	LotteryId string   `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64    `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr      string   `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Add       []string `protobuf:"bytes,4,rep,name=add" json:"add,omitempty"`
+	Remove    []string `protobuf:"bytes,5,rep,name=remove" json:"remove,omitempty"`
+	Time      int64    `protobuf:"varint,6,opt,name=time" json:"time,omitempty"`
+	TxHash    string   `protobuf:"bytes,7,opt,name=txHash" json:"txHash,omitempty"`
+	Index     int64    `protobuf:"varint,8,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *ReceiptLotteryBlacklist) Reset()                    { *m = ReceiptLotteryBlacklist{} }
+func (m *ReceiptLotteryBlacklist) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryBlacklist) ProtoMessage()               {}
+func (*ReceiptLotteryBlacklist) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ReceiptLotteryBlacklist) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryBlacklist) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryBlacklist) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryBlacklist) GetAdd() []string {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+func (m *ReceiptLotteryBlacklist) GetRemove() []string {
+	if m != nil {
+		return m.Remove
+	}
+	return nil
+}
+
+func (m *ReceiptLotteryBlacklist) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryBlacklist) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryBlacklist) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type ReplyLotteryTransferRecords struct {
+	Records []*ReceiptLotteryTransfer `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ReplyLotteryTransferRecords) Reset()                    { *m = ReplyLotteryTransferRecords{} }
+func (m *ReplyLotteryTransferRecords) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryTransferRecords) ProtoMessage()               {}
+func (*ReplyLotteryTransferRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ReplyLotteryTransferRecords) GetRecords() []*ReceiptLotteryTransfer {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// 配置修改记录中的一项, 列表类的配置只记录增减的部分, 单个值的配置remove 为旧值, add 为新值
+type LotteryConfigField struct {
+	Name   string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Remove []string `protobuf:"bytes,2,rep,name=remove" json:"remove,omitempty"`
+	Add    []string `protobuf:"bytes,3,rep,name=add" json:"add,omitempty"`
+}
+
+func (m *LotteryConfigField) Reset()                    { *m = LotteryConfigField{} }
+func (m *LotteryConfigField) String() string            { return proto.CompactTextString(m) }
+func (*LotteryConfigField) ProtoMessage()               {}
+func (*LotteryConfigField) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *LotteryConfigField) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LotteryConfigField) GetRemove() []string {
+	if m != nil {
+		return m.Remove
+	}
+	return nil
+}
+
+func (m *LotteryConfigField) GetAdd() []string {
+	if m != nil {
+		return m.Add
+	}
+	return nil
+}
+
+// 彩票可变配置的一次修改, 由交易的回执生成, 只追加, 回滚时删除
+type LotteryConfigChange struct {
+	LotteryId string                `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Height    int64                 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	Index     int64                 `protobuf:"varint,3,opt,name=index" json:"index,omitempty"`
+	Round     int64                 `protobuf:"varint,4,opt,name=round" json:"round,omitempty"`
+	Addr      string                `protobuf:"bytes,5,opt,name=addr" json:"addr,omitempty"`
+	ActionTy  int32                 `protobuf:"varint,6,opt,name=actionTy" json:"actionTy,omitempty"`
+	Fields    []*LotteryConfigField `protobuf:"bytes,7,rep,name=fields" json:"fields,omitempty"`
+	Time      int64                 `protobuf:"varint,8,opt,name=time" json:"time,omitempty"`
+	TxHash    string                `protobuf:"bytes,9,opt,name=txHash" json:"txHash,omitempty"`
+}
+
+func (m *LotteryConfigChange) Reset()                    { *m = LotteryConfigChange{} }
+func (m *LotteryConfigChange) String() string            { return proto.CompactTextString(m) }
+func (*LotteryConfigChange) ProtoMessage()               {}
+func (*LotteryConfigChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *LotteryConfigChange) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryConfigChange) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *LotteryConfigChange) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *LotteryConfigChange) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryConfigChange) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryConfigChange) GetActionTy() int32 {
+	if m != nil {
+		return m.ActionTy
+	}
+	return 0
+}
+
+func (m *LotteryConfigChange) GetFields() []*LotteryConfigField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *LotteryConfigChange) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LotteryConfigChange) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+type ReplyLotteryConfigHistory struct {
+	Records []*LotteryConfigChange `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ReplyLotteryConfigHistory) Reset()                    { *m = ReplyLotteryConfigHistory{} }
+func (m *ReplyLotteryConfigHistory) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryConfigHistory) ProtoMessage()               {}
+func (*ReplyLotteryConfigHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ReplyLotteryConfigHistory) GetRecords() []*LotteryConfigChange {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type ReceiptLotteryRefund struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Addr      string `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
+	Amount    int64  `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	Time      int64  `protobuf:"varint,5,opt,name=time" json:"time,omitempty"`
+	TxHash    string `protobuf:"bytes,6,opt,name=txHash" json:"txHash,omitempty"`
+	Rollover  int64  `protobuf:"varint,7,opt,name=rollover" json:"rollover,omitempty"`
+}
+
+func (m *ReceiptLotteryRefund) Reset()                    { *m = ReceiptLotteryRefund{} }
+func (m *ReceiptLotteryRefund) String() string            { return proto.CompactTextString(m) }
+func (*ReceiptLotteryRefund) ProtoMessage()               {}
+func (*ReceiptLotteryRefund) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ReceiptLotteryRefund) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryRefund) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryRefund) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryRefund) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryRefund) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptLotteryRefund) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ReceiptLotteryRefund) GetRollover() int64 {
+	if m != nil {
+		return m.Rollover
+	}
+	return 0
+}
+
+type ReqLotteryInfo struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+}
+
+func (m *ReqLotteryInfo) Reset()                    { *m = ReqLotteryInfo{} }
+func (m *ReqLotteryInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryInfo) ProtoMessage()               {}
+func (*ReqLotteryInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ReqLotteryInfo) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+type ReqLotteryBuyInfo struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	Round     int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+}
+
+func (m *ReqLotteryBuyInfo) Reset()                    { *m = ReqLotteryBuyInfo{} }
+func (m *ReqLotteryBuyInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryBuyInfo) ProtoMessage()               {}
+func (*ReqLotteryBuyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ReqLotteryBuyInfo) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyInfo) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyInfo) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+type ReqLotteryBuyHistory struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	Round     int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+	Count     int32  `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+	Direction int32  `protobuf:"varint,5,opt,name=direction" json:"direction,omitempty"`
+	Index     int64  `protobuf:"varint,6,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *ReqLotteryBuyHistory) Reset()                    { *m = ReqLotteryBuyHistory{} }
+func (m *ReqLotteryBuyHistory) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryBuyHistory) ProtoMessage()               {}
+func (*ReqLotteryBuyHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ReqLotteryBuyHistory) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyHistory) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyHistory) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReqLotteryBuyHistory) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqLotteryBuyHistory) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+func (m *ReqLotteryBuyHistory) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type ReqLotteryLuckyInfo struct {
+	LotteryId string  `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     []int64 `protobuf:"varint,2,rep,packed,name=round" json:"round,omitempty"`
+}
+
+func (m *ReqLotteryLuckyInfo) Reset()                    { *m = ReqLotteryLuckyInfo{} }
+func (m *ReqLotteryLuckyInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryLuckyInfo) ProtoMessage()               {}
+func (*ReqLotteryLuckyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ReqLotteryLuckyInfo) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryLuckyInfo) GetRound() []int64 {
+	if m != nil {
+		return m.Round
+	}
+	return nil
+}
+
+type ReqLotteryLuckyHistory struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Count     int32  `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
+	Direction int32  `protobuf:"varint,4,opt,name=direction" json:"direction,omitempty"`
+}
+
+func (m *ReqLotteryLuckyHistory) Reset()                    { *m = ReqLotteryLuckyHistory{} }
+func (m *ReqLotteryLuckyHistory) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryLuckyHistory) ProtoMessage()               {}
+func (*ReqLotteryLuckyHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ReqLotteryLuckyHistory) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryLuckyHistory) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReqLotteryLuckyHistory) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqLotteryLuckyHistory) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+type ReplyLotteryNormalInfo struct {
+	CreateHeight   int64    `protobuf:"varint,1,opt,name=createHeight" json:"createHeight,omitempty"`
+	PurBlockNum    int64    `protobuf:"varint,2,opt,name=purBlockNum" json:"purBlockNum,omitempty"`
+	DrawBlockNum   int64    `protobuf:"varint,3,opt,name=drawBlockNum" json:"drawBlockNum,omitempty"`
+	CreateAddr     string   `protobuf:"bytes,4,opt,name=createAddr" json:"createAddr,omitempty"`
+	TokenSymbol    string   `protobuf:"bytes,5,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+	MinPurchaseNum int64    `protobuf:"varint,6,opt,name=minPurchaseNum" json:"minPurchaseNum,omitempty"`
+	MinSalesAmount int64    `protobuf:"varint,7,opt,name=minSalesAmount" json:"minSalesAmount,omitempty"`
+	MaxWaitBlocks  int64    `protobuf:"varint,8,opt,name=maxWaitBlocks" json:"maxWaitBlocks,omitempty"`
+	RefundBelowMin bool     `protobuf:"varint,9,opt,name=refundBelowMin" json:"refundBelowMin,omitempty"`
+	Drawers        []string `protobuf:"bytes,10,rep,name=drawers" json:"drawers,omitempty"`
+	Admin          string   `protobuf:"bytes,11,opt,name=admin" json:"admin,omitempty"`
+	Blacklist      []string `protobuf:"bytes,12,rep,name=blacklist" json:"blacklist,omitempty"`
+	AssetExec      string   `protobuf:"bytes,13,opt,name=assetExec" json:"assetExec,omitempty"`
+}
+
+func (m *ReplyLotteryNormalInfo) Reset()                    { *m = ReplyLotteryNormalInfo{} }
+func (m *ReplyLotteryNormalInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryNormalInfo) ProtoMessage()               {}
+func (*ReplyLotteryNormalInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ReplyLotteryNormalInfo) GetCreateHeight() int64 {
+	if m != nil {
+		return m.CreateHeight
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNormalInfo) GetPurBlockNum() int64 {
+	if m != nil {
+		return m.PurBlockNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNormalInfo) GetDrawBlockNum() int64 {
+	if m != nil {
+		return m.DrawBlockNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNormalInfo) GetCreateAddr() string {
+	if m != nil {
+		return m.CreateAddr
+	}
+	return ""
+}
+
+func (m *ReplyLotteryNormalInfo) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *ReplyLotteryNormalInfo) GetMinPurchaseNum() int64 {
+	if m != nil {
+		return m.MinPurchaseNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNormalInfo) GetMinSalesAmount() int64 {
+	if m != nil {
+		return m.MinSalesAmount
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNormalInfo) GetMaxWaitBlocks() int64 {
+	if m != nil {
+		return m.MaxWaitBlocks
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNormalInfo) GetRefundBelowMin() bool {
+	if m != nil {
+		return m.RefundBelowMin
+	}
+	return false
+}
+
+func (m *ReplyLotteryNormalInfo) GetDrawers() []string {
+	if m != nil {
+		return m.Drawers
+	}
+	return nil
+}
+
+func (m *ReplyLotteryNormalInfo) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+func (m *ReplyLotteryNormalInfo) GetBlacklist() []string {
+	if m != nil {
+		return m.Blacklist
+	}
+	return nil
+}
+
+func (m *ReplyLotteryNormalInfo) GetAssetExec() string {
+	if m != nil {
+		return m.AssetExec
+	}
+	return ""
+}
+
+type ReplyLotteryCurrentInfo struct {
+	Status                     int32            `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
+	Fund                       int64            `protobuf:"varint,2,opt,name=fund" json:"fund,omitempty"`
+	LastTransToPurState        int64            `protobuf:"varint,3,opt,name=lastTransToPurState" json:"lastTransToPurState,omitempty"`
+	LastTransToDrawState       int64            `protobuf:"varint,4,opt,name=lastTransToDrawState" json:"lastTransToDrawState,omitempty"`
+	TotalPurchasedTxNum        int64            `protobuf:"varint,5,opt,name=totalPurchasedTxNum" json:"totalPurchasedTxNum,omitempty"`
+	Round                      int64            `protobuf:"varint,6,opt,name=round" json:"round,omitempty"`
+	LuckyNumber                int64            `protobuf:"varint,7,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
+	LastTransToPurStateOnMain  int64            `protobuf:"varint,8,opt,name=lastTransToPurStateOnMain" json:"lastTransToPurStateOnMain,omitempty"`
+	LastTransToDrawStateOnMain int64            `protobuf:"varint,9,opt,name=lastTransToDrawStateOnMain" json:"lastTransToDrawStateOnMain,omitempty"`
+	PurBlockNum                int64            `protobuf:"varint,10,opt,name=purBlockNum" json:"purBlockNum,omitempty"`
+	DrawBlockNum               int64            `protobuf:"varint,11,opt,name=drawBlockNum" json:"drawBlockNum,omitempty"`
+	MissingRecords             []*MissingRecord `protobuf:"bytes,12,rep,name=missingRecords" json:"missingRecords,omitempty"`
+	TokenSymbol                string           `protobuf:"bytes,13,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryCurrentInfo) Reset()                    { *m = ReplyLotteryCurrentInfo{} }
+func (m *ReplyLotteryCurrentInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryCurrentInfo) ProtoMessage()               {}
+func (*ReplyLotteryCurrentInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ReplyLotteryCurrentInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetFund() int64 {
+	if m != nil {
+		return m.Fund
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetLastTransToPurState() int64 {
+	if m != nil {
+		return m.LastTransToPurState
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetLastTransToDrawState() int64 {
+	if m != nil {
+		return m.LastTransToDrawState
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetTotalPurchasedTxNum() int64 {
+	if m != nil {
+		return m.TotalPurchasedTxNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetLuckyNumber() int64 {
+	if m != nil {
+		return m.LuckyNumber
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetLastTransToPurStateOnMain() int64 {
+	if m != nil {
+		return m.LastTransToPurStateOnMain
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetLastTransToDrawStateOnMain() int64 {
+	if m != nil {
+		return m.LastTransToDrawStateOnMain
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetPurBlockNum() int64 {
+	if m != nil {
+		return m.PurBlockNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetDrawBlockNum() int64 {
+	if m != nil {
+		return m.DrawBlockNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentInfo) GetMissingRecords() []*MissingRecord {
+	if m != nil {
+		return m.MissingRecords
+	}
+	return nil
+}
+
+func (m *ReplyLotteryCurrentInfo) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type ReplyLotteryHistoryLuckyNumber struct {
+	LuckyNumber []int64 `protobuf:"varint,1,rep,packed,name=luckyNumber" json:"luckyNumber,omitempty"`
+}
+
+func (m *ReplyLotteryHistoryLuckyNumber) Reset()         { *m = ReplyLotteryHistoryLuckyNumber{} }
+func (m *ReplyLotteryHistoryLuckyNumber) String() string { return proto.CompactTextString(m) }
+func (*ReplyLotteryHistoryLuckyNumber) ProtoMessage()    {}
+func (*ReplyLotteryHistoryLuckyNumber) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{44}
+}
+
+func (m *ReplyLotteryHistoryLuckyNumber) GetLuckyNumber() []int64 {
+	if m != nil {
+		return m.LuckyNumber
+	}
+	return nil
+}
+
+type ReplyLotteryShowInfo struct {
+	Records []*LotteryBuyRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *ReplyLotteryShowInfo) Reset()                    { *m = ReplyLotteryShowInfo{} }
+func (m *ReplyLotteryShowInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryShowInfo) ProtoMessage()               {}
+func (*ReplyLotteryShowInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ReplyLotteryShowInfo) GetRecords() []*LotteryBuyRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type LotteryNumberRecord struct {
+	Number int64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *LotteryNumberRecord) Reset()                    { *m = LotteryNumberRecord{} }
+func (m *LotteryNumberRecord) String() string            { return proto.CompactTextString(m) }
+func (*LotteryNumberRecord) ProtoMessage()               {}
+func (*LotteryNumberRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *LotteryNumberRecord) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LotteryNumberRecord) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+// used for execlocal
+type LotteryBuyRecord struct {
+	Number     int64  `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Amount     int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Round      int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+	Type       int64  `protobuf:"varint,4,opt,name=type" json:"type,omitempty"`
+	Way        int64  `protobuf:"varint,5,opt,name=way" json:"way,omitempty"`
+	Index      int64  `protobuf:"varint,6,opt,name=index" json:"index,omitempty"`
+	Time       int64  `protobuf:"varint,7,opt,name=time" json:"time,omitempty"`
+	TxHash     string `protobuf:"bytes,8,opt,name=txHash" json:"txHash,omitempty"`
+	CommitHash []byte `protobuf:"bytes,9,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	Revealed   bool   `protobuf:"varint,10,opt,name=revealed" json:"revealed,omitempty"`
+	// 开奖结果, 未开奖/中奖/未中奖
+	Result int32 `protobuf:"varint,11,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *LotteryBuyRecord) Reset()                    { *m = LotteryBuyRecord{} }
+func (m *LotteryBuyRecord) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBuyRecord) ProtoMessage()               {}
+func (*LotteryBuyRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *LotteryBuyRecord) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetType() int64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetWay() int64 {
+	if m != nil {
+		return m.Way
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LotteryBuyRecord) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *LotteryBuyRecord) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
+func (m *LotteryBuyRecord) GetRevealed() bool {
+	if m != nil {
+		return m.Revealed
+	}
+	return false
+}
+
+func (m *LotteryBuyRecord) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+type LotteryBuyRecords struct {
+	Records     []*LotteryBuyRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+	TokenSymbol string              `protobuf:"bytes,2,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *LotteryBuyRecords) Reset()                    { *m = LotteryBuyRecords{} }
+func (m *LotteryBuyRecords) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBuyRecords) ProtoMessage()               {}
+func (*LotteryBuyRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *LotteryBuyRecords) GetRecords() []*LotteryBuyRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *LotteryBuyRecords) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type LotteryDrawRecord struct {
+	Number int64  `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Round  int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Time   int64  `protobuf:"varint,3,opt,name=time" json:"time,omitempty"`
+	TxHash string `protobuf:"bytes,4,opt,name=txHash" json:"txHash,omitempty"`
+}
+
+func (m *LotteryDrawRecord) Reset()                    { *m = LotteryDrawRecord{} }
+func (m *LotteryDrawRecord) String() string            { return proto.CompactTextString(m) }
+func (*LotteryDrawRecord) ProtoMessage()               {}
+func (*LotteryDrawRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *LotteryDrawRecord) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LotteryDrawRecord) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryDrawRecord) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LotteryDrawRecord) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+type LotteryDrawRecords struct {
+	Records []*LotteryDrawRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *LotteryDrawRecords) Reset()                    { *m = LotteryDrawRecords{} }
+func (m *LotteryDrawRecords) String() string            { return proto.CompactTextString(m) }
+func (*LotteryDrawRecords) ProtoMessage()               {}
+func (*LotteryDrawRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *LotteryDrawRecords) GetRecords() []*LotteryDrawRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type LotteryUpdateRec struct {
+	Index  int64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Type   int64 `protobuf:"varint,2,opt,name=type" json:"type,omitempty"`
+	Amount int64 `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *LotteryUpdateRec) Reset()                    { *m = LotteryUpdateRec{} }
+func (m *LotteryUpdateRec) String() string            { return proto.CompactTextString(m) }
+func (*LotteryUpdateRec) ProtoMessage()               {}
+func (*LotteryUpdateRec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *LotteryUpdateRec) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *LotteryUpdateRec) GetType() int64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *LotteryUpdateRec) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type LotteryUpdateRecs struct {
+	Records []*LotteryUpdateRec `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *LotteryUpdateRecs) Reset()                    { *m = LotteryUpdateRecs{} }
+func (m *LotteryUpdateRecs) String() string            { return proto.CompactTextString(m) }
+func (*LotteryUpdateRecs) ProtoMessage()               {}
+func (*LotteryUpdateRecs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *LotteryUpdateRecs) GetRecords() []*LotteryUpdateRec {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type LotteryUpdateBuyInfo struct {
+	BuyInfo map[string]*LotteryUpdateRecs `protobuf:"bytes,1,rep,name=buyInfo" json:"buyInfo,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *LotteryUpdateBuyInfo) Reset()                    { *m = LotteryUpdateBuyInfo{} }
+func (m *LotteryUpdateBuyInfo) String() string            { return proto.CompactTextString(m) }
+func (*LotteryUpdateBuyInfo) ProtoMessage()               {}
+func (*LotteryUpdateBuyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *LotteryUpdateBuyInfo) GetBuyInfo() map[string]*LotteryUpdateRecs {
+	if m != nil {
+		return m.BuyInfo
+	}
+	return nil
+}
+
+type ReplyLotteryPurchaseAddr struct {
+	Address []string `protobuf:"bytes,1,rep,name=address" json:"address,omitempty"`
+}
+
+func (m *ReplyLotteryPurchaseAddr) Reset()                    { *m = ReplyLotteryPurchaseAddr{} }
+func (m *ReplyLotteryPurchaseAddr) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryPurchaseAddr) ProtoMessage()               {}
+func (*ReplyLotteryPurchaseAddr) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *ReplyLotteryPurchaseAddr) GetAddress() []string {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+type ReqLotteryBuyRecordsByAddr struct {
+	LotteryId  string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr       string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	Round      int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+	Count      int32  `protobuf:"varint,4,opt,name=count" json:"count,omitempty"`
+	Direction  int32  `protobuf:"varint,5,opt,name=direction" json:"direction,omitempty"`
+	PrimaryKey string `protobuf:"bytes,6,opt,name=primaryKey" json:"primaryKey,omitempty"`
+}
+
+func (m *ReqLotteryBuyRecordsByAddr) Reset()                    { *m = ReqLotteryBuyRecordsByAddr{} }
+func (m *ReqLotteryBuyRecordsByAddr) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryBuyRecordsByAddr) ProtoMessage()               {}
+func (*ReqLotteryBuyRecordsByAddr) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *ReqLotteryBuyRecordsByAddr) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyRecordsByAddr) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyRecordsByAddr) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReqLotteryBuyRecordsByAddr) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqLotteryBuyRecordsByAddr) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+func (m *ReqLotteryBuyRecordsByAddr) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+type LotteryBuyEntry struct {
+	Number     int64  `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Amount     int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Way        int64  `protobuf:"varint,3,opt,name=way" json:"way,omitempty"`
+	Round      int64  `protobuf:"varint,4,opt,name=round" json:"round,omitempty"`
+	Index      int64  `protobuf:"varint,5,opt,name=index" json:"index,omitempty"`
+	Time       int64  `protobuf:"varint,6,opt,name=time" json:"time,omitempty"`
+	TxHash     string `protobuf:"bytes,7,opt,name=txHash" json:"txHash,omitempty"`
+	Type       int64  `protobuf:"varint,8,opt,name=type" json:"type,omitempty"`
+	Drawn      bool   `protobuf:"varint,9,opt,name=drawn" json:"drawn,omitempty"`
+	PrimaryKey string `protobuf:"bytes,10,opt,name=primaryKey" json:"primaryKey,omitempty"`
+	CommitHash []byte `protobuf:"bytes,11,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	Revealed   bool   `protobuf:"varint,12,opt,name=revealed" json:"revealed,omitempty"`
+	Result     int32  `protobuf:"varint,13,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *LotteryBuyEntry) Reset()                    { *m = LotteryBuyEntry{} }
+func (m *LotteryBuyEntry) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBuyEntry) ProtoMessage()               {}
+func (*LotteryBuyEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *LotteryBuyEntry) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetWay() int64 {
+	if m != nil {
+		return m.Way
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *LotteryBuyEntry) GetType() int64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *LotteryBuyEntry) GetDrawn() bool {
+	if m != nil {
+		return m.Drawn
+	}
+	return false
+}
+
+func (m *LotteryBuyEntry) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+func (m *LotteryBuyEntry) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
+func (m *LotteryBuyEntry) GetRevealed() bool {
+	if m != nil {
+		return m.Revealed
+	}
+	return false
+}
+
+func (m *LotteryBuyEntry) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+// used for execlocal, 购买交易hash 对应的购买记录
+type LotteryBuyTxIndex struct {
+	Addr    string  `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Round   int64   `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Indexes []int64 `protobuf:"varint,3,rep,packed,name=indexes" json:"indexes,omitempty"`
+}
+
+func (m *LotteryBuyTxIndex) Reset()                    { *m = LotteryBuyTxIndex{} }
+func (m *LotteryBuyTxIndex) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBuyTxIndex) ProtoMessage()               {}
+func (*LotteryBuyTxIndex) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *LotteryBuyTxIndex) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryBuyTxIndex) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryBuyTxIndex) GetIndexes() []int64 {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+type ReqLotteryBuyByTxHash struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	TxHash    string `protobuf:"bytes,2,opt,name=txHash" json:"txHash,omitempty"`
+}
+
+func (m *ReqLotteryBuyByTxHash) Reset()                    { *m = ReqLotteryBuyByTxHash{} }
+func (m *ReqLotteryBuyByTxHash) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryBuyByTxHash) ProtoMessage()               {}
+func (*ReqLotteryBuyByTxHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *ReqLotteryBuyByTxHash) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryBuyByTxHash) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+type ReplyLotteryBuyByTxHash struct {
+	Addr        string             `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Records     []*LotteryBuyEntry `protobuf:"bytes,2,rep,name=records" json:"records,omitempty"`
+	TokenSymbol string             `protobuf:"bytes,3,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryBuyByTxHash) Reset()                    { *m = ReplyLotteryBuyByTxHash{} }
+func (m *ReplyLotteryBuyByTxHash) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryBuyByTxHash) ProtoMessage()               {}
+func (*ReplyLotteryBuyByTxHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *ReplyLotteryBuyByTxHash) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReplyLotteryBuyByTxHash) GetRecords() []*LotteryBuyEntry {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *ReplyLotteryBuyByTxHash) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type ReplyLotteryBuyRecordsByAddr struct {
+	Records     []*LotteryBuyEntry `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+	PrimaryKey  string             `protobuf:"bytes,2,opt,name=primaryKey" json:"primaryKey,omitempty"`
+	TokenSymbol string             `protobuf:"bytes,3,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryBuyRecordsByAddr) Reset()                    { *m = ReplyLotteryBuyRecordsByAddr{} }
+func (m *ReplyLotteryBuyRecordsByAddr) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryBuyRecordsByAddr) ProtoMessage()               {}
+func (*ReplyLotteryBuyRecordsByAddr) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ReplyLotteryBuyRecordsByAddr) GetRecords() []*LotteryBuyEntry {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *ReplyLotteryBuyRecordsByAddr) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+func (m *ReplyLotteryBuyRecordsByAddr) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+// used for execlocal
+type LotteryWinnerRecord struct {
+	Addr   string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Round  int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Index  int64  `protobuf:"varint,3,opt,name=index" json:"index,omitempty"`
+	Level  int64  `protobuf:"varint,4,opt,name=level" json:"level,omitempty"`
+	Amount int64  `protobuf:"varint,5,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *LotteryWinnerRecord) Reset()                    { *m = LotteryWinnerRecord{} }
+func (m *LotteryWinnerRecord) String() string            { return proto.CompactTextString(m) }
+func (*LotteryWinnerRecord) ProtoMessage()               {}
+func (*LotteryWinnerRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *LotteryWinnerRecord) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryWinnerRecord) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryWinnerRecord) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *LotteryWinnerRecord) GetLevel() int64 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *LotteryWinnerRecord) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type ReqLotteryRoundWinners struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+}
+
+func (m *ReqLotteryRoundWinners) Reset()                    { *m = ReqLotteryRoundWinners{} }
+func (m *ReqLotteryRoundWinners) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryRoundWinners) ProtoMessage()               {}
+func (*ReqLotteryRoundWinners) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *ReqLotteryRoundWinners) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryRoundWinners) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+type ReplyLotteryRoundWinners struct {
+	Round       int64                  `protobuf:"varint,1,opt,name=round" json:"round,omitempty"`
+	Records     []*LotteryWinnerRecord `protobuf:"bytes,2,rep,name=records" json:"records,omitempty"`
+	TotalPayout int64                  `protobuf:"varint,3,opt,name=totalPayout" json:"totalPayout,omitempty"`
+	TokenSymbol string                 `protobuf:"bytes,4,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryRoundWinners) Reset()                    { *m = ReplyLotteryRoundWinners{} }
+func (m *ReplyLotteryRoundWinners) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryRoundWinners) ProtoMessage()               {}
+func (*ReplyLotteryRoundWinners) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *ReplyLotteryRoundWinners) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReplyLotteryRoundWinners) GetRecords() []*LotteryWinnerRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *ReplyLotteryRoundWinners) GetTotalPayout() int64 {
+	if m != nil {
+		return m.TotalPayout
+	}
+	return 0
+}
+
+func (m *ReplyLotteryRoundWinners) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+// used for execlocal
+// 每个彩票的累计统计, 在localdb 中随购买, 开奖和退款回执增量更新
+type LotteryStats struct {
+	LotteryId    string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	TicketsSold  int64  `protobuf:"varint,2,opt,name=ticketsSold" json:"ticketsSold,omitempty"`
+	TotalAmount  int64  `protobuf:"varint,3,opt,name=totalAmount" json:"totalAmount,omitempty"`
+	TotalPayout  int64  `protobuf:"varint,4,opt,name=totalPayout" json:"totalPayout,omitempty"`
+	TotalRefund  int64  `protobuf:"varint,5,opt,name=totalRefund" json:"totalRefund,omitempty"`
+	Rounds       int64  `protobuf:"varint,6,opt,name=rounds" json:"rounds,omitempty"`
+	UniqueBuyers int64  `protobuf:"varint,7,opt,name=uniqueBuyers" json:"uniqueBuyers,omitempty"`
+	TokenSymbol  string `protobuf:"bytes,8,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+	TotalReclaim int64  `protobuf:"varint,9,opt,name=totalReclaim" json:"totalReclaim,omitempty"`
+}
+
+func (m *LotteryStats) Reset()                    { *m = LotteryStats{} }
+func (m *LotteryStats) String() string            { return proto.CompactTextString(m) }
+func (*LotteryStats) ProtoMessage()               {}
+func (*LotteryStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *LotteryStats) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryStats) GetTicketsSold() int64 {
+	if m != nil {
+		return m.TicketsSold
+	}
+	return 0
+}
+
+func (m *LotteryStats) GetTotalAmount() int64 {
+	if m != nil {
+		return m.TotalAmount
+	}
+	return 0
+}
+
+func (m *LotteryStats) GetTotalPayout() int64 {
+	if m != nil {
+		return m.TotalPayout
+	}
+	return 0
+}
+
+func (m *LotteryStats) GetTotalRefund() int64 {
+	if m != nil {
+		return m.TotalRefund
+	}
+	return 0
+}
+
+func (m *LotteryStats) GetRounds() int64 {
+	if m != nil {
+		return m.Rounds
+	}
+	return 0
+}
+
+func (m *LotteryStats) GetUniqueBuyers() int64 {
+	if m != nil {
+		return m.UniqueBuyers
+	}
+	return 0
+}
+
+func (m *LotteryStats) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+func (m *LotteryStats) GetTotalReclaim() int64 {
+	if m != nil {
+		return m.TotalReclaim
+	}
+	return 0
+}
+
+// 一轮中选择某个号码的购买, 不区分玩法, 盲选购买在揭示之后计入
+type LotteryNumberHeat struct {
+	Number    int64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Purchases int64 `protobuf:"varint,2,opt,name=purchases" json:"purchases,omitempty"`
+	Amount    int64 `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *LotteryNumberHeat) Reset()                    { *m = LotteryNumberHeat{} }
+func (m *LotteryNumberHeat) String() string            { return proto.CompactTextString(m) }
+func (*LotteryNumberHeat) ProtoMessage()               {}
+func (*LotteryNumberHeat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *LotteryNumberHeat) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LotteryNumberHeat) GetPurchases() int64 {
+	if m != nil {
+		return m.Purchases
+	}
+	return 0
+}
+
+func (m *LotteryNumberHeat) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type ReqLotteryNumberHeat struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+}
+
+func (m *ReqLotteryNumberHeat) Reset()                    { *m = ReqLotteryNumberHeat{} }
+func (m *ReqLotteryNumberHeat) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryNumberHeat) ProtoMessage()               {}
+func (*ReqLotteryNumberHeat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *ReqLotteryNumberHeat) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryNumberHeat) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+// 只包含有人购买的号码, 按号码排序
+type ReplyLotteryNumberHeat struct {
+	LotteryId string               `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64                `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Numbers   []*LotteryNumberHeat `protobuf:"bytes,3,rep,name=numbers" json:"numbers,omitempty"`
+}
+
+func (m *ReplyLotteryNumberHeat) Reset()                    { *m = ReplyLotteryNumberHeat{} }
+func (m *ReplyLotteryNumberHeat) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryNumberHeat) ProtoMessage()               {}
+func (*ReplyLotteryNumberHeat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *ReplyLotteryNumberHeat) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReplyLotteryNumberHeat) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReplyLotteryNumberHeat) GetNumbers() []*LotteryNumberHeat {
+	if m != nil {
+		return m.Numbers
+	}
+	return nil
+}
+
+// 进行中的轮次累计的购买数量, 扣除已经退款的部分, 没有进行中的轮次时为下一轮, 数量为0
+type ReplyLotteryCurrentPool struct {
+	LotteryId   string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round       int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Amount      int64  `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+	TokenSymbol string `protobuf:"bytes,4,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryCurrentPool) Reset()                    { *m = ReplyLotteryCurrentPool{} }
+func (m *ReplyLotteryCurrentPool) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryCurrentPool) ProtoMessage()               {}
+func (*ReplyLotteryCurrentPool) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *ReplyLotteryCurrentPool) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReplyLotteryCurrentPool) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentPool) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReplyLotteryCurrentPool) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+// round 为0时查询所有轮次的累计
+type ReqLotteryAgentSales struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	AgentAddr string `protobuf:"bytes,2,opt,name=agentAddr" json:"agentAddr,omitempty"`
+	Round     int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+}
+
+func (m *ReqLotteryAgentSales) Reset()                    { *m = ReqLotteryAgentSales{} }
+func (m *ReqLotteryAgentSales) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryAgentSales) ProtoMessage()               {}
+func (*ReqLotteryAgentSales) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *ReqLotteryAgentSales) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryAgentSales) GetAgentAddr() string {
+	if m != nil {
+		return m.AgentAddr
+	}
+	return ""
+}
+
+func (m *ReqLotteryAgentSales) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+// 代理的销售数量和佣金, 单位和购买数量相同, 退款不影响已经付出的佣金
+type LotteryAgentSales struct {
+	LotteryId  string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	AgentAddr  string `protobuf:"bytes,2,opt,name=agentAddr" json:"agentAddr,omitempty"`
+	Round      int64  `protobuf:"varint,3,opt,name=round" json:"round,omitempty"`
+	Amount     int64  `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	Commission int64  `protobuf:"varint,5,opt,name=commission" json:"commission,omitempty"`
+	TxNum      int64  `protobuf:"varint,6,opt,name=txNum" json:"txNum,omitempty"`
+}
+
+func (m *LotteryAgentSales) Reset()                    { *m = LotteryAgentSales{} }
+func (m *LotteryAgentSales) String() string            { return proto.CompactTextString(m) }
+func (*LotteryAgentSales) ProtoMessage()               {}
+func (*LotteryAgentSales) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *LotteryAgentSales) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryAgentSales) GetAgentAddr() string {
+	if m != nil {
+		return m.AgentAddr
+	}
+	return ""
+}
+
+func (m *LotteryAgentSales) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryAgentSales) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *LotteryAgentSales) GetCommission() int64 {
+	if m != nil {
+		return m.Commission
+	}
+	return 0
+}
+
+func (m *LotteryAgentSales) GetTxNum() int64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+type ReqLotteryAddrWinnings struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+}
+
+func (m *ReqLotteryAddrWinnings) Reset()                    { *m = ReqLotteryAddrWinnings{} }
+func (m *ReqLotteryAddrWinnings) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryAddrWinnings) ProtoMessage()               {}
+func (*ReqLotteryAddrWinnings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *ReqLotteryAddrWinnings) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryAddrWinnings) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+// 地址在一个彩票中累计的中奖和购买金额, 单位为账户中的金额
+type LotteryAddrWinnings struct {
+	LotteryId   string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr        string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	TotalWon    int64  `protobuf:"varint,3,opt,name=totalWon" json:"totalWon,omitempty"`
+	TotalSpent  int64  `protobuf:"varint,4,opt,name=totalSpent" json:"totalSpent,omitempty"`
+	Net         int64  `protobuf:"varint,5,opt,name=net" json:"net,omitempty"`
+	TokenSymbol string `protobuf:"bytes,6,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *LotteryAddrWinnings) Reset()                    { *m = LotteryAddrWinnings{} }
+func (m *LotteryAddrWinnings) String() string            { return proto.CompactTextString(m) }
+func (*LotteryAddrWinnings) ProtoMessage()               {}
+func (*LotteryAddrWinnings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *LotteryAddrWinnings) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryAddrWinnings) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryAddrWinnings) GetTotalWon() int64 {
+	if m != nil {
+		return m.TotalWon
+	}
+	return 0
+}
+
+func (m *LotteryAddrWinnings) GetTotalSpent() int64 {
+	if m != nil {
+		return m.TotalSpent
+	}
+	return 0
+}
+
+func (m *LotteryAddrWinnings) GetNet() int64 {
+	if m != nil {
+		return m.Net
+	}
+	return 0
+}
+
+func (m *LotteryAddrWinnings) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+// 排行榜中的一个地址, 购买数量排名的score 和购买记录中的amount 单位相同, 中奖金额排名为账户中的金额
+type LotteryBoardEntry struct {
+	Addr  string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Score int64  `protobuf:"varint,2,opt,name=score" json:"score,omitempty"`
+	Rank  int32  `protobuf:"varint,3,opt,name=rank" json:"rank,omitempty"`
+}
+
+func (m *LotteryBoardEntry) Reset()                    { *m = LotteryBoardEntry{} }
+func (m *LotteryBoardEntry) String() string            { return proto.CompactTextString(m) }
+func (*LotteryBoardEntry) ProtoMessage()               {}
+func (*LotteryBoardEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *LotteryBoardEntry) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryBoardEntry) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *LotteryBoardEntry) GetRank() int32 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+type LotteryLeaderboard struct {
+	Entries []*LotteryBoardEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *LotteryLeaderboard) Reset()                    { *m = LotteryLeaderboard{} }
+func (m *LotteryLeaderboard) String() string            { return proto.CompactTextString(m) }
+func (*LotteryLeaderboard) ProtoMessage()               {}
+func (*LotteryLeaderboard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+func (m *LotteryLeaderboard) GetEntries() []*LotteryBoardEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type ReqLotteryLeaderboard struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Metric    int32  `protobuf:"varint,2,opt,name=metric" json:"metric,omitempty"`
+	TopN      int32  `protobuf:"varint,3,opt,name=topN" json:"topN,omitempty"`
+}
+
+func (m *ReqLotteryLeaderboard) Reset()                    { *m = ReqLotteryLeaderboard{} }
+func (m *ReqLotteryLeaderboard) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryLeaderboard) ProtoMessage()               {}
+func (*ReqLotteryLeaderboard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+
+func (m *ReqLotteryLeaderboard) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryLeaderboard) GetMetric() int32 {
+	if m != nil {
+		return m.Metric
+	}
+	return 0
+}
+
+func (m *ReqLotteryLeaderboard) GetTopN() int32 {
+	if m != nil {
+		return m.TopN
+	}
+	return 0
+}
+
+type ReplyLotteryLeaderboard struct {
+	LotteryId   string               `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Metric      int32                `protobuf:"varint,2,opt,name=metric" json:"metric,omitempty"`
+	Entries     []*LotteryBoardEntry `protobuf:"bytes,3,rep,name=entries" json:"entries,omitempty"`
+	TokenSymbol string               `protobuf:"bytes,4,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryLeaderboard) Reset()                    { *m = ReplyLotteryLeaderboard{} }
+func (m *ReplyLotteryLeaderboard) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryLeaderboard) ProtoMessage()               {}
+func (*ReplyLotteryLeaderboard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+func (m *ReplyLotteryLeaderboard) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReplyLotteryLeaderboard) GetMetric() int32 {
+	if m != nil {
+		return m.Metric
+	}
+	return 0
+}
+
+func (m *ReplyLotteryLeaderboard) GetEntries() []*LotteryBoardEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ReplyLotteryLeaderboard) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+// 开奖号码的全部推导输入, 任何人都可以据此在链下重新计算开奖号码
+type LotteryDrawProof struct {
+	LotteryId      string  `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round          int64   `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	TxHash         string  `protobuf:"bytes,3,opt,name=txHash" json:"txHash,omitempty"`
+	DrawHeight     int64   `protobuf:"varint,4,opt,name=drawHeight" json:"drawHeight,omitempty"`
+	Method         int32   `protobuf:"varint,5,opt,name=method" json:"method,omitempty"`
+	PurchasedTxNum int64   `protobuf:"varint,6,opt,name=purchasedTxNum" json:"purchasedTxNum,omitempty"`
+	BeginHeight    int64   `protobuf:"varint,7,opt,name=beginHeight" json:"beginHeight,omitempty"`
+	EndHeight      int64   `protobuf:"varint,8,opt,name=endHeight" json:"endHeight,omitempty"`
+	Step           int64   `protobuf:"varint,9,opt,name=step" json:"step,omitempty"`
+	TimeHeights    []int64 `protobuf:"varint,10,rep,packed,name=timeHeights" json:"timeHeights,omitempty"`
+	TimeSum        int64   `protobuf:"varint,11,opt,name=timeSum" json:"timeSum,omitempty"`
+	MinerHeights   []int64 `protobuf:"varint,12,rep,packed,name=minerHeights" json:"minerHeights,omitempty"`
+	Modifies       []byte  `protobuf:"bytes,13,opt,name=modifies,proto3" json:"modifies,omitempty"`
+	TicketIds      string  `protobuf:"bytes,14,opt,name=ticketIds" json:"ticketIds,omitempty"`
+	Bits           uint32  `protobuf:"varint,15,opt,name=bits" json:"bits,omitempty"`
+	Secret         []byte  `protobuf:"bytes,16,opt,name=secret,proto3" json:"secret,omitempty"`
+	HashHeight     int64   `protobuf:"varint,17,opt,name=hashHeight" json:"hashHeight,omitempty"`
+	BlockHash      []byte  `protobuf:"bytes,18,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Seed           []byte  `protobuf:"bytes,19,opt,name=seed,proto3" json:"seed,omitempty"`
+	LuckyNumMol    int64   `protobuf:"varint,20,opt,name=luckyNumMol" json:"luckyNumMol,omitempty"`
+	LuckyNumber    int64   `protobuf:"varint,21,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
+	AlgoVersion    int32   `protobuf:"varint,22,opt,name=algoVersion" json:"algoVersion,omitempty"`
+}
+
+func (m *LotteryDrawProof) Reset()                    { *m = LotteryDrawProof{} }
+func (m *LotteryDrawProof) String() string            { return proto.CompactTextString(m) }
+func (*LotteryDrawProof) ProtoMessage()               {}
+func (*LotteryDrawProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+
+func (m *LotteryDrawProof) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *LotteryDrawProof) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *LotteryDrawProof) GetDrawHeight() int64 {
+	if m != nil {
+		return m.DrawHeight
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetMethod() int32 {
+	if m != nil {
+		return m.Method
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetPurchasedTxNum() int64 {
+	if m != nil {
+		return m.PurchasedTxNum
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetBeginHeight() int64 {
+	if m != nil {
+		return m.BeginHeight
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetEndHeight() int64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetStep() int64 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetTimeHeights() []int64 {
+	if m != nil {
+		return m.TimeHeights
+	}
+	return nil
+}
+
+func (m *LotteryDrawProof) GetTimeSum() int64 {
+	if m != nil {
+		return m.TimeSum
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetMinerHeights() []int64 {
+	if m != nil {
+		return m.MinerHeights
+	}
+	return nil
+}
+
+func (m *LotteryDrawProof) GetModifies() []byte {
+	if m != nil {
+		return m.Modifies
+	}
+	return nil
+}
+
+func (m *LotteryDrawProof) GetTicketIds() string {
+	if m != nil {
+		return m.TicketIds
+	}
+	return ""
+}
+
+func (m *LotteryDrawProof) GetBits() uint32 {
+	if m != nil {
+		return m.Bits
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetSecret() []byte {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+func (m *LotteryDrawProof) GetHashHeight() int64 {
+	if m != nil {
+		return m.HashHeight
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *LotteryDrawProof) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *LotteryDrawProof) GetLuckyNumMol() int64 {
+	if m != nil {
+		return m.LuckyNumMol
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetLuckyNumber() int64 {
+	if m != nil {
+		return m.LuckyNumber
+	}
+	return 0
+}
+
+func (m *LotteryDrawProof) GetAlgoVersion() int32 {
+	if m != nil {
+		return m.AlgoVersion
+	}
+	return 0
+}
+
+type ReqLotteryDrawProof struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round     int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+}
+
+func (m *ReqLotteryDrawProof) Reset()                    { *m = ReqLotteryDrawProof{} }
+func (m *ReqLotteryDrawProof) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryDrawProof) ProtoMessage()               {}
+func (*ReqLotteryDrawProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+func (m *ReqLotteryDrawProof) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryDrawProof) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+type LotteryRoundInfo struct {
+	Round       int64  `protobuf:"varint,1,opt,name=round" json:"round,omitempty"`
+	Status      int32  `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
+	TotalSales  int64  `protobuf:"varint,3,opt,name=totalSales" json:"totalSales,omitempty"`
+	LuckyNumber int64  `protobuf:"varint,4,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
+	TotalPayout int64  `protobuf:"varint,5,opt,name=totalPayout" json:"totalPayout,omitempty"`
+	Time        int64  `protobuf:"varint,6,opt,name=time" json:"time,omitempty"`
+	TxHash      string `protobuf:"bytes,7,opt,name=txHash" json:"txHash,omitempty"`
+	Rollover    int64  `protobuf:"varint,8,opt,name=rollover" json:"rollover,omitempty"`
+	// 开奖使用的seed 和算法版本, 可以用 Query_VerifyDraw 重新选出中奖号码
+	Seed        []byte `protobuf:"bytes,9,opt,name=seed,proto3" json:"seed,omitempty"`
+	AlgoVersion int32  `protobuf:"varint,10,opt,name=algoVersion" json:"algoVersion,omitempty"`
+}
+
+func (m *LotteryRoundInfo) Reset()                    { *m = LotteryRoundInfo{} }
+func (m *LotteryRoundInfo) String() string            { return proto.CompactTextString(m) }
+func (*LotteryRoundInfo) ProtoMessage()               {}
+func (*LotteryRoundInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+func (m *LotteryRoundInfo) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetTotalSales() int64 {
+	if m != nil {
+		return m.TotalSales
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetLuckyNumber() int64 {
+	if m != nil {
+		return m.LuckyNumber
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetTotalPayout() int64 {
+	if m != nil {
+		return m.TotalPayout
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *LotteryRoundInfo) GetRollover() int64 {
+	if m != nil {
+		return m.Rollover
+	}
+	return 0
+}
+
+func (m *LotteryRoundInfo) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *LotteryRoundInfo) GetAlgoVersion() int32 {
+	if m != nil {
+		return m.AlgoVersion
+	}
+	return 0
+}
+
+type ReqLotteryFullInfo struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+}
+
+func (m *ReqLotteryFullInfo) Reset()                    { *m = ReqLotteryFullInfo{} }
+func (m *ReqLotteryFullInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryFullInfo) ProtoMessage()               {}
+func (*ReqLotteryFullInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+
+func (m *ReqLotteryFullInfo) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryFullInfo) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+type LotteryAddrRoundInfo struct {
+	Addr       string              `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Round      int64               `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	TicketNum  int32               `protobuf:"varint,3,opt,name=ticketNum" json:"ticketNum,omitempty"`
+	Amount     int64               `protobuf:"varint,4,opt,name=amount" json:"amount,omitempty"`
+	PendingNum int32               `protobuf:"varint,5,opt,name=pendingNum" json:"pendingNum,omitempty"`
+	Winnings   int64               `protobuf:"varint,6,opt,name=winnings" json:"winnings,omitempty"`
+	Records    []*LotteryBuyRecord `protobuf:"bytes,7,rep,name=records" json:"records,omitempty"`
+}
+
+func (m *LotteryAddrRoundInfo) Reset()                    { *m = LotteryAddrRoundInfo{} }
+func (m *LotteryAddrRoundInfo) String() string            { return proto.CompactTextString(m) }
+func (*LotteryAddrRoundInfo) ProtoMessage()               {}
+func (*LotteryAddrRoundInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+
+func (m *LotteryAddrRoundInfo) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *LotteryAddrRoundInfo) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LotteryAddrRoundInfo) GetTicketNum() int32 {
+	if m != nil {
+		return m.TicketNum
+	}
+	return 0
+}
+
+func (m *LotteryAddrRoundInfo) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *LotteryAddrRoundInfo) GetPendingNum() int32 {
+	if m != nil {
+		return m.PendingNum
+	}
+	return 0
+}
+
+func (m *LotteryAddrRoundInfo) GetWinnings() int64 {
+	if m != nil {
+		return m.Winnings
+	}
+	return 0
+}
+
+func (m *LotteryAddrRoundInfo) GetRecords() []*LotteryBuyRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// 彩票页面需要的全部信息, 一次查询返回
+type ReplyLotteryFullInfo struct {
+	LotteryId     string                `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Status        int32                 `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
+	CreateAddr    string                `protobuf:"bytes,3,opt,name=createAddr" json:"createAddr,omitempty"`
+	Admin         string                `protobuf:"bytes,4,opt,name=admin" json:"admin,omitempty"`
+	CreateHeight  int64                 `protobuf:"varint,5,opt,name=createHeight" json:"createHeight,omitempty"`
+	Config        *LotteryCreate        `protobuf:"bytes,6,opt,name=config" json:"config,omitempty"`
+	Round         int64                 `protobuf:"varint,7,opt,name=round" json:"round,omitempty"`
+	RoundSales    int64                 `protobuf:"varint,8,opt,name=roundSales" json:"roundSales,omitempty"`
+	RoundTxNum    int64                 `protobuf:"varint,9,opt,name=roundTxNum" json:"roundTxNum,omitempty"`
+	RoundBuyerNum int32                 `protobuf:"varint,10,opt,name=roundBuyerNum" json:"roundBuyerNum,omitempty"`
+	Fund          int64                 `protobuf:"varint,11,opt,name=fund" json:"fund,omitempty"`
+	EscrowAddr    string                `protobuf:"bytes,12,opt,name=escrowAddr" json:"escrowAddr,omitempty"`
+	LastDraw      *LotteryRoundInfo     `protobuf:"bytes,13,opt,name=lastDraw" json:"lastDraw,omitempty"`
+	AddrInfo      *LotteryAddrRoundInfo `protobuf:"bytes,14,opt,name=addrInfo" json:"addrInfo,omitempty"`
+	TokenSymbol   string                `protobuf:"bytes,15,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryFullInfo) Reset()                    { *m = ReplyLotteryFullInfo{} }
+func (m *ReplyLotteryFullInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryFullInfo) ProtoMessage()               {}
+func (*ReplyLotteryFullInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+func (m *ReplyLotteryFullInfo) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReplyLotteryFullInfo) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetCreateAddr() string {
+	if m != nil {
+		return m.CreateAddr
+	}
+	return ""
+}
+
+func (m *ReplyLotteryFullInfo) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+func (m *ReplyLotteryFullInfo) GetCreateHeight() int64 {
+	if m != nil {
+		return m.CreateHeight
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetConfig() *LotteryCreate {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *ReplyLotteryFullInfo) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetRoundSales() int64 {
+	if m != nil {
+		return m.RoundSales
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetRoundTxNum() int64 {
+	if m != nil {
+		return m.RoundTxNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetRoundBuyerNum() int32 {
+	if m != nil {
+		return m.RoundBuyerNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetFund() int64 {
+	if m != nil {
+		return m.Fund
+	}
+	return 0
+}
+
+func (m *ReplyLotteryFullInfo) GetEscrowAddr() string {
+	if m != nil {
+		return m.EscrowAddr
+	}
+	return ""
+}
+
+func (m *ReplyLotteryFullInfo) GetLastDraw() *LotteryRoundInfo {
+	if m != nil {
+		return m.LastDraw
+	}
+	return nil
+}
+
+func (m *ReplyLotteryFullInfo) GetAddrInfo() *LotteryAddrRoundInfo {
+	if m != nil {
+		return m.AddrInfo
+	}
+	return nil
+}
+
+func (m *ReplyLotteryFullInfo) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type ReplyLotteryVerifyDraw struct {
+	LotteryId   string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Round       int64  `protobuf:"varint,2,opt,name=round" json:"round,omitempty"`
+	Seed        []byte `protobuf:"bytes,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	AlgoVersion int32  `protobuf:"varint,4,opt,name=algoVersion" json:"algoVersion,omitempty"`
+	LuckyNumber int64  `protobuf:"varint,5,opt,name=luckyNumber" json:"luckyNumber,omitempty"`
+	Verified    bool   `protobuf:"varint,6,opt,name=verified" json:"verified,omitempty"`
+	ExpectedNum int32  `protobuf:"varint,7,opt,name=expectedNum" json:"expectedNum,omitempty"`
+	RecordedNum int32  `protobuf:"varint,8,opt,name=recordedNum" json:"recordedNum,omitempty"`
+	// 重新计算和记录不一致的中奖号码, 只比较地址, 购买序号和奖级
+	Mismatches []*LotteryWinnerRecord `protobuf:"bytes,9,rep,name=mismatches" json:"mismatches,omitempty"`
+}
+
+func (m *ReplyLotteryVerifyDraw) Reset()                    { *m = ReplyLotteryVerifyDraw{} }
+func (m *ReplyLotteryVerifyDraw) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryVerifyDraw) ProtoMessage()               {}
+func (*ReplyLotteryVerifyDraw) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+
+func (m *ReplyLotteryVerifyDraw) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReplyLotteryVerifyDraw) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ReplyLotteryVerifyDraw) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *ReplyLotteryVerifyDraw) GetAlgoVersion() int32 {
+	if m != nil {
+		return m.AlgoVersion
+	}
+	return 0
+}
+
+func (m *ReplyLotteryVerifyDraw) GetLuckyNumber() int64 {
+	if m != nil {
+		return m.LuckyNumber
+	}
+	return 0
+}
+
+func (m *ReplyLotteryVerifyDraw) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
+func (m *ReplyLotteryVerifyDraw) GetExpectedNum() int32 {
+	if m != nil {
+		return m.ExpectedNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryVerifyDraw) GetRecordedNum() int32 {
+	if m != nil {
+		return m.RecordedNum
+	}
+	return 0
+}
+
+func (m *ReplyLotteryVerifyDraw) GetMismatches() []*LotteryWinnerRecord {
+	if m != nil {
+		return m.Mismatches
+	}
+	return nil
+}
+
+type ReqLotteryRoundsInfo struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	FromRound int64  `protobuf:"varint,2,opt,name=fromRound" json:"fromRound,omitempty"`
+	Count     int32  `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
+	Direction int32  `protobuf:"varint,4,opt,name=direction" json:"direction,omitempty"`
+}
+
+func (m *ReqLotteryRoundsInfo) Reset()                    { *m = ReqLotteryRoundsInfo{} }
+func (m *ReqLotteryRoundsInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryRoundsInfo) ProtoMessage()               {}
+func (*ReqLotteryRoundsInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+func (m *ReqLotteryRoundsInfo) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryRoundsInfo) GetFromRound() int64 {
+	if m != nil {
+		return m.FromRound
+	}
+	return 0
+}
+
+func (m *ReqLotteryRoundsInfo) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqLotteryRoundsInfo) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+type ReplyLotteryRoundsInfo struct {
+	Rounds      []*LotteryRoundInfo `protobuf:"bytes,1,rep,name=rounds" json:"rounds,omitempty"`
+	TokenSymbol string              `protobuf:"bytes,2,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryRoundsInfo) Reset()                    { *m = ReplyLotteryRoundsInfo{} }
+func (m *ReplyLotteryRoundsInfo) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryRoundsInfo) ProtoMessage()               {}
+func (*ReplyLotteryRoundsInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+
+func (m *ReplyLotteryRoundsInfo) GetRounds() []*LotteryRoundInfo {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
+}
+
+func (m *ReplyLotteryRoundsInfo) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
 	}
 	return ""
 }
 
-type LotteryDrawRecords struct {
-	Records []*LotteryDrawRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+type ReplyLotteryPrizePool struct {
+	LotteryId   string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	EscrowAddr  string `protobuf:"bytes,2,opt,name=escrowAddr" json:"escrowAddr,omitempty"`
+	Balance     int64  `protobuf:"varint,3,opt,name=balance" json:"balance,omitempty"`
+	TokenSymbol string `protobuf:"bytes,4,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
 }
 
-func (m *LotteryDrawRecords) Reset()                    { *m = LotteryDrawRecords{} }
-func (m *LotteryDrawRecords) String() string            { return proto.CompactTextString(m) }
-func (*LotteryDrawRecords) ProtoMessage()               {}
-func (*LotteryDrawRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (m *ReplyLotteryPrizePool) Reset()                    { *m = ReplyLotteryPrizePool{} }
+func (m *ReplyLotteryPrizePool) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryPrizePool) ProtoMessage()               {}
+func (*ReplyLotteryPrizePool) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
 
-func (m *LotteryDrawRecords) GetRecords() []*LotteryDrawRecord {
+func (m *ReplyLotteryPrizePool) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReplyLotteryPrizePool) GetEscrowAddr() string {
+	if m != nil {
+		return m.EscrowAddr
+	}
+	return ""
+}
+
+func (m *ReplyLotteryPrizePool) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *ReplyLotteryPrizePool) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type ReqLotteryRefundRecords struct {
+	LotteryId string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Addr      string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+}
+
+func (m *ReqLotteryRefundRecords) Reset()                    { *m = ReqLotteryRefundRecords{} }
+func (m *ReqLotteryRefundRecords) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryRefundRecords) ProtoMessage()               {}
+func (*ReqLotteryRefundRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
+func (m *ReqLotteryRefundRecords) GetLotteryId() string {
+	if m != nil {
+		return m.LotteryId
+	}
+	return ""
+}
+
+func (m *ReqLotteryRefundRecords) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+type ReplyLotteryRefundRecords struct {
+	Records     []*ReceiptLotteryRefund `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+	TokenSymbol string                  `protobuf:"bytes,2,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *ReplyLotteryRefundRecords) Reset()                    { *m = ReplyLotteryRefundRecords{} }
+func (m *ReplyLotteryRefundRecords) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryRefundRecords) ProtoMessage()               {}
+func (*ReplyLotteryRefundRecords) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+func (m *ReplyLotteryRefundRecords) GetRecords() []*ReceiptLotteryRefund {
 	if m != nil {
 		return m.Records
 	}
 	return nil
 }
 
-type LotteryUpdateRec struct {
-	Index int64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
-	Type  int64 `protobuf:"varint,2,opt,name=type" json:"type,omitempty"`
+func (m *ReplyLotteryRefundRecords) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
 }
 
-func (m *LotteryUpdateRec) Reset()                    { *m = LotteryUpdateRec{} }
-func (m *LotteryUpdateRec) String() string            { return proto.CompactTextString(m) }
-func (*LotteryUpdateRec) ProtoMessage()               {}
-func (*LotteryUpdateRec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+type ReqLotteryList struct {
+	Status     int32  `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
+	Count      int32  `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Direction  int32  `protobuf:"varint,3,opt,name=direction" json:"direction,omitempty"`
+	PrimaryKey string `protobuf:"bytes,4,opt,name=primaryKey" json:"primaryKey,omitempty"`
+}
 
-func (m *LotteryUpdateRec) GetIndex() int64 {
+func (m *ReqLotteryList) Reset()                    { *m = ReqLotteryList{} }
+func (m *ReqLotteryList) String() string            { return proto.CompactTextString(m) }
+func (*ReqLotteryList) ProtoMessage()               {}
+func (*ReqLotteryList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+
+func (m *ReqLotteryList) GetStatus() int32 {
 	if m != nil {
-		return m.Index
+		return m.Status
 	}
 	return 0
 }
 
-func (m *LotteryUpdateRec) GetType() int64 {
+func (m *ReqLotteryList) GetCount() int32 {
 	if m != nil {
-		return m.Type
+		return m.Count
 	}
 	return 0
 }
 
-type LotteryUpdateRecs struct {
-	Records []*LotteryUpdateRec `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
+func (m *ReqLotteryList) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
 }
 
-func (m *LotteryUpdateRecs) Reset()                    { *m = LotteryUpdateRecs{} }
-func (m *LotteryUpdateRecs) String() string            { return proto.CompactTextString(m) }
-func (*LotteryUpdateRecs) ProtoMessage()               {}
-func (*LotteryUpdateRecs) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (m *ReqLotteryList) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
 
-func (m *LotteryUpdateRecs) GetRecords() []*LotteryUpdateRec {
+type LotteryListItem struct {
+	LotteryId    string `protobuf:"bytes,1,opt,name=lotteryId" json:"lotteryId,omitempty"`
+	Status       int32  `protobuf:"varint,2,opt,name=status" json:"status,omitempty"`
+	CreateAddr   string `protobuf:"bytes,3,opt,name=createAddr" json:"createAddr,omitempty"`
+	CreateHeight int64  `protobuf:"varint,4,opt,name=createHeight" json:"createHeight,omitempty"`
+	Round        int64  `protobuf:"varint,5,opt,name=round" json:"round,omitempty"`
+	PurBlockNum  int64  `protobuf:"varint,6,opt,name=purBlockNum" json:"purBlockNum,omitempty"`
+	DrawBlockNum int64  `protobuf:"varint,7,opt,name=drawBlockNum" json:"drawBlockNum,omitempty"`
+	RoundSales   int64  `protobuf:"varint,8,opt,name=roundSales" json:"roundSales,omitempty"`
+	TotalSales   int64  `protobuf:"varint,9,opt,name=totalSales" json:"totalSales,omitempty"`
+	TokenSymbol  string `protobuf:"bytes,10,opt,name=tokenSymbol" json:"tokenSymbol,omitempty"`
+}
+
+func (m *LotteryListItem) Reset()                    { *m = LotteryListItem{} }
+func (m *LotteryListItem) String() string            { return proto.CompactTextString(m) }
+func (*LotteryListItem) ProtoMessage()               {}
+func (*LotteryListItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+func (m *LotteryListItem) GetLotteryId() string {
 	if m != nil {
-		return m.Records
+		return m.LotteryId
 	}
-	return nil
+	return ""
 }
 
-type LotteryUpdateBuyInfo struct {
-	BuyInfo map[string]*LotteryUpdateRecs `protobuf:"bytes,1,rep,name=buyInfo" json:"buyInfo,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+func (m *LotteryListItem) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
 }
 
-func (m *LotteryUpdateBuyInfo) Reset()                    { *m = LotteryUpdateBuyInfo{} }
-func (m *LotteryUpdateBuyInfo) String() string            { return proto.CompactTextString(m) }
-func (*LotteryUpdateBuyInfo) ProtoMessage()               {}
-func (*LotteryUpdateBuyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (m *LotteryListItem) GetCreateAddr() string {
+	if m != nil {
+		return m.CreateAddr
+	}
+	return ""
+}
 
-func (m *LotteryUpdateBuyInfo) GetBuyInfo() map[string]*LotteryUpdateRecs {
+func (m *LotteryListItem) GetCreateHeight() int64 {
 	if m != nil {
-		return m.BuyInfo
+		return m.CreateHeight
 	}
-	return nil
+	return 0
 }
 
-type ReplyLotteryPurchaseAddr struct {
-	Address []string `protobuf:"bytes,1,rep,name=address" json:"address,omitempty"`
+func (m *LotteryListItem) GetRound() int64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
 }
 
-func (m *ReplyLotteryPurchaseAddr) Reset()                    { *m = ReplyLotteryPurchaseAddr{} }
-func (m *ReplyLotteryPurchaseAddr) String() string            { return proto.CompactTextString(m) }
-func (*ReplyLotteryPurchaseAddr) ProtoMessage()               {}
-func (*ReplyLotteryPurchaseAddr) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (m *LotteryListItem) GetPurBlockNum() int64 {
+	if m != nil {
+		return m.PurBlockNum
+	}
+	return 0
+}
 
-func (m *ReplyLotteryPurchaseAddr) GetAddress() []string {
+func (m *LotteryListItem) GetDrawBlockNum() int64 {
 	if m != nil {
-		return m.Address
+		return m.DrawBlockNum
+	}
+	return 0
+}
+
+func (m *LotteryListItem) GetRoundSales() int64 {
+	if m != nil {
+		return m.RoundSales
+	}
+	return 0
+}
+
+func (m *LotteryListItem) GetTotalSales() int64 {
+	if m != nil {
+		return m.TotalSales
+	}
+	return 0
+}
+
+func (m *LotteryListItem) GetTokenSymbol() string {
+	if m != nil {
+		return m.TokenSymbol
+	}
+	return ""
+}
+
+type ReplyLotteryList struct {
+	Lotteries  []*LotteryListItem `protobuf:"bytes,1,rep,name=lotteries" json:"lotteries,omitempty"`
+	PrimaryKey string             `protobuf:"bytes,2,opt,name=primaryKey" json:"primaryKey,omitempty"`
+}
+
+func (m *ReplyLotteryList) Reset()                    { *m = ReplyLotteryList{} }
+func (m *ReplyLotteryList) String() string            { return proto.CompactTextString(m) }
+func (*ReplyLotteryList) ProtoMessage()               {}
+func (*ReplyLotteryList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+func (m *ReplyLotteryList) GetLotteries() []*LotteryListItem {
+	if m != nil {
+		return m.Lotteries
 	}
 	return nil
 }
 
+func (m *ReplyLotteryList) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*PurchaseRecord)(nil), "types.PurchaseRecord")
 	proto.RegisterType((*PurchaseRecords)(nil), "types.PurchaseRecords")
@@ -1282,9 +5333,36 @@ func init() {
 	proto.RegisterType((*LotteryAction)(nil), "types.LotteryAction")
 	proto.RegisterType((*LotteryCreate)(nil), "types.LotteryCreate")
 	proto.RegisterType((*LotteryBuy)(nil), "types.LotteryBuy")
+	proto.RegisterType((*LotteryBuyItem)(nil), "types.LotteryBuyItem")
 	proto.RegisterType((*LotteryDraw)(nil), "types.LotteryDraw")
 	proto.RegisterType((*LotteryClose)(nil), "types.LotteryClose")
+	proto.RegisterType((*LotteryCommit)(nil), "types.LotteryCommit")
+	proto.RegisterType((*LotteryReveal)(nil), "types.LotteryReveal")
+	proto.RegisterType((*LotteryRevealNumber)(nil), "types.LotteryRevealNumber")
+	proto.RegisterType((*LotteryModify)(nil), "types.LotteryModify")
+	proto.RegisterType((*LotteryTransfer)(nil), "types.LotteryTransfer")
+	proto.RegisterType((*LotteryReclaim)(nil), "types.LotteryReclaim")
+	proto.RegisterType((*LotteryClaim)(nil), "types.LotteryClaim")
+	proto.RegisterType((*LotteryTransferTicket)(nil), "types.LotteryTransferTicket")
+	proto.RegisterType((*LotteryBlacklist)(nil), "types.LotteryBlacklist")
 	proto.RegisterType((*ReceiptLottery)(nil), "types.ReceiptLottery")
+	proto.RegisterType((*ReceiptLotteryCreatorFee)(nil), "types.ReceiptLotteryCreatorFee")
+	proto.RegisterType((*ReceiptLotteryDrawReward)(nil), "types.ReceiptLotteryDrawReward")
+	proto.RegisterType((*ReceiptLotteryModify)(nil), "types.ReceiptLotteryModify")
+	proto.RegisterType((*ReplyLotteryModifyRecords)(nil), "types.ReplyLotteryModifyRecords")
+	proto.RegisterType((*ReceiptLotteryTransfer)(nil), "types.ReceiptLotteryTransfer")
+	proto.RegisterType((*ReceiptLotteryTicketTransfer)(nil), "types.ReceiptLotteryTicketTransfer")
+	proto.RegisterType((*ReceiptLotteryReclaim)(nil), "types.ReceiptLotteryReclaim")
+	proto.RegisterType((*ReceiptLotteryPayout)(nil), "types.ReceiptLotteryPayout")
+	proto.RegisterType((*LotteryPayout)(nil), "types.LotteryPayout")
+	proto.RegisterType((*ReqLotteryPayouts)(nil), "types.ReqLotteryPayouts")
+	proto.RegisterType((*ReplyLotteryPayouts)(nil), "types.ReplyLotteryPayouts")
+	proto.RegisterType((*ReceiptLotteryBlacklist)(nil), "types.ReceiptLotteryBlacklist")
+	proto.RegisterType((*ReplyLotteryTransferRecords)(nil), "types.ReplyLotteryTransferRecords")
+	proto.RegisterType((*LotteryConfigField)(nil), "types.LotteryConfigField")
+	proto.RegisterType((*LotteryConfigChange)(nil), "types.LotteryConfigChange")
+	proto.RegisterType((*ReplyLotteryConfigHistory)(nil), "types.ReplyLotteryConfigHistory")
+	proto.RegisterType((*ReceiptLotteryRefund)(nil), "types.ReceiptLotteryRefund")
 	proto.RegisterType((*ReqLotteryInfo)(nil), "types.ReqLotteryInfo")
 	proto.RegisterType((*ReqLotteryBuyInfo)(nil), "types.ReqLotteryBuyInfo")
 	proto.RegisterType((*ReqLotteryBuyHistory)(nil), "types.ReqLotteryBuyHistory")
@@ -1303,89 +5381,633 @@ func init() {
 	proto.RegisterType((*LotteryUpdateRecs)(nil), "types.LotteryUpdateRecs")
 	proto.RegisterType((*LotteryUpdateBuyInfo)(nil), "types.LotteryUpdateBuyInfo")
 	proto.RegisterType((*ReplyLotteryPurchaseAddr)(nil), "types.ReplyLotteryPurchaseAddr")
+	proto.RegisterType((*ReqLotteryBuyRecordsByAddr)(nil), "types.ReqLotteryBuyRecordsByAddr")
+	proto.RegisterType((*LotteryBuyEntry)(nil), "types.LotteryBuyEntry")
+	proto.RegisterType((*LotteryBuyTxIndex)(nil), "types.LotteryBuyTxIndex")
+	proto.RegisterType((*ReqLotteryBuyByTxHash)(nil), "types.ReqLotteryBuyByTxHash")
+	proto.RegisterType((*ReplyLotteryBuyByTxHash)(nil), "types.ReplyLotteryBuyByTxHash")
+	proto.RegisterType((*ReplyLotteryBuyRecordsByAddr)(nil), "types.ReplyLotteryBuyRecordsByAddr")
+	proto.RegisterType((*LotteryWinnerRecord)(nil), "types.LotteryWinnerRecord")
+	proto.RegisterType((*ReqLotteryRoundWinners)(nil), "types.ReqLotteryRoundWinners")
+	proto.RegisterType((*ReplyLotteryRoundWinners)(nil), "types.ReplyLotteryRoundWinners")
+	proto.RegisterType((*LotteryStats)(nil), "types.LotteryStats")
+	proto.RegisterType((*LotteryNumberHeat)(nil), "types.LotteryNumberHeat")
+	proto.RegisterType((*ReqLotteryNumberHeat)(nil), "types.ReqLotteryNumberHeat")
+	proto.RegisterType((*ReplyLotteryNumberHeat)(nil), "types.ReplyLotteryNumberHeat")
+	proto.RegisterType((*ReplyLotteryCurrentPool)(nil), "types.ReplyLotteryCurrentPool")
+	proto.RegisterType((*ReqLotteryAgentSales)(nil), "types.ReqLotteryAgentSales")
+	proto.RegisterType((*LotteryAgentSales)(nil), "types.LotteryAgentSales")
+	proto.RegisterType((*ReqLotteryAddrWinnings)(nil), "types.ReqLotteryAddrWinnings")
+	proto.RegisterType((*LotteryAddrWinnings)(nil), "types.LotteryAddrWinnings")
+	proto.RegisterType((*LotteryBoardEntry)(nil), "types.LotteryBoardEntry")
+	proto.RegisterType((*LotteryLeaderboard)(nil), "types.LotteryLeaderboard")
+	proto.RegisterType((*ReqLotteryLeaderboard)(nil), "types.ReqLotteryLeaderboard")
+	proto.RegisterType((*ReplyLotteryLeaderboard)(nil), "types.ReplyLotteryLeaderboard")
+	proto.RegisterType((*LotteryDrawProof)(nil), "types.LotteryDrawProof")
+	proto.RegisterType((*ReqLotteryDrawProof)(nil), "types.ReqLotteryDrawProof")
+	proto.RegisterType((*LotteryRoundInfo)(nil), "types.LotteryRoundInfo")
+	proto.RegisterType((*ReqLotteryFullInfo)(nil), "types.ReqLotteryFullInfo")
+	proto.RegisterType((*LotteryAddrRoundInfo)(nil), "types.LotteryAddrRoundInfo")
+	proto.RegisterType((*ReplyLotteryFullInfo)(nil), "types.ReplyLotteryFullInfo")
+	proto.RegisterType((*ReplyLotteryVerifyDraw)(nil), "types.ReplyLotteryVerifyDraw")
+	proto.RegisterType((*ReqLotteryRoundsInfo)(nil), "types.ReqLotteryRoundsInfo")
+	proto.RegisterType((*ReplyLotteryRoundsInfo)(nil), "types.ReplyLotteryRoundsInfo")
+	proto.RegisterType((*ReplyLotteryPrizePool)(nil), "types.ReplyLotteryPrizePool")
+	proto.RegisterType((*ReqLotteryRefundRecords)(nil), "types.ReqLotteryRefundRecords")
+	proto.RegisterType((*ReplyLotteryRefundRecords)(nil), "types.ReplyLotteryRefundRecords")
+	proto.RegisterType((*ReqLotteryList)(nil), "types.ReqLotteryList")
+	proto.RegisterType((*LotteryListItem)(nil), "types.LotteryListItem")
+	proto.RegisterType((*ReplyLotteryList)(nil), "types.ReplyLotteryList")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Lottery service
+
+type LotteryClient interface {
+	// 构造未签名的彩票交易
+	CreateRawLotteryCreateTx(ctx context.Context, in *LotteryCreate, opts ...grpc.CallOption) (*types2.UnsignTx, error)
+	CreateRawLotteryBuyTx(ctx context.Context, in *LotteryBuy, opts ...grpc.CallOption) (*types2.UnsignTx, error)
+	CreateRawLotteryDrawTx(ctx context.Context, in *LotteryDraw, opts ...grpc.CallOption) (*types2.UnsignTx, error)
+	CreateRawLotteryCloseTx(ctx context.Context, in *LotteryClose, opts ...grpc.CallOption) (*types2.UnsignTx, error)
+	// 查询, 和执行器的Query_ 函数返回相同的结果
+	GetLotteryInfo(ctx context.Context, in *ReqLotteryInfo, opts ...grpc.CallOption) (*ReplyLotteryNormalInfo, error)
+	GetBuyRecords(ctx context.Context, in *ReqLotteryBuyHistory, opts ...grpc.CallOption) (*LotteryBuyRecords, error)
+	GetDrawResults(ctx context.Context, in *ReqLotteryLuckyHistory, opts ...grpc.CallOption) (*LotteryDrawRecords, error)
+	ListLotteries(ctx context.Context, in *ReqLotteryList, opts ...grpc.CallOption) (*ReplyLotteryList, error)
+}
+
+type lotteryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLotteryClient(cc *grpc.ClientConn) LotteryClient {
+	return &lotteryClient{cc}
+}
+
+func (c *lotteryClient) CreateRawLotteryCreateTx(ctx context.Context, in *LotteryCreate, opts ...grpc.CallOption) (*types2.UnsignTx, error) {
+	out := new(types2.UnsignTx)
+	err := grpc.Invoke(ctx, "/types.lottery/CreateRawLotteryCreateTx", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) CreateRawLotteryBuyTx(ctx context.Context, in *LotteryBuy, opts ...grpc.CallOption) (*types2.UnsignTx, error) {
+	out := new(types2.UnsignTx)
+	err := grpc.Invoke(ctx, "/types.lottery/CreateRawLotteryBuyTx", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) CreateRawLotteryDrawTx(ctx context.Context, in *LotteryDraw, opts ...grpc.CallOption) (*types2.UnsignTx, error) {
+	out := new(types2.UnsignTx)
+	err := grpc.Invoke(ctx, "/types.lottery/CreateRawLotteryDrawTx", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) CreateRawLotteryCloseTx(ctx context.Context, in *LotteryClose, opts ...grpc.CallOption) (*types2.UnsignTx, error) {
+	out := new(types2.UnsignTx)
+	err := grpc.Invoke(ctx, "/types.lottery/CreateRawLotteryCloseTx", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetLotteryInfo(ctx context.Context, in *ReqLotteryInfo, opts ...grpc.CallOption) (*ReplyLotteryNormalInfo, error) {
+	out := new(ReplyLotteryNormalInfo)
+	err := grpc.Invoke(ctx, "/types.lottery/GetLotteryInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetBuyRecords(ctx context.Context, in *ReqLotteryBuyHistory, opts ...grpc.CallOption) (*LotteryBuyRecords, error) {
+	out := new(LotteryBuyRecords)
+	err := grpc.Invoke(ctx, "/types.lottery/GetBuyRecords", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetDrawResults(ctx context.Context, in *ReqLotteryLuckyHistory, opts ...grpc.CallOption) (*LotteryDrawRecords, error) {
+	out := new(LotteryDrawRecords)
+	err := grpc.Invoke(ctx, "/types.lottery/GetDrawResults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) ListLotteries(ctx context.Context, in *ReqLotteryList, opts ...grpc.CallOption) (*ReplyLotteryList, error) {
+	out := new(ReplyLotteryList)
+	err := grpc.Invoke(ctx, "/types.lottery/ListLotteries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Lottery service
+
+type LotteryServer interface {
+	// 构造未签名的彩票交易
+	CreateRawLotteryCreateTx(context.Context, *LotteryCreate) (*types2.UnsignTx, error)
+	CreateRawLotteryBuyTx(context.Context, *LotteryBuy) (*types2.UnsignTx, error)
+	CreateRawLotteryDrawTx(context.Context, *LotteryDraw) (*types2.UnsignTx, error)
+	CreateRawLotteryCloseTx(context.Context, *LotteryClose) (*types2.UnsignTx, error)
+	// 查询, 和执行器的Query_ 函数返回相同的结果
+	GetLotteryInfo(context.Context, *ReqLotteryInfo) (*ReplyLotteryNormalInfo, error)
+	GetBuyRecords(context.Context, *ReqLotteryBuyHistory) (*LotteryBuyRecords, error)
+	GetDrawResults(context.Context, *ReqLotteryLuckyHistory) (*LotteryDrawRecords, error)
+	ListLotteries(context.Context, *ReqLotteryList) (*ReplyLotteryList, error)
+}
+
+func RegisterLotteryServer(s *grpc.Server, srv LotteryServer) {
+	s.RegisterService(&_Lottery_serviceDesc, srv)
+}
+
+func _Lottery_CreateRawLotteryCreateTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotteryCreate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).CreateRawLotteryCreateTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/CreateRawLotteryCreateTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).CreateRawLotteryCreateTx(ctx, req.(*LotteryCreate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_CreateRawLotteryBuyTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotteryBuy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).CreateRawLotteryBuyTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/CreateRawLotteryBuyTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).CreateRawLotteryBuyTx(ctx, req.(*LotteryBuy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_CreateRawLotteryDrawTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotteryDraw)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).CreateRawLotteryDrawTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/CreateRawLotteryDrawTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).CreateRawLotteryDrawTx(ctx, req.(*LotteryDraw))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_CreateRawLotteryCloseTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotteryClose)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).CreateRawLotteryCloseTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/CreateRawLotteryCloseTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).CreateRawLotteryCloseTx(ctx, req.(*LotteryClose))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetLotteryInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqLotteryInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetLotteryInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/GetLotteryInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetLotteryInfo(ctx, req.(*ReqLotteryInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetBuyRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqLotteryBuyHistory)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetBuyRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/GetBuyRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetBuyRecords(ctx, req.(*ReqLotteryBuyHistory))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetDrawResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqLotteryLuckyHistory)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetDrawResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/GetDrawResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetDrawResults(ctx, req.(*ReqLotteryLuckyHistory))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_ListLotteries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqLotteryList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).ListLotteries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.lottery/ListLotteries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).ListLotteries(ctx, req.(*ReqLotteryList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Lottery_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.lottery",
+	HandlerType: (*LotteryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRawLotteryCreateTx",
+			Handler:    _Lottery_CreateRawLotteryCreateTx_Handler,
+		},
+		{
+			MethodName: "CreateRawLotteryBuyTx",
+			Handler:    _Lottery_CreateRawLotteryBuyTx_Handler,
+		},
+		{
+			MethodName: "CreateRawLotteryDrawTx",
+			Handler:    _Lottery_CreateRawLotteryDrawTx_Handler,
+		},
+		{
+			MethodName: "CreateRawLotteryCloseTx",
+			Handler:    _Lottery_CreateRawLotteryCloseTx_Handler,
+		},
+		{
+			MethodName: "GetLotteryInfo",
+			Handler:    _Lottery_GetLotteryInfo_Handler,
+		},
+		{
+			MethodName: "GetBuyRecords",
+			Handler:    _Lottery_GetBuyRecords_Handler,
+		},
+		{
+			MethodName: "GetDrawResults",
+			Handler:    _Lottery_GetDrawResults_Handler,
+		},
+		{
+			MethodName: "ListLotteries",
+			Handler:    _Lottery_ListLotteries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "lottery.proto",
 }
 
 func init() { proto.RegisterFile("lottery.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1252 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x5f, 0x6f, 0xe3, 0x44,
-	0x10, 0xaf, 0xed, 0x38, 0x69, 0x26, 0x69, 0xee, 0xba, 0x2d, 0x3d, 0x53, 0x50, 0x55, 0x59, 0x3a,
-	0x54, 0xe9, 0x8e, 0x08, 0x02, 0x48, 0x08, 0x2a, 0xa4, 0xcb, 0x71, 0x28, 0x95, 0x7a, 0xbd, 0xd3,
-	0xb6, 0x27, 0x1e, 0x78, 0x72, 0xe3, 0xbd, 0xab, 0xd5, 0xc4, 0x0e, 0xf6, 0xfa, 0x5a, 0xbf, 0x21,
-	0xbe, 0x04, 0x3c, 0xf3, 0xc4, 0x23, 0x1f, 0x81, 0x4f, 0xc1, 0x17, 0xe1, 0x0b, 0xa0, 0x9d, 0x5d,
-	0xc7, 0x6b, 0xc7, 0x49, 0x7a, 0xdc, 0x53, 0x76, 0x67, 0x67, 0x67, 0x67, 0x7e, 0xf3, 0xd7, 0x81,
-	0xad, 0x49, 0xc4, 0x39, 0x8b, 0xb3, 0xfe, 0x2c, 0x8e, 0x78, 0x44, 0x6c, 0x9e, 0xcd, 0x58, 0xe2,
-	0x5e, 0x41, 0xef, 0x65, 0x1a, 0x8f, 0xaf, 0xbc, 0x84, 0x51, 0x36, 0x8e, 0x62, 0x9f, 0xec, 0x41,
-	0xd3, 0x9b, 0x46, 0x69, 0xc8, 0x1d, 0xe3, 0xd0, 0x38, 0xb2, 0xa8, 0xda, 0x09, 0x7a, 0x98, 0x4e,
-	0x2f, 0x59, 0xec, 0x98, 0x92, 0x2e, 0x77, 0x64, 0x17, 0xec, 0x20, 0xf4, 0xd9, 0xad, 0x63, 0x21,
-	0x59, 0x6e, 0xc8, 0x7d, 0xb0, 0x6e, 0xbc, 0xcc, 0x69, 0x20, 0x4d, 0x2c, 0xdd, 0x5f, 0x0d, 0xb8,
-	0x57, 0x7e, 0x2a, 0x21, 0x9f, 0x42, 0x33, 0xc6, 0xa5, 0x63, 0x1c, 0x5a, 0x47, 0x9d, 0xc1, 0x07,
-	0x7d, 0xd4, 0xaa, 0x5f, 0xe6, 0xa3, 0x8a, 0x89, 0x38, 0xd0, 0x7a, 0x9d, 0x86, 0xfe, 0x8f, 0x41,
-	0xa8, 0x74, 0xc8, 0xb7, 0xe4, 0x13, 0xe8, 0x49, 0x35, 0x5f, 0x84, 0x8c, 0x46, 0x69, 0xe8, 0x2b,
-	0x6d, 0x2a, 0x54, 0xf7, 0xf7, 0x26, 0xb4, 0x4e, 0x25, 0x0e, 0xe4, 0x63, 0x68, 0x2b, 0x48, 0x4e,
-	0x7c, 0xb4, 0xb5, 0x4d, 0x0b, 0x82, 0x30, 0x37, 0xe1, 0x1e, 0x4f, 0x13, 0x7c, 0xca, 0xa6, 0x6a,
-	0x47, 0x5c, 0xe8, 0x8e, 0x63, 0xe6, 0x71, 0x36, 0x62, 0xc1, 0x9b, 0x2b, 0xae, 0xde, 0x29, 0xd1,
-	0x08, 0x81, 0x86, 0x50, 0x4c, 0x59, 0x8f, 0x6b, 0x72, 0x08, 0x9d, 0x59, 0x1a, 0x0f, 0x27, 0xd1,
-	0xf8, 0xfa, 0x2c, 0x9d, 0x3a, 0x36, 0x1e, 0xe9, 0x24, 0x21, 0xd9, 0x8f, 0xbd, 0x9b, 0x39, 0x4b,
-	0x53, 0x4a, 0xd6, 0x69, 0xe4, 0x33, 0xd8, 0x99, 0x78, 0x09, 0xbf, 0x88, 0xbd, 0x30, 0xb9, 0x88,
-	0x5e, 0xa6, 0xf1, 0x39, 0xf7, 0x38, 0x73, 0x5a, 0xc8, 0x5a, 0x77, 0x44, 0x06, 0xb0, 0xab, 0x91,
-	0xbf, 0x8f, 0xbd, 0x1b, 0x79, 0x65, 0x13, 0xaf, 0xd4, 0x9e, 0x91, 0xaf, 0xa0, 0x25, 0x11, 0x4f,
-	0x9c, 0x36, 0xfa, 0xe5, 0x23, 0xe5, 0x17, 0x05, 0x5d, 0x5f, 0xf9, 0xef, 0x59, 0xc8, 0xe3, 0x8c,
-	0xe6, 0xbc, 0x42, 0x39, 0x1e, 0x71, 0x6f, 0x92, 0x7b, 0xcf, 0xbf, 0xb8, 0x15, 0x76, 0x80, 0x54,
-	0xae, 0xe6, 0x88, 0x1c, 0x00, 0x48, 0xe0, 0x9e, 0xf8, 0x7e, 0xec, 0x74, 0xd0, 0x07, 0x1a, 0x45,
-	0xc4, 0x56, 0x8c, 0xde, 0xec, 0xca, 0xd8, 0xc2, 0x8d, 0x80, 0x72, 0x92, 0x8e, 0xaf, 0xb3, 0x33,
-	0x19, 0x8e, 0x5b, 0x12, 0x4a, 0x8d, 0x54, 0x38, 0xe9, 0x45, 0xf8, 0xdc, 0x0b, 0x42, 0xa7, 0xa7,
-	0x3b, 0x49, 0xd2, 0xc8, 0x31, 0x7c, 0x58, 0x83, 0x97, 0xba, 0x70, 0x0f, 0x2f, 0x2c, 0x67, 0x20,
-	0xdf, 0xc1, 0x7e, 0x1d, 0x74, 0xea, 0xfa, 0x7d, 0xbc, 0xbe, 0x82, 0x83, 0x1c, 0x43, 0x6f, 0x1a,
-	0x24, 0x49, 0x10, 0xbe, 0x51, 0x58, 0x3a, 0xdb, 0x88, 0xf4, 0xae, 0x42, 0xfa, 0xb9, 0x7e, 0x48,
-	0x2b, 0xbc, 0xfb, 0x14, 0xba, 0xba, 0x0b, 0x44, 0xb6, 0x5d, 0xb3, 0x4c, 0x05, 0xb1, 0x58, 0x92,
-	0xc7, 0x60, 0xbf, 0xf5, 0x26, 0x29, 0xc3, 0xe8, 0xed, 0x0c, 0xf6, 0x6a, 0x13, 0x2b, 0xa1, 0x92,
-	0xe9, 0x1b, 0xf3, 0x6b, 0xc3, 0x7d, 0x08, 0x5b, 0xa5, 0x47, 0x05, 0xf8, 0x3c, 0x98, 0xb2, 0x04,
-	0x73, 0xd3, 0xa6, 0x72, 0xe3, 0xfe, 0x63, 0xc0, 0x96, 0x0a, 0x83, 0x27, 0x63, 0x1e, 0x44, 0x21,
-	0xe9, 0x43, 0x53, 0x02, 0x8b, 0xef, 0x17, 0x26, 0x28, 0xae, 0xa7, 0x32, 0x33, 0x36, 0xa8, 0xe2,
-	0x22, 0x0f, 0xc1, 0xba, 0x4c, 0x33, 0xa5, 0xd8, 0x76, 0x99, 0x79, 0x98, 0x66, 0xa3, 0x0d, 0x2a,
-	0xce, 0xc9, 0x11, 0x34, 0x44, 0xe8, 0x63, 0x82, 0x75, 0x06, 0xa4, 0xcc, 0x27, 0xe0, 0x1c, 0x6d,
-	0x50, 0xe4, 0x20, 0x8f, 0xc0, 0x1e, 0x4f, 0xa2, 0x84, 0x61, 0xbe, 0x75, 0x06, 0x3b, 0x95, 0xf7,
-	0xc5, 0xd1, 0x68, 0x83, 0x4a, 0x1e, 0xd2, 0x03, 0x93, 0x67, 0x18, 0x93, 0x36, 0x35, 0x79, 0x36,
-	0x6c, 0x29, 0xa0, 0xdc, 0x57, 0x73, 0xbb, 0xa4, 0xc6, 0xd5, 0x8c, 0x35, 0xd6, 0x67, 0xac, 0xb9,
-	0x98, 0xb1, 0xee, 0x04, 0xa0, 0xb0, 0x6d, 0x7d, 0xcd, 0x51, 0xa5, 0xd7, 0x5c, 0x52, 0x7a, 0xad,
-	0x52, 0xe9, 0x5d, 0x2c, 0xb2, 0x8f, 0xa0, 0xa3, 0x21, 0xb4, 0xfa, 0x39, 0xf7, 0x31, 0x74, 0x75,
-	0x8c, 0xd6, 0x70, 0xff, 0x6b, 0x42, 0x8f, 0xb2, 0x31, 0x0b, 0x66, 0xfc, 0xfd, 0x2a, 0xe8, 0x01,
-	0xc0, 0x2c, 0x66, 0x6f, 0xcf, 0xe5, 0x99, 0x85, 0x67, 0x1a, 0x45, 0x54, 0x4f, 0x4f, 0x94, 0x83,
-	0x06, 0x0a, 0xc4, 0x75, 0x51, 0x08, 0x6c, 0xbd, 0x10, 0x14, 0xb8, 0x34, 0x4b, 0xb8, 0x14, 0x38,
-	0xb6, 0x4a, 0x38, 0x56, 0x0a, 0xc7, 0xe6, 0x62, 0xe1, 0x20, 0xd0, 0x10, 0x61, 0xee, 0xb4, 0x65,
-	0xe5, 0x16, 0x6b, 0x21, 0x8d, 0xdf, 0x8e, 0xbc, 0xe4, 0x0a, 0xa3, 0xa6, 0x4d, 0xd5, 0x8e, 0x7c,
-	0x0b, 0x90, 0xce, 0x7c, 0x8f, 0xb3, 0x93, 0xf0, 0x75, 0x84, 0xc5, 0x6b, 0xa1, 0x50, 0xbe, 0xc2,
-	0xf3, 0x61, 0x9a, 0x09, 0x16, 0xaa, 0xb1, 0xe7, 0xae, 0xeb, 0xce, 0x5d, 0x57, 0xf4, 0xd1, 0x2d,
-	0xad, 0x8f, 0xba, 0x7d, 0x01, 0xfa, 0xcf, 0x4a, 0x1c, 0xde, 0x5c, 0xed, 0xa5, 0x9f, 0x60, 0xbb,
-	0xe0, 0x57, 0x0f, 0xaf, 0xf1, 0x53, 0x8e, 0xb7, 0x59, 0x87, 0xb7, 0xa5, 0xe1, 0xed, 0xfe, 0x69,
-	0xc0, 0x6e, 0x49, 0xfa, 0x28, 0x48, 0x78, 0xb4, 0x36, 0x10, 0xee, 0xfc, 0x80, 0xa0, 0x8e, 0xd1,
-	0x6f, 0x0d, 0x8c, 0x0a, 0xb9, 0x11, 0xd2, 0xfd, 0x20, 0x66, 0x58, 0x6d, 0x30, 0x00, 0x6c, 0x5a,
-	0x10, 0x0a, 0xdc, 0x9a, 0x3a, 0x6e, 0x27, 0xb0, 0x53, 0x68, 0x7a, 0x2a, 0x3c, 0x7c, 0x07, 0x24,
-	0xe6, 0x4a, 0x99, 0x87, 0x56, 0x61, 0xf5, 0x2f, 0x06, 0xec, 0x55, 0x64, 0xdd, 0xcd, 0x6e, 0x4d,
-	0x5c, 0x9d, 0x8d, 0xd6, 0x52, 0x1b, 0x1b, 0x15, 0x1b, 0xdd, 0x3f, 0x50, 0x85, 0xd9, 0x24, 0x53,
-	0x4a, 0x9c, 0x45, 0xf1, 0xd4, 0x9b, 0xa0, 0x45, 0xd5, 0x79, 0xc4, 0xa8, 0x99, 0x47, 0x2a, 0x95,
-	0xcc, 0x5c, 0x5f, 0xc9, 0xac, 0x9a, 0xd9, 0xa3, 0xdc, 0xac, 0x1b, 0xd5, 0x66, 0xed, 0xfe, 0xd6,
-	0x80, 0x07, 0xba, 0x92, 0x4f, 0xd3, 0x38, 0x66, 0x21, 0x47, 0x2d, 0x8b, 0x5a, 0x60, 0x94, 0x6a,
-	0x41, 0x3e, 0x29, 0x99, 0xda, 0xa4, 0xb4, 0x64, 0xc6, 0xb1, 0xde, 0x7d, 0xc6, 0x69, 0xac, 0x98,
-	0x71, 0x96, 0x0c, 0x2b, 0xf6, 0xf2, 0x61, 0x65, 0xee, 0xce, 0xe6, 0x8a, 0x61, 0xa4, 0xb5, 0x58,
-	0x53, 0x56, 0x0e, 0x1a, 0x9b, 0xef, 0x37, 0x68, 0xb4, 0xd7, 0x0e, 0x1a, 0x15, 0xdf, 0xc3, 0x7a,
-	0xdf, 0x77, 0x6a, 0x7c, 0xbf, 0x38, 0xae, 0x74, 0xef, 0x3e, 0xae, 0xb8, 0x43, 0x38, 0xd0, 0x03,
-	0x43, 0x65, 0xcf, 0xa9, 0x86, 0x51, 0x05, 0x45, 0x03, 0xf3, 0x4f, 0x27, 0xb9, 0x27, 0xa2, 0xf4,
-	0x14, 0x32, 0xce, 0xaf, 0xa2, 0x1b, 0x8c, 0xac, 0xcf, 0x8b, 0x59, 0x55, 0x7e, 0x43, 0x3c, 0x58,
-	0x98, 0x28, 0x94, 0x56, 0x39, 0x9f, 0xfb, 0x0c, 0x76, 0xf2, 0x3c, 0x42, 0xd9, 0xc5, 0x87, 0x4f,
-	0x98, 0x3f, 0x5f, 0xdf, 0x4d, 0x4a, 0x5d, 0xd9, 0xfd, 0xdb, 0x80, 0xfb, 0xd5, 0x47, 0xde, 0x55,
-	0xc8, 0x92, 0x3a, 0x28, 0xda, 0x50, 0x36, 0xcb, 0x03, 0x18, 0xd7, 0x79, 0xc7, 0xb0, 0x6b, 0x3a,
-	0x86, 0x5e, 0xf9, 0xe6, 0x2d, 0xac, 0x55, 0xdb, 0xc2, 0x36, 0xf5, 0x16, 0xe6, 0xfe, 0x00, 0xdb,
-	0x55, 0x0b, 0x92, 0xff, 0x83, 0xe8, 0x74, 0x2e, 0x47, 0x84, 0xdf, 0x1a, 0x28, 0xea, 0xcb, 0x62,
-	0xae, 0xb6, 0x55, 0xab, 0x76, 0xa3, 0xa4, 0xf6, 0x08, 0xc8, 0xc2, 0x73, 0x09, 0x19, 0x54, 0xf5,
-	0x76, 0x16, 0x67, 0xc6, 0xaa, 0xe2, 0xc7, 0x73, 0x17, 0xca, 0x56, 0x4d, 0xd9, 0xb8, 0x80, 0xd5,
-	0xa8, 0xc2, 0x2a, 0x5c, 0x62, 0x16, 0x2e, 0xd1, 0xe0, 0x9b, 0xdf, 0x5e, 0x0f, 0xdf, 0x9c, 0xb5,
-	0xd0, 0xe2, 0x2f, 0x03, 0x76, 0xeb, 0x26, 0x06, 0x32, 0x84, 0xd6, 0xa5, 0x5c, 0x2a, 0x59, 0x47,
-	0x2b, 0xe6, 0x8b, 0xbe, 0xfa, 0x55, 0x5f, 0x65, 0xea, 0xe2, 0xfe, 0x05, 0x74, 0xf5, 0x83, 0x9a,
-	0x6f, 0x85, 0x7e, 0xf9, 0x5b, 0xc1, 0x59, 0xa2, 0x6f, 0xe9, 0x6b, 0xe1, 0x4b, 0x70, 0xf4, 0x74,
-	0xcc, 0x4b, 0x25, 0x7e, 0xb5, 0x39, 0xd0, 0x12, 0x3d, 0x9e, 0x25, 0x12, 0x81, 0x36, 0xcd, 0xb7,
-	0x97, 0x4d, 0xfc, 0xef, 0xe1, 0x8b, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xb7, 0x56, 0x83, 0xf5,
-	0x8c, 0x10, 0x00, 0x00,
+	// 4459 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcd, 0x1c, 0x4d, 0x6f, 0x24, 0x47,
+	0x75, 0x67, 0x7a, 0x7a, 0x66, 0x5c, 0xfe, 0xee, 0xb5, 0xbd, 0xbd, 0xde, 0x4d, 0x08, 0x4d, 0x12,
+	0x96, 0x64, 0xe3, 0xec, 0x3a, 0x1b, 0x05, 0x85, 0x8f, 0xb0, 0xde, 0x0f, 0x76, 0x89, 0x77, 0xb3,
+	0x69, 0x3b, 0x59, 0x89, 0x9c, 0xda, 0x33, 0x6d, 0x7b, 0xe4, 0x99, 0x69, 0xa7, 0xbb, 0x67, 0xed,
+	0x89, 0x38, 0x04, 0x21, 0x85, 0x2b, 0x1f, 0x39, 0x73, 0x40, 0x42, 0x42, 0x9c, 0x90, 0x90, 0x02,
+	0xb9, 0x00, 0x07, 0x2e, 0x1c, 0xe0, 0x86, 0x90, 0xb8, 0x20, 0x21, 0x21, 0xf1, 0x2b, 0x10, 0xf5,
+	0x5e, 0x55, 0x57, 0x57, 0x55, 0xd7, 0xcc, 0xb4, 0xbd, 0x1b, 0x91, 0x93, 0xbb, 0x5e, 0xbd, 0xfa,
+	0x7a, 0xdf, 0xf5, 0xea, 0x8d, 0xc9, 0x6c, 0x37, 0x4a, 0xd3, 0x30, 0x1e, 0xae, 0x1d, 0xc6, 0x51,
+	0x1a, 0x39, 0x76, 0x3a, 0x3c, 0x0c, 0x93, 0xd5, 0xc5, 0x34, 0x0e, 0xfa, 0x49, 0xd0, 0x4a, 0x3b,
+	0x51, 0x9f, 0xf5, 0x78, 0x7f, 0xaa, 0x90, 0xb9, 0x07, 0x83, 0xb8, 0xb5, 0x1f, 0x24, 0xa1, 0x1f,
+	0xb6, 0xa2, 0xb8, 0xed, 0xac, 0x90, 0x7a, 0xd0, 0x8b, 0x06, 0xfd, 0xd4, 0xad, 0x3c, 0x53, 0xb9,
+	0x64, 0xf9, 0xbc, 0x05, 0xf0, 0xfe, 0xa0, 0xb7, 0x13, 0xc6, 0x6e, 0x95, 0xc1, 0x59, 0xcb, 0x59,
+	0x22, 0x76, 0xa7, 0xdf, 0x0e, 0x8f, 0x5d, 0x0b, 0xc1, 0xac, 0xe1, 0x2c, 0x10, 0xeb, 0x28, 0x18,
+	0xba, 0x35, 0x84, 0xc1, 0xa7, 0xf3, 0x34, 0x21, 0xad, 0xa8, 0xd7, 0xeb, 0xa4, 0x77, 0x82, 0x64,
+	0xdf, 0xb5, 0x69, 0xc7, 0x8c, 0x2f, 0x41, 0x9c, 0x55, 0xd2, 0x8c, 0xc3, 0x47, 0x61, 0xd0, 0x0d,
+	0xdb, 0x6e, 0x9d, 0xf6, 0x36, 0x7d, 0xd1, 0x16, 0x63, 0x93, 0x84, 0x6e, 0xdd, 0x6d, 0xe0, 0xa4,
+	0x12, 0xc4, 0xfb, 0x59, 0x85, 0xcc, 0xab, 0xc7, 0x48, 0x9c, 0x97, 0x48, 0x3d, 0xc6, 0x4f, 0x7a,
+	0x0e, 0xeb, 0xd2, 0xf4, 0xfa, 0xf2, 0x1a, 0x52, 0x61, 0x4d, 0xc5, 0xf3, 0x39, 0x92, 0xe3, 0x92,
+	0xc6, 0xee, 0xa0, 0xdf, 0x7e, 0xd8, 0xe9, 0xf3, 0xf3, 0x65, 0x4d, 0xe7, 0x79, 0x32, 0xc7, 0x48,
+	0xf0, 0x56, 0x3f, 0xf4, 0xe9, 0xdf, 0x36, 0x3f, 0xa9, 0x06, 0x65, 0x07, 0x80, 0x41, 0xf4, 0x00,
+	0xb5, 0xec, 0x00, 0xac, 0xed, 0xfd, 0x77, 0x9e, 0x34, 0x36, 0x19, 0x4f, 0x9c, 0x8b, 0x64, 0x8a,
+	0xb3, 0xe7, 0x6e, 0x1b, 0x69, 0x3c, 0xe5, 0xe7, 0x00, 0x20, 0x73, 0x92, 0x06, 0xe9, 0x20, 0xc1,
+	0x6d, 0xd8, 0x3e, 0x6f, 0x39, 0x1e, 0x99, 0x69, 0xc5, 0x61, 0x90, 0x86, 0x77, 0xc2, 0xce, 0xde,
+	0x7e, 0xca, 0xf7, 0xa0, 0xc0, 0x1c, 0x87, 0xd4, 0x60, 0x3d, 0x4e, 0x75, 0xfc, 0x76, 0x9e, 0x21,
+	0xd3, 0x87, 0x83, 0x78, 0xa3, 0x1b, 0xb5, 0x0e, 0xee, 0x0f, 0x7a, 0x48, 0x77, 0xcb, 0x97, 0x41,
+	0x30, 0x73, 0x3b, 0x0e, 0x8e, 0x04, 0x4a, 0x9d, 0xcd, 0x2c, 0xc3, 0x9c, 0x2b, 0xe4, 0x6c, 0x37,
+	0x48, 0xd2, 0x6d, 0x10, 0xa0, 0xed, 0x88, 0x92, 0x70, 0x8b, 0xee, 0x2a, 0xe4, 0x9c, 0x30, 0x75,
+	0x39, 0xeb, 0x64, 0x49, 0x02, 0xdf, 0xa4, 0x93, 0xb1, 0x21, 0x4d, 0x1c, 0x62, 0xec, 0x73, 0x5e,
+	0x25, 0x0d, 0xc6, 0x8d, 0xc4, 0x9d, 0x42, 0x9e, 0x5d, 0xe0, 0x3c, 0xe3, 0xa4, 0x5b, 0xe3, 0xbc,
+	0xbd, 0xd5, 0x4f, 0xe3, 0xa1, 0x9f, 0xe1, 0xc2, 0xe6, 0xd2, 0x28, 0x0d, 0xba, 0x19, 0x67, 0xdb,
+	0xdb, 0xc7, 0x70, 0x0e, 0xc2, 0x36, 0x67, 0xe8, 0x42, 0x79, 0x42, 0xc2, 0x5d, 0x6f, 0xb7, 0x63,
+	0x77, 0x1a, 0x79, 0x20, 0x41, 0x40, 0xa6, 0x63, 0xe4, 0xf4, 0x0c, 0x93, 0x69, 0x6c, 0x00, 0x29,
+	0xbb, 0x83, 0xd6, 0xc1, 0xf0, 0x3e, 0x53, 0x83, 0x59, 0x46, 0x4a, 0x09, 0x94, 0x33, 0xe9, 0xad,
+	0xfe, 0xbd, 0x80, 0x4a, 0xd2, 0x9c, 0xcc, 0x24, 0x06, 0x73, 0xbe, 0x4e, 0xce, 0x1b, 0xe8, 0xc5,
+	0x07, 0xcc, 0xe3, 0x80, 0xd1, 0x08, 0xce, 0x37, 0xc9, 0xaa, 0x89, 0x74, 0x7c, 0xf8, 0x02, 0x0e,
+	0x1f, 0x83, 0x41, 0x57, 0x9f, 0x43, 0xa5, 0xe9, 0xef, 0x71, 0x5a, 0xba, 0x8b, 0x48, 0xe9, 0x25,
+	0x4e, 0xe9, 0x7b, 0x72, 0xa7, 0xaf, 0xe1, 0x3a, 0x97, 0xc8, 0x7c, 0x74, 0x98, 0xd1, 0x72, 0xb3,
+	0x43, 0x55, 0xd7, 0x75, 0x70, 0x49, 0x1d, 0x0c, 0x98, 0x78, 0xea, 0x28, 0xbe, 0x1d, 0x86, 0x7e,
+	0x40, 0x4d, 0x8e, 0x7b, 0x96, 0x61, 0x6a, 0x60, 0xe0, 0xc5, 0x61, 0xdc, 0xf9, 0x80, 0x23, 0x2d,
+	0xd1, 0xdd, 0x50, 0xdd, 0xce, 0x21, 0xa0, 0x2e, 0xbd, 0xe0, 0x18, 0x55, 0x2c, 0x71, 0x97, 0x71,
+	0x8e, 0x1c, 0x00, 0x6a, 0xdb, 0xea, 0x46, 0xb0, 0x47, 0x77, 0x05, 0x75, 0x2e, 0x6b, 0x82, 0xda,
+	0x32, 0xfb, 0x21, 0x04, 0xfb, 0x1c, 0x53, 0x5b, 0x15, 0xea, 0x3c, 0x4b, 0x66, 0x19, 0x64, 0xbb,
+	0xd3, 0x0b, 0xa3, 0x41, 0xea, 0xba, 0x88, 0xa6, 0x02, 0x01, 0x2b, 0x65, 0x9f, 0x3e, 0xea, 0xb4,
+	0x7b, 0x1e, 0x57, 0x53, 0x81, 0x9a, 0x8d, 0x5b, 0x2d, 0xd8, 0x38, 0x90, 0x0f, 0xd6, 0x62, 0x4a,
+	0x7c, 0x81, 0xcb, 0x87, 0x04, 0xcb, 0xe7, 0x40, 0xd9, 0xbc, 0xc8, 0x65, 0x53, 0x40, 0x60, 0x8e,
+	0x38, 0xea, 0x76, 0xa3, 0x47, 0x61, 0xfc, 0x20, 0x8a, 0xba, 0xee, 0x53, 0x6c, 0x0e, 0x19, 0xe6,
+	0xbc, 0x40, 0x16, 0xb2, 0xf6, 0x76, 0xb4, 0x31, 0x18, 0x86, 0x71, 0xe2, 0x3e, 0x8d, 0x1b, 0x2e,
+	0xc0, 0x41, 0xaa, 0xd3, 0xe8, 0x20, 0xec, 0x6f, 0x0d, 0x7b, 0x3b, 0x74, 0xba, 0x2f, 0xe0, 0x82,
+	0x32, 0x08, 0x76, 0x14, 0x26, 0xad, 0x38, 0x3a, 0xc2, 0x1d, 0x3d, 0xc3, 0x76, 0x94, 0x43, 0xa0,
+	0x1f, 0x95, 0x6c, 0x8b, 0xda, 0xea, 0xc4, 0xfd, 0x22, 0xb3, 0xce, 0x39, 0xc4, 0x59, 0x23, 0x0e,
+	0x18, 0x93, 0x9b, 0x61, 0xd0, 0xee, 0x76, 0xfa, 0x21, 0x52, 0x3e, 0x71, 0x3d, 0xc4, 0x33, 0xf4,
+	0x80, 0xec, 0x00, 0xd4, 0x0f, 0x8f, 0x02, 0x2a, 0x83, 0x28, 0x16, 0x5f, 0x62, 0xb2, 0xa3, 0x81,
+	0x81, 0xc7, 0xbd, 0x4e, 0x3f, 0x93, 0x3c, 0xe0, 0xf1, 0xb3, 0x8c, 0xc7, 0x2a, 0x94, 0xe3, 0xe1,
+	0x6e, 0xae, 0x33, 0xdf, 0xf6, 0x9c, 0xc0, 0x93, 0xa0, 0xc0, 0x65, 0x2a, 0x5a, 0x0f, 0x83, 0x4e,
+	0xca, 0x37, 0xf9, 0x3c, 0x93, 0x05, 0x05, 0xc8, 0x24, 0x0b, 0xf8, 0xbd, 0x11, 0x76, 0xa3, 0xa3,
+	0x7b, 0x54, 0xef, 0xbe, 0x8c, 0xb4, 0xd5, 0xa0, 0x20, 0x9b, 0xb0, 0x61, 0x20, 0xfe, 0x25, 0x2a,
+	0xd6, 0x53, 0x7e, 0xd6, 0x04, 0xfb, 0x12, 0xb4, 0xe9, 0xda, 0xee, 0x57, 0x90, 0x98, 0xac, 0x01,
+	0x9c, 0x00, 0xe1, 0xcd, 0x2c, 0xfc, 0x0b, 0xcc, 0xbe, 0x48, 0x20, 0xa0, 0x0c, 0x35, 0x7a, 0xdd,
+	0xa0, 0xd3, 0x13, 0x42, 0xfd, 0x22, 0xa3, 0x8c, 0x06, 0x06, 0xad, 0xe1, 0x20, 0xea, 0x8d, 0x2e,
+	0xe3, 0xf6, 0x72, 0x00, 0xf4, 0xee, 0x74, 0x83, 0xd6, 0x41, 0xb7, 0x93, 0xa4, 0xee, 0x4b, 0xb8,
+	0xb7, 0x1c, 0x00, 0xfb, 0xa0, 0x07, 0xa6, 0xe2, 0xf1, 0x80, 0x8a, 0xc9, 0xb1, 0xbb, 0xc6, 0xf6,
+	0x21, 0x81, 0x50, 0xbb, 0x85, 0xf7, 0x65, 0x1c, 0x7a, 0x99, 0x6b, 0xb7, 0x0a, 0x76, 0x2e, 0x93,
+	0xc5, 0xdd, 0x28, 0xde, 0xe9, 0xb4, 0xb7, 0xc2, 0xee, 0x2e, 0xe5, 0x73, 0x17, 0x34, 0xf5, 0x0a,
+	0xee, 0xa7, 0xd8, 0x01, 0xfb, 0x0a, 0x92, 0x24, 0x4c, 0x6f, 0x1d, 0x87, 0x2d, 0xf7, 0x2a, 0x73,
+	0x8d, 0x02, 0x00, 0x0e, 0xf6, 0x08, 0xe9, 0x40, 0x8f, 0xb4, 0xce, 0x1c, 0x6c, 0xd6, 0x06, 0x9e,
+	0x1c, 0x06, 0x43, 0xaa, 0x89, 0xf7, 0xa8, 0xb7, 0x8c, 0x3b, 0xe9, 0xd0, 0x7d, 0x85, 0x71, 0x58,
+	0x85, 0x82, 0xf6, 0x00, 0x89, 0xc2, 0xf6, 0x03, 0x84, 0xbb, 0xd7, 0x98, 0xf6, 0xc8, 0xb0, 0x55,
+	0x9f, 0xcc, 0xc8, 0x8e, 0x06, 0x62, 0x99, 0x83, 0x70, 0xc8, 0x5d, 0x35, 0x7c, 0xd2, 0x53, 0xd9,
+	0x8f, 0x82, 0xee, 0x20, 0x44, 0x1f, 0x3d, 0xbd, 0xbe, 0x62, 0x0c, 0x2d, 0x12, 0x9f, 0x21, 0xbd,
+	0x5e, 0xfd, 0x6a, 0xc5, 0x7b, 0x8e, 0xcc, 0x2a, 0xa6, 0x15, 0x44, 0x00, 0x6c, 0x47, 0x82, 0xd1,
+	0x89, 0xed, 0xb3, 0x86, 0xf7, 0x07, 0x9b, 0xcc, 0x72, 0x67, 0x77, 0x1d, 0xe3, 0x34, 0xaa, 0x3c,
+	0x75, 0xe6, 0x3e, 0x70, 0xfd, 0xdc, 0x50, 0x73, 0xac, 0x1b, 0xcc, 0xff, 0x9f, 0xf1, 0x39, 0x96,
+	0xf3, 0x1c, 0xb1, 0x76, 0x06, 0x43, 0xbe, 0xb1, 0x45, 0x15, 0x99, 0xf2, 0x8f, 0x62, 0x42, 0x3f,
+	0xe5, 0x60, 0x0d, 0x84, 0x11, 0xc3, 0x88, 0xe9, 0x75, 0x47, 0xc5, 0x03, 0xa7, 0x41, 0x11, 0x11,
+	0xc3, 0x79, 0x91, 0xd8, 0x28, 0x82, 0x18, 0x55, 0x4c, 0xaf, 0x9f, 0xd5, 0xd6, 0x47, 0xe9, 0x3c,
+	0xe3, 0x33, 0x1c, 0xdc, 0x2d, 0x9a, 0x2a, 0x0c, 0x34, 0x8a, 0xbb, 0x65, 0x86, 0x0e, 0x76, 0x8b,
+	0x5f, 0x80, 0xcf, 0xec, 0x2c, 0x46, 0x1d, 0x05, 0x7c, 0x1f, 0xfb, 0x00, 0x9f, 0x61, 0x39, 0xdf,
+	0xa2, 0xc6, 0x0f, 0xbf, 0xb8, 0x0f, 0x6e, 0xe0, 0xa8, 0x55, 0xd3, 0x28, 0x86, 0x41, 0xc7, 0x2a,
+	0x23, 0x60, 0xc5, 0x5e, 0xd4, 0xee, 0xec, 0x0e, 0x31, 0x12, 0x29, 0xac, 0x78, 0x0f, 0xfb, 0x60,
+	0x45, 0x86, 0xe5, 0x5c, 0x23, 0x4d, 0x0c, 0x9b, 0x77, 0xe9, 0x6a, 0x53, 0x0a, 0xb7, 0xf9, 0x88,
+	0x6d, 0xde, 0x4b, 0xc7, 0x08, 0x4c, 0xe7, 0x2a, 0x46, 0x32, 0xa0, 0x6d, 0x18, 0x5d, 0xe4, 0xd1,
+	0xa7, 0xd8, 0x22, 0x76, 0xd2, 0x31, 0x19, 0x9e, 0xf3, 0x9a, 0xac, 0x93, 0x33, 0x38, 0xe8, 0x9c,
+	0xc6, 0xbe, 0xac, 0x9b, 0x0e, 0x93, 0xd4, 0x15, 0x19, 0x04, 0x2b, 0xcd, 0x9a, 0x19, 0xc4, 0xd6,
+	0x61, 0x38, 0xce, 0x6d, 0x32, 0x97, 0x6d, 0x72, 0xbb, 0x43, 0x65, 0x3e, 0xc5, 0x18, 0x65, 0x7a,
+	0xfd, 0xa2, 0xf9, 0x50, 0x0c, 0x87, 0x0e, 0xd7, 0x46, 0x39, 0x73, 0xa4, 0x4a, 0x75, 0x8c, 0x60,
+	0x88, 0x4a, 0xbf, 0x36, 0x1a, 0x5c, 0x23, 0xbc, 0x7f, 0x36, 0x84, 0x04, 0x33, 0xd9, 0xd4, 0x23,
+	0xd0, 0xca, 0xe4, 0x08, 0xb4, 0x6a, 0x88, 0x40, 0x0d, 0xa1, 0x87, 0x55, 0x3a, 0xf4, 0xa8, 0x95,
+	0x09, 0x3d, 0xec, 0xf1, 0xa1, 0x47, 0x5d, 0x0f, 0x3d, 0x8a, 0x01, 0x46, 0xa3, 0x5c, 0x80, 0xd1,
+	0x2c, 0x15, 0x60, 0x4c, 0x99, 0x02, 0x0c, 0x93, 0x63, 0x27, 0xe5, 0x1c, 0xfb, 0x74, 0xd1, 0xb1,
+	0x9b, 0x1d, 0xf3, 0xcc, 0x49, 0x1c, 0xf3, 0x6c, 0x59, 0xc7, 0x3c, 0x57, 0xd2, 0x31, 0xcf, 0x97,
+	0x73, 0xcc, 0x0b, 0xe5, 0x1c, 0xf3, 0xe2, 0x24, 0xc7, 0xec, 0xa8, 0x8e, 0xd9, 0xe0, 0x60, 0xcf,
+	0x8e, 0x74, 0xb0, 0xb9, 0xba, 0x2e, 0x4d, 0x70, 0xa1, 0xcb, 0xa5, 0x5c, 0xe8, 0xca, 0x09, 0x5c,
+	0xe8, 0xb9, 0x52, 0x2e, 0xd4, 0x1d, 0xe7, 0x42, 0xcf, 0x4f, 0x74, 0xa1, 0xab, 0x26, 0x17, 0xea,
+	0xfd, 0xbd, 0x42, 0x48, 0xee, 0x50, 0x26, 0x5f, 0x67, 0x79, 0x36, 0xa1, 0x3a, 0x22, 0x9b, 0x60,
+	0x29, 0xd9, 0x84, 0x62, 0xde, 0x80, 0x9a, 0xb7, 0x4e, 0x1a, 0xf6, 0x12, 0xd4, 0xcf, 0x82, 0x21,
+	0xa5, 0x3b, 0xb8, 0x4b, 0x7b, 0x7d, 0x86, 0xa3, 0x05, 0xe0, 0xf5, 0x42, 0x00, 0x0e, 0xd4, 0xd9,
+	0x0b, 0xfb, 0x2c, 0xb6, 0x6e, 0x70, 0xea, 0x64, 0x00, 0x6f, 0x9f, 0xcc, 0xa9, 0xd3, 0x4a, 0xdb,
+	0xac, 0x28, 0xdb, 0x1c, 0x75, 0x2c, 0xbe, 0x7d, 0x2b, 0xdf, 0xbe, 0x48, 0x8f, 0xd4, 0xa4, 0xf4,
+	0x88, 0xf7, 0x22, 0x99, 0x96, 0x7c, 0xed, 0x78, 0x1a, 0x7a, 0x97, 0xc9, 0x8c, 0xec, 0x6d, 0x27,
+	0x60, 0x5f, 0xcf, 0xed, 0x2f, 0xf3, 0xb1, 0xe3, 0x19, 0xe4, 0x90, 0xda, 0x3e, 0xd0, 0xaa, 0x8a,
+	0xb4, 0xc2, 0x6f, 0xef, 0x96, 0x98, 0x82, 0xb9, 0xd2, 0x12, 0x29, 0x8b, 0x90, 0xda, 0xd6, 0x94,
+	0x4f, 0xc2, 0x5b, 0x5e, 0x40, 0xce, 0x1a, 0x3c, 0xf2, 0xe4, 0xc9, 0x46, 0xa5, 0x99, 0xfa, 0x51,
+	0xbf, 0x15, 0x22, 0x6d, 0x67, 0x7c, 0xd6, 0xf0, 0x12, 0xb1, 0x53, 0xe6, 0xb8, 0x27, 0x4c, 0x4e,
+	0xc5, 0x23, 0x68, 0xb7, 0x6f, 0x72, 0xdd, 0xaf, 0xa2, 0xd6, 0x4a, 0x10, 0x66, 0xaa, 0x7b, 0xd4,
+	0x8a, 0x66, 0x28, 0x16, 0xa2, 0xa8, 0x40, 0xef, 0x0d, 0x32, 0xaf, 0xb9, 0xc9, 0x09, 0xcb, 0x82,
+	0xb3, 0x8c, 0xf0, 0x3c, 0x53, 0xd4, 0x59, 0x46, 0xde, 0x9a, 0x90, 0x33, 0x1e, 0x07, 0x4c, 0x60,
+	0xe9, 0x86, 0x24, 0x00, 0x13, 0xb1, 0xf3, 0xe4, 0x45, 0x55, 0x4a, 0x5e, 0x78, 0xef, 0x91, 0x65,
+	0xa3, 0x6f, 0x9f, 0x3c, 0x19, 0x13, 0xdf, 0xaa, 0x9c, 0xdd, 0x63, 0x07, 0xb2, 0xc4, 0x81, 0xbe,
+	0x4b, 0x16, 0xf4, 0x18, 0x65, 0xc2, 0xbc, 0x54, 0x51, 0x28, 0xdd, 0x39, 0x0b, 0xe0, 0x13, 0x18,
+	0xcf, 0xc8, 0xcc, 0x89, 0xce, 0x5b, 0xde, 0x4f, 0x6d, 0x32, 0x47, 0xc9, 0x14, 0x76, 0x0e, 0xd3,
+	0xc7, 0xcb, 0xa0, 0xa1, 0xb7, 0x0f, 0x1f, 0x6d, 0xb1, 0x3e, 0x0b, 0xfb, 0x24, 0x08, 0x68, 0x42,
+	0x00, 0x66, 0xa1, 0x86, 0x13, 0xe2, 0x77, 0x4e, 0x4b, 0x5b, 0x4e, 0x04, 0xe5, 0x32, 0x5a, 0x1f,
+	0x61, 0x15, 0x1a, 0x8a, 0x55, 0xd0, 0x12, 0x47, 0xcd, 0x62, 0xe2, 0x88, 0xae, 0x0d, 0x8e, 0x1e,
+	0x9d, 0xbe, 0xe5, 0xe3, 0x37, 0xcc, 0x96, 0x1e, 0xa3, 0x1d, 0x23, 0xb8, 0x23, 0xde, 0x72, 0xbe,
+	0x46, 0xc8, 0xe0, 0xb0, 0x4d, 0x23, 0xab, 0xbb, 0xfd, 0xdd, 0x88, 0x87, 0x97, 0x5a, 0xa2, 0xec,
+	0x1d, 0xec, 0x07, 0x23, 0x46, 0x51, 0x7c, 0x09, 0x3d, 0x33, 0x50, 0x33, 0x06, 0x03, 0x35, 0x2b,
+	0x73, 0xf8, 0x2a, 0x69, 0xee, 0x30, 0x1b, 0x98, 0x50, 0xd7, 0x3d, 0xc6, 0xf0, 0x0a, 0x34, 0xcc,
+	0x7f, 0xf2, 0x18, 0x84, 0x7b, 0x71, 0xd1, 0xd6, 0xec, 0xf2, 0x82, 0x31, 0x31, 0x22, 0x67, 0x37,
+	0x17, 0x0d, 0xd9, 0xcd, 0x57, 0xc9, 0x14, 0xb8, 0xe9, 0x07, 0x71, 0x14, 0xed, 0x62, 0xda, 0xa9,
+	0x10, 0x20, 0xdf, 0xcc, 0xba, 0xfd, 0x1c, 0x13, 0xc8, 0xb8, 0xcf, 0x26, 0x65, 0x9e, 0x9c, 0xb7,
+	0x54, 0x57, 0xb0, 0xa4, 0xb9, 0x02, 0x2d, 0xe3, 0xbc, 0x5c, 0xc8, 0x38, 0xa7, 0xc4, 0x55, 0x85,
+	0xf2, 0x86, 0x88, 0x2d, 0x4f, 0xa3, 0x9e, 0x42, 0xf8, 0x2c, 0x49, 0xf8, 0x28, 0xaf, 0x76, 0xc3,
+	0x30, 0xf3, 0x85, 0xf4, 0xd3, 0xfb, 0x40, 0x5f, 0xf5, 0xa6, 0x88, 0xbb, 0x9e, 0xd8, 0xaa, 0xa8,
+	0x87, 0x30, 0x23, 0x5f, 0x98, 0xb7, 0xbc, 0x0f, 0xab, 0x64, 0x49, 0x5d, 0xbc, 0x94, 0xc9, 0x2d,
+	0xbf, 0xb0, 0x6a, 0x9c, 0x6b, 0x93, 0x8d, 0xb3, 0x6d, 0x30, 0xce, 0x72, 0x6c, 0x57, 0x57, 0x63,
+	0xbb, 0x4c, 0xc7, 0x1a, 0x46, 0x1d, 0x6b, 0x2a, 0x3a, 0x26, 0x94, 0x62, 0x4a, 0xf6, 0xda, 0x3e,
+	0x39, 0xef, 0x87, 0x87, 0xdd, 0xa1, 0x72, 0xfe, 0x2c, 0x37, 0x2a, 0x25, 0xaf, 0x2b, 0x4a, 0xf2,
+	0xda, 0x44, 0x34, 0x91, 0xbc, 0xf6, 0xfe, 0x51, 0x21, 0x2b, 0x2a, 0x46, 0x49, 0xa7, 0x62, 0x26,
+	0x6c, 0x6e, 0xfc, 0x2c, 0xc5, 0xf8, 0xd1, 0xb9, 0xc0, 0xd4, 0x5d, 0xc7, 0xac, 0x13, 0xb3, 0x70,
+	0x39, 0x20, 0xcf, 0x47, 0xd9, 0x72, 0x3e, 0x2a, 0x23, 0x58, 0xdd, 0x48, 0xb0, 0x86, 0x99, 0x60,
+	0x4d, 0x99, 0x60, 0xff, 0xa9, 0x90, 0x8b, 0xda, 0xe1, 0xd0, 0xe9, 0x3c, 0xd6, 0x11, 0xcd, 0x0f,
+	0x4e, 0xf0, 0xf6, 0x11, 0x47, 0xbd, 0xcc, 0x7a, 0xc3, 0x37, 0x77, 0x53, 0x76, 0xe6, 0xa6, 0x4e,
+	0x6c, 0xb7, 0x33, 0x02, 0x34, 0x8d, 0x04, 0x98, 0x92, 0x09, 0xe0, 0x7d, 0x5a, 0x21, 0xcb, 0xea,
+	0x51, 0x4b, 0xf9, 0xf6, 0x93, 0x29, 0x26, 0xdf, 0x65, 0xcd, 0xb8, 0x4b, 0xdb, 0xb8, 0xcb, 0xba,
+	0x99, 0x4d, 0x0d, 0x99, 0x4d, 0xff, 0xaa, 0xe8, 0xaa, 0xcd, 0x32, 0x61, 0x9f, 0xf9, 0xd6, 0xe1,
+	0x12, 0x04, 0x34, 0x0a, 0x76, 0xba, 0x99, 0xe5, 0xb7, 0xf9, 0x25, 0x48, 0x05, 0x3f, 0x01, 0x59,
+	0xfc, 0x5b, 0x45, 0xc4, 0x8a, 0x9f, 0xbb, 0xd3, 0xe1, 0x2b, 0x06, 0xcb, 0xd5, 0xd6, 0xb3, 0x57,
+	0x0c, 0x96, 0xa9, 0xc5, 0x9c, 0x30, 0xfd, 0xdc, 0x96, 0x0f, 0x2a, 0x83, 0x68, 0xb0, 0xbe, 0xe8,
+	0x87, 0xef, 0x2b, 0x27, 0x4b, 0x26, 0xc7, 0xfc, 0x78, 0x88, 0x6a, 0x7e, 0x08, 0x6f, 0x8f, 0x9c,
+	0x95, 0x6d, 0x5b, 0x36, 0xd1, 0x1a, 0x69, 0xb0, 0xeb, 0x5f, 0x66, 0xd5, 0xb4, 0x7c, 0x19, 0xc3,
+	0xf3, 0x33, 0x24, 0x3d, 0xe9, 0x50, 0x2d, 0x24, 0x1d, 0xbc, 0xbf, 0x54, 0xc8, 0x39, 0x55, 0xd8,
+	0xca, 0xc6, 0x8c, 0x27, 0xf2, 0x9c, 0x10, 0x5d, 0xd6, 0x4c, 0xd1, 0xa5, 0x2d, 0x47, 0x97, 0x4f,
+	0x40, 0xae, 0xde, 0x25, 0x17, 0x64, 0xc2, 0x65, 0xa6, 0x2d, 0x73, 0x0b, 0xaf, 0xe9, 0x6e, 0xe1,
+	0x29, 0xa3, 0x5b, 0x10, 0xc3, 0x84, 0x63, 0xf0, 0x89, 0x23, 0xee, 0x71, 0xfd, 0xdd, 0xce, 0xde,
+	0xed, 0x4e, 0xd8, 0xc5, 0xd3, 0xf6, 0x83, 0x5e, 0xc8, 0x89, 0x83, 0xdf, 0xd2, 0xd9, 0xaa, 0xca,
+	0xd9, 0x38, 0x15, 0x2c, 0x41, 0x05, 0xef, 0xa3, 0xaa, 0xb8, 0x92, 0xb1, 0x49, 0x6f, 0xec, 0x07,
+	0xfd, 0xbd, 0x70, 0x72, 0x40, 0xcd, 0x23, 0xa8, 0xaa, 0x12, 0x41, 0x99, 0x0d, 0xb1, 0xe0, 0x52,
+	0xcd, 0xc4, 0x25, 0x5b, 0xe2, 0x12, 0x0d, 0x18, 0x59, 0x31, 0xc2, 0xf6, 0x10, 0xe9, 0x6f, 0xfb,
+	0xa2, 0x4d, 0xe3, 0xcf, 0xfa, 0x2e, 0x1c, 0x38, 0xa1, 0x3c, 0x00, 0xaa, 0x9d, 0xd7, 0x13, 0xc9,
+	0x82, 0x24, 0x3e, 0x47, 0x3c, 0x91, 0xb5, 0x7e, 0x5b, 0xf5, 0xe4, 0x6c, 0xba, 0x3b, 0x54, 0x04,
+	0xa3, 0x18, 0x52, 0xbe, 0x1a, 0xcb, 0x56, 0x4d, 0x8b, 0x33, 0xd2, 0xe5, 0xfc, 0xfa, 0x7d, 0xc1,
+	0x88, 0xf2, 0x9c, 0xdd, 0xe7, 0xc9, 0xfe, 0xcb, 0x31, 0x7a, 0x43, 0x8d, 0xd1, 0xe1, 0x56, 0x9a,
+	0x1b, 0x12, 0xbc, 0x2c, 0x8c, 0xbf, 0x95, 0xbe, 0x27, 0x1b, 0x1e, 0x7e, 0xd7, 0x38, 0xb9, 0xe1,
+	0xc9, 0x09, 0x60, 0xc9, 0xd7, 0xd5, 0x5f, 0x22, 0x35, 0xa5, 0xd9, 0x33, 0xe6, 0x3c, 0xa1, 0x05,
+	0x00, 0xda, 0x12, 0xc4, 0xb4, 0x7d, 0xd6, 0x80, 0xd9, 0xdb, 0x1d, 0xca, 0x51, 0x90, 0x42, 0x24,
+	0xa8, 0xed, 0xe7, 0x80, 0x5c, 0xe0, 0xeb, 0xb2, 0x01, 0xb8, 0x0b, 0x96, 0x33, 0xdb, 0xe9, 0x26,
+	0x5c, 0xea, 0x4a, 0x50, 0x42, 0x62, 0xbb, 0x95, 0x9f, 0xfa, 0x43, 0x0c, 0x06, 0x95, 0xb9, 0xca,
+	0x9d, 0x7b, 0x64, 0xa4, 0xc4, 0xce, 0x68, 0x8d, 0x3c, 0x63, 0x4d, 0x3b, 0xa3, 0xf7, 0x57, 0x0b,
+	0xb6, 0x90, 0xab, 0xc6, 0xfd, 0x28, 0xee, 0x05, 0x5d, 0x3c, 0x91, 0x7e, 0x49, 0xab, 0x18, 0x2e,
+	0x69, 0x5a, 0xb2, 0xbf, 0x3a, 0x39, 0xd9, 0x6f, 0x19, 0x92, 0xfd, 0x6a, 0x7d, 0x46, 0xad, 0x50,
+	0x9f, 0xa1, 0x79, 0x19, 0xbb, 0x98, 0xda, 0x2e, 0x26, 0xa0, 0xeb, 0x25, 0x13, 0xd0, 0x8d, 0x72,
+	0x09, 0xe8, 0x66, 0xb9, 0x04, 0xf4, 0xd4, 0xa4, 0x04, 0x34, 0x19, 0xf1, 0x32, 0x3c, 0x2d, 0x47,
+	0xe2, 0x17, 0xd5, 0xb7, 0x21, 0x2d, 0xd9, 0xac, 0xa4, 0x7c, 0x67, 0xb5, 0x94, 0xaf, 0xf7, 0x69,
+	0x0d, 0xfc, 0xad, 0x64, 0xeb, 0x06, 0x71, 0x4c, 0x6f, 0xb9, 0xc8, 0xd1, 0xfc, 0xb6, 0x50, 0x51,
+	0x6e, 0x0b, 0x59, 0x21, 0x51, 0x55, 0x2a, 0x24, 0x1a, 0x51, 0x02, 0x64, 0x9d, 0xbc, 0x04, 0xa8,
+	0x36, 0xa6, 0x04, 0x68, 0x44, 0x2d, 0x8f, 0x3d, 0xba, 0x96, 0x47, 0x88, 0x7e, 0x7d, 0x4c, 0xad,
+	0x4e, 0xa3, 0x98, 0x72, 0x19, 0x5b, 0x87, 0xd3, 0x7c, 0xbc, 0x3a, 0x9c, 0xa9, 0x89, 0x75, 0x38,
+	0x9a, 0x9e, 0x90, 0xc9, 0x7a, 0x32, 0x6d, 0xd0, 0x93, 0x62, 0x35, 0xcf, 0xcc, 0x09, 0xaa, 0x79,
+	0x34, 0x2d, 0x9a, 0x2d, 0xc6, 0x6a, 0x1b, 0xe4, 0x69, 0x59, 0x74, 0xb8, 0x2d, 0xda, 0x94, 0xa8,
+	0xa8, 0xd1, 0xb9, 0x82, 0xd6, 0x4c, 0x06, 0x51, 0xf3, 0xb8, 0x24, 0xcf, 0xb1, 0xb5, 0x1f, 0x1d,
+	0xa1, 0xec, 0x5d, 0xd5, 0xbd, 0xec, 0xb9, 0x42, 0x82, 0x89, 0xef, 0x5b, 0xb8, 0xd8, 0x5b, 0x22,
+	0x7a, 0x61, 0x73, 0xe7, 0x15, 0x8b, 0x27, 0x49, 0xd2, 0x7b, 0x1f, 0x57, 0xf3, 0x74, 0x65, 0xb6,
+	0xc8, 0x89, 0x33, 0xfd, 0x66, 0xaf, 0x02, 0xbe, 0x98, 0x1e, 0x22, 0xab, 0xc0, 0x83, 0xef, 0x2c,
+	0xe5, 0x66, 0x1b, 0x52, 0x6e, 0x75, 0xed, 0x06, 0x5b, 0x3a, 0x3f, 0xa1, 0xe6, 0xd3, 0xa6, 0xc6,
+	0x16, 0x53, 0x12, 0xad, 0x98, 0x12, 0xc3, 0xc5, 0x64, 0xd0, 0x4d, 0x51, 0xa4, 0x6c, 0x9f, 0xb7,
+	0xbc, 0x7d, 0xb2, 0xa8, 0x53, 0x25, 0x39, 0x05, 0x97, 0x4a, 0x5c, 0x01, 0x7a, 0x62, 0x25, 0x96,
+	0xbf, 0x1a, 0xcb, 0x80, 0x91, 0x01, 0x12, 0x12, 0xcb, 0x32, 0x12, 0xab, 0xa6, 0x04, 0x7b, 0x77,
+	0x44, 0x24, 0x9d, 0x2f, 0x97, 0x50, 0xeb, 0xa4, 0x9d, 0xcc, 0x2d, 0x26, 0x13, 0x75, 0x01, 0xdc,
+	0x16, 0x82, 0xc3, 0x32, 0xac, 0xb4, 0x3f, 0x67, 0x66, 0x45, 0x67, 0x26, 0x08, 0x42, 0x55, 0x12,
+	0x84, 0x5c, 0x94, 0x2c, 0x45, 0x1e, 0x6f, 0x0b, 0x72, 0x88, 0x59, 0x27, 0x13, 0x5e, 0xa0, 0xe6,
+	0xbb, 0xfb, 0x35, 0x8d, 0x99, 0x4c, 0x09, 0x60, 0x67, 0x83, 0x34, 0x76, 0xd8, 0x27, 0x9f, 0xeb,
+	0xd2, 0x98, 0x74, 0xf1, 0x1a, 0xff, 0xcb, 0x8b, 0x2c, 0xf9, 0xc0, 0xd5, 0x6d, 0x32, 0x23, 0x77,
+	0x18, 0x8a, 0x62, 0xd6, 0xd4, 0xa2, 0x18, 0x77, 0xc4, 0x7e, 0x95, 0xb2, 0x98, 0x6b, 0x90, 0xd0,
+	0x94, 0x6e, 0x9d, 0xdc, 0xb4, 0xa3, 0x93, 0xa7, 0x4e, 0x12, 0xe2, 0xb7, 0x30, 0x61, 0x14, 0xa0,
+	0x4e, 0x92, 0x37, 0xbd, 0xdf, 0x55, 0xc8, 0xaa, 0x12, 0x1c, 0x72, 0x9e, 0x6e, 0x0c, 0x71, 0xe0,
+	0xff, 0x33, 0x44, 0x64, 0x25, 0x05, 0xbd, 0x20, 0x1e, 0xbe, 0x19, 0x0e, 0x79, 0xf0, 0x2d, 0x41,
+	0xbc, 0x3f, 0x57, 0xc5, 0xe3, 0x11, 0xdd, 0x37, 0x23, 0xe5, 0x13, 0x79, 0x64, 0x34, 0xdc, 0xb9,
+	0x84, 0x64, 0xda, 0x26, 0x33, 0x53, 0xe6, 0xc6, 0x9b, 0x49, 0x71, 0x53, 0x92, 0x62, 0x3a, 0x2b,
+	0xf8, 0xa0, 0x2c, 0xb4, 0x61, 0x0d, 0xed, 0xdc, 0x44, 0x3f, 0xb7, 0x66, 0xb0, 0xa6, 0xc7, 0x1a,
+	0xac, 0x99, 0x91, 0x06, 0x6b, 0x56, 0x31, 0x58, 0x0f, 0x65, 0x83, 0xb5, 0x7d, 0x7c, 0x37, 0x3b,
+	0x1e, 0xb2, 0xb7, 0x62, 0x62, 0xaf, 0x62, 0x42, 0xa8, 0x7c, 0x21, 0x45, 0x42, 0xf6, 0xcc, 0x67,
+	0xf9, 0x59, 0xd3, 0xbb, 0x07, 0xa9, 0x3c, 0x49, 0xbc, 0x36, 0x86, 0x2c, 0xd7, 0x32, 0xf9, 0x9e,
+	0xcc, 0xa9, 0x58, 0x55, 0xec, 0xcf, 0xf7, 0x2b, 0x6a, 0x04, 0x26, 0xcf, 0x68, 0xda, 0xee, 0x95,
+	0x5c, 0xf5, 0xab, 0xa8, 0xae, 0x2b, 0x05, 0x9b, 0xab, 0x55, 0x40, 0x6b, 0x26, 0xd7, 0x2a, 0x9a,
+	0xdc, 0x9f, 0x60, 0x26, 0x56, 0xd9, 0x83, 0xaa, 0x34, 0x57, 0x74, 0x7b, 0x33, 0x71, 0x51, 0x95,
+	0xe5, 0xd5, 0x02, 0xcb, 0x27, 0x6f, 0xea, 0x07, 0x15, 0xe1, 0xd0, 0x1f, 0x76, 0xfa, 0x7d, 0xe1,
+	0xd0, 0xcb, 0xf3, 0x70, 0x64, 0x0a, 0xa2, 0x4b, 0x85, 0xa7, 0x9b, 0xa9, 0x03, 0x36, 0x24, 0x75,
+	0xb2, 0x15, 0xf3, 0xbb, 0x29, 0xdf, 0xb9, 0xb0, 0xb6, 0x87, 0x6d, 0x26, 0x39, 0xd5, 0x3b, 0xeb,
+	0xaf, 0x2a, 0xaa, 0x49, 0x53, 0x26, 0x14, 0x43, 0x2a, 0xf2, 0x21, 0xae, 0xe9, 0xfc, 0xd6, 0xf2,
+	0x0d, 0x32, 0x6d, 0x34, 0x9e, 0x43, 0x38, 0xcc, 0x0a, 0x19, 0x19, 0x01, 0x64, 0x90, 0xce, 0x80,
+	0x5a, 0x91, 0x01, 0x9f, 0x54, 0xc5, 0xcb, 0x32, 0x04, 0xa7, 0x93, 0x4e, 0x0c, 0x13, 0x62, 0xfe,
+	0x3e, 0xd9, 0x8a, 0xba, 0xd9, 0xb9, 0x65, 0x90, 0xd8, 0xd4, 0x75, 0xd9, 0xcf, 0xc9, 0x20, 0x7d,
+	0xdb, 0xb5, 0x11, 0xdb, 0xa6, 0x4d, 0x5e, 0x07, 0x65, 0x4b, 0x18, 0x3c, 0xa3, 0x02, 0x06, 0x41,
+	0x2e, 0xca, 0xe2, 0x2d, 0x08, 0x99, 0x07, 0xfd, 0xce, 0xfb, 0x83, 0x90, 0x57, 0x46, 0xb1, 0x48,
+	0x4a, 0x81, 0xe9, 0x44, 0x69, 0x16, 0xaf, 0x8e, 0x74, 0x16, 0xbe, 0x18, 0x2b, 0xab, 0x63, 0xc1,
+	0xbc, 0x02, 0xf3, 0x02, 0x61, 0x7a, 0x78, 0x99, 0x21, 0xbd, 0x9a, 0x8e, 0xb4, 0xe3, 0xf0, 0xf6,
+	0xc2, 0x1d, 0x5b, 0xc2, 0x89, 0x96, 0x03, 0x46, 0x46, 0x05, 0xdf, 0x91, 0x13, 0x20, 0xd2, 0x2a,
+	0xa7, 0x11, 0x4a, 0x96, 0x57, 0x90, 0x2e, 0xf5, 0x8f, 0x35, 0x1d, 0x84, 0x4e, 0xec, 0x68, 0xcc,
+	0x72, 0x16, 0x7c, 0x7d, 0x3e, 0xbd, 0x9f, 0x21, 0x7a, 0x3f, 0xac, 0x18, 0xaf, 0xa1, 0x58, 0xae,
+	0x7e, 0xca, 0x87, 0x2e, 0x13, 0xd9, 0x4a, 0x08, 0xfd, 0xbe, 0x4c, 0xd8, 0xeb, 0xf0, 0xe2, 0xcb,
+	0xca, 0xd4, 0xc7, 0xef, 0x42, 0x79, 0x2e, 0xae, 0xea, 0xcf, 0xc5, 0xe6, 0x24, 0xd6, 0x6f, 0x2a,
+	0x42, 0x4c, 0x3e, 0xcb, 0x75, 0x46, 0x66, 0x06, 0xd5, 0x47, 0x6c, 0x5b, 0x7f, 0xc4, 0xc6, 0x1a,
+	0xe4, 0xe3, 0x3c, 0x37, 0xc2, 0x1a, 0x54, 0xf0, 0x24, 0x7b, 0x08, 0xab, 0x82, 0xfd, 0xa1, 0xd7,
+	0xc6, 0xd3, 0xbc, 0x2a, 0xfc, 0x36, 0xb7, 0xf0, 0x8f, 0x37, 0x13, 0x04, 0x08, 0xa8, 0x81, 0x0f,
+	0xe9, 0x49, 0xd8, 0xe1, 0x45, 0x3b, 0xff, 0x01, 0xc2, 0x61, 0x28, 0x68, 0x20, 0x41, 0x20, 0x60,
+	0xea, 0x87, 0x99, 0xd9, 0x87, 0x4f, 0x5d, 0x4a, 0xea, 0x45, 0x29, 0x79, 0x3b, 0x0f, 0x2e, 0xa2,
+	0x20, 0x6e, 0xb3, 0x48, 0x6d, 0x84, 0x63, 0x4a, 0xa8, 0x45, 0xce, 0x42, 0x7d, 0xd6, 0x00, 0x4c,
+	0x7a, 0xf1, 0x3f, 0xe0, 0x99, 0x37, 0xfc, 0x96, 0xee, 0x21, 0x9b, 0x61, 0xd0, 0x0e, 0xe3, 0x1d,
+	0x98, 0x18, 0x94, 0x89, 0xee, 0x31, 0xee, 0x84, 0x23, 0xee, 0x21, 0xf9, 0xf2, 0x7e, 0x86, 0x48,
+	0xcd, 0x8f, 0x14, 0xa0, 0xc8, 0x93, 0x4d, 0x0c, 0x50, 0x7a, 0x21, 0x9d, 0xa1, 0x95, 0x55, 0xc6,
+	0xb0, 0x16, 0x86, 0x79, 0xd1, 0xe1, 0xfd, 0x6c, 0xb3, 0xf0, 0xed, 0xfd, 0x42, 0xd3, 0xd7, 0xc7,
+	0x5f, 0x45, 0x3a, 0xa8, 0x55, 0xf2, 0xa0, 0x25, 0xb4, 0xf9, 0x47, 0xb6, 0xb8, 0x93, 0x89, 0xf2,
+	0x8f, 0xd3, 0x1a, 0x14, 0x1e, 0xbd, 0x59, 0xfa, 0x55, 0x1b, 0x42, 0x5c, 0x9e, 0xf3, 0xe4, 0xc2,
+	0x95, 0x43, 0xf8, 0x71, 0xf7, 0xa3, 0x36, 0xbf, 0x0c, 0xf0, 0x16, 0x96, 0x53, 0xaa, 0x49, 0x2c,
+	0x9e, 0x81, 0x54, 0xa1, 0x70, 0xc4, 0x9d, 0x70, 0xaf, 0xd3, 0xe7, 0x0b, 0xf0, 0x4c, 0x95, 0x04,
+	0x82, 0xd3, 0x84, 0xfd, 0x36, 0xef, 0x67, 0xa1, 0x78, 0x0e, 0x00, 0xe6, 0x25, 0x69, 0x78, 0x98,
+	0x95, 0x0e, 0xc1, 0x37, 0x73, 0xd4, 0x3d, 0x9e, 0x93, 0x65, 0x39, 0x46, 0x74, 0xd4, 0x02, 0x04,
+	0xc1, 0x2f, 0x34, 0xb7, 0x44, 0x62, 0x29, 0x6b, 0x82, 0xfb, 0xeb, 0x75, 0xfa, 0x60, 0xbe, 0xd9,
+	0xe0, 0x19, 0x1c, 0xac, 0xc0, 0x40, 0x19, 0xb1, 0x3c, 0x1e, 0x78, 0x39, 0x8b, 0xb1, 0xbc, 0x68,
+	0xc3, 0x6e, 0x59, 0x44, 0x70, 0xb7, 0x9d, 0x60, 0xd5, 0x2f, 0xa5, 0xbd, 0x00, 0xc0, 0x6e, 0x77,
+	0x3a, 0x74, 0x56, 0x28, 0x10, 0x9a, 0xf5, 0xf1, 0x5b, 0xaa, 0x1f, 0x5c, 0x90, 0xeb, 0x07, 0x81,
+	0xf2, 0x50, 0x8e, 0xa8, 0x94, 0x04, 0x49, 0x10, 0x96, 0x15, 0x8d, 0x5a, 0x07, 0xc8, 0x34, 0x07,
+	0x87, 0xe6, 0x00, 0xa4, 0x4b, 0x48, 0x6f, 0x13, 0x67, 0x59, 0x61, 0x23, 0x7c, 0xcb, 0xd9, 0xaa,
+	0x7b, 0x54, 0x9c, 0x96, 0xd4, 0xac, 0x20, 0x05, 0xe9, 0xf9, 0xac, 0xe5, 0x62, 0xde, 0x90, 0x62,
+	0x04, 0xdd, 0xbd, 0xe8, 0x5d, 0xea, 0xd4, 0xc0, 0xaa, 0xae, 0x20, 0xd3, 0x65, 0x90, 0xfa, 0x20,
+	0xf0, 0x58, 0x42, 0xe9, 0xfd, 0x3c, 0x4f, 0x55, 0x61, 0x20, 0x89, 0xd7, 0x79, 0x73, 0x14, 0x39,
+	0xa6, 0xec, 0x4d, 0xfa, 0x75, 0x96, 0x55, 0xf8, 0x75, 0x96, 0x76, 0xe2, 0x9a, 0xf1, 0xc4, 0x72,
+	0xc8, 0x66, 0x17, 0x43, 0xb6, 0x93, 0xdc, 0x29, 0xe5, 0x27, 0xa8, 0xa6, 0x56, 0x26, 0x96, 0xf1,
+	0x6c, 0x4a, 0xe5, 0x99, 0x4c, 0x6f, 0x52, 0xa4, 0xf7, 0x6d, 0xe2, 0xe4, 0xf4, 0xbe, 0x3d, 0xe8,
+	0x76, 0x4f, 0xf7, 0x12, 0xe5, 0xfd, 0x3b, 0xcf, 0x9f, 0x80, 0xb3, 0xca, 0x09, 0x5e, 0xfe, 0x3e,
+	0x22, 0x84, 0x3f, 0x7b, 0xd9, 0xb0, 0xfd, 0x1c, 0x30, 0xce, 0x4f, 0x53, 0x3f, 0xd5, 0xa6, 0x9e,
+	0x31, 0xcb, 0x75, 0x43, 0x65, 0xa2, 0x80, 0x60, 0xd5, 0x36, 0xf7, 0x9c, 0x9c, 0xc4, 0xa2, 0x2d,
+	0xe7, 0x89, 0x1a, 0x25, 0xd3, 0xa8, 0x1f, 0xd7, 0xd4, 0x94, 0x6c, 0x49, 0x92, 0x8d, 0x11, 0x30,
+	0xe9, 0xb1, 0xc6, 0x32, 0xfd, 0x98, 0x36, 0x90, 0xca, 0x8e, 0xf8, 0x93, 0x86, 0xfe, 0x98, 0x64,
+	0x1b, 0x1e, 0x93, 0x2e, 0xc3, 0xaf, 0x89, 0xe0, 0xad, 0xd5, 0xfc, 0xeb, 0x20, 0xf6, 0xfb, 0x12,
+	0x9f, 0xe3, 0xe4, 0x1c, 0x69, 0xc8, 0x1c, 0xa1, 0xbb, 0xc3, 0x0f, 0x26, 0xfe, 0x4c, 0xe0, 0x24,
+	0x88, 0xe8, 0x67, 0x26, 0x7a, 0x4a, 0xea, 0x67, 0xe6, 0x19, 0xaa, 0xce, 0xa0, 0x85, 0xd7, 0x87,
+	0x2c, 0x55, 0x6f, 0xfb, 0x2a, 0x50, 0x3c, 0x98, 0x4c, 0x4b, 0x0f, 0x26, 0xea, 0xcf, 0x26, 0x67,
+	0x0a, 0x3f, 0x9b, 0x7c, 0x85, 0x34, 0xe1, 0x81, 0x00, 0x0c, 0x04, 0xff, 0xe9, 0x8e, 0xc6, 0x3a,
+	0x21, 0x80, 0xbe, 0x40, 0x74, 0x5e, 0x23, 0x4d, 0x10, 0x3f, 0xcc, 0xe5, 0xcd, 0x99, 0x4a, 0x3f,
+	0x15, 0xc9, 0xf5, 0x05, 0xb2, 0xee, 0x49, 0xe7, 0x8b, 0x9e, 0xf4, 0x8f, 0x55, 0xf5, 0x92, 0x40,
+	0xd5, 0xab, 0xb3, 0x5b, 0xa2, 0x3e, 0x7d, 0x74, 0x86, 0x16, 0x75, 0xd9, 0x1a, 0xad, 0xcb, 0xb5,
+	0x82, 0x2e, 0xeb, 0xd6, 0xc8, 0x2e, 0x5a, 0x23, 0xaa, 0x0c, 0x8f, 0x60, 0x67, 0x9d, 0xfc, 0xff,
+	0x04, 0x64, 0x6d, 0x18, 0x1d, 0x1e, 0x1f, 0x86, 0xad, 0x34, 0x6c, 0x67, 0xbf, 0xc7, 0xa1, 0xf3,
+	0x4b, 0x20, 0xc0, 0x60, 0x6a, 0xc0, 0x30, 0x9a, 0x0c, 0x43, 0x02, 0x39, 0xaf, 0x13, 0x42, 0xe3,
+	0xe3, 0x5e, 0x90, 0xb6, 0xf6, 0xc3, 0xec, 0x77, 0xe8, 0xe3, 0x2e, 0xe4, 0x12, 0xb6, 0xf7, 0x91,
+	0xf2, 0x6a, 0xcd, 0x7e, 0x27, 0x54, 0x42, 0xb3, 0x68, 0x2f, 0xd4, 0xab, 0xf9, 0x12, 0x11, 0x73,
+	0xc0, 0xa9, 0x5e, 0x71, 0x0f, 0x54, 0x56, 0x4a, 0x3b, 0x79, 0x59, 0xdc, 0x9d, 0x8d, 0x69, 0xe5,
+	0x5c, 0x74, 0xb2, 0x4b, 0xf5, 0xe4, 0x74, 0xfe, 0x8f, 0xb1, 0xf4, 0x4d, 0xca, 0xe2, 0xc2, 0x2f,
+	0xa8, 0x4a, 0x5c, 0xec, 0x54, 0x05, 0xa9, 0x16, 0x14, 0x84, 0xc6, 0x28, 0x3b, 0x41, 0x37, 0xc8,
+	0x8a, 0xfe, 0x69, 0x8c, 0xc2, 0x9b, 0x25, 0xc2, 0xc2, 0x37, 0x21, 0x7a, 0x7d, 0x5f, 0x29, 0xc4,
+	0xc8, 0x12, 0xff, 0x27, 0x77, 0x0c, 0xa9, 0x5a, 0x2d, 0xa2, 0x4e, 0x57, 0xb2, 0xee, 0x93, 0x0f,
+	0x3a, 0xc1, 0x2b, 0xc9, 0xf7, 0xe4, 0x7a, 0x8c, 0x4d, 0x78, 0xe8, 0x1d, 0xf5, 0x5c, 0x2b, 0x24,
+	0xa4, 0x3a, 0x52, 0x42, 0xac, 0xf1, 0x89, 0xea, 0xda, 0xb8, 0x44, 0x35, 0xac, 0x8d, 0xbf, 0x86,
+	0xf9, 0x6c, 0xfc, 0x83, 0xee, 0x09, 0x6a, 0x06, 0x4f, 0x60, 0xae, 0xc3, 0xd7, 0x1e, 0x51, 0xeb,
+	0x93, 0x1f, 0x51, 0x1b, 0xe6, 0x62, 0x83, 0x49, 0x1e, 0x42, 0x0a, 0xa0, 0xa6, 0x4c, 0x01, 0x94,
+	0xcc, 0x49, 0x62, 0xca, 0x38, 0x2c, 0x28, 0x57, 0x29, 0xe0, 0xe5, 0xb5, 0x8c, 0x96, 0xf9, 0xc5,
+	0x4f, 0xcb, 0xb8, 0x66, 0x64, 0xf7, 0x73, 0xc4, 0x49, 0x39, 0xd7, 0xf5, 0x4f, 0x6a, 0xa4, 0xc1,
+	0x39, 0xe2, 0xdc, 0x20, 0x2e, 0xf7, 0x90, 0xc1, 0x91, 0xe2, 0x31, 0xb7, 0x8f, 0x1d, 0xa3, 0x27,
+	0x5d, 0x9d, 0xe7, 0xd0, 0x77, 0xfa, 0x49, 0x67, 0xaf, 0xbf, 0x7d, 0xec, 0x9d, 0x71, 0xbe, 0x41,
+	0x96, 0xf5, 0x49, 0x30, 0xd9, 0xee, 0x14, 0x7f, 0x5a, 0x6c, 0x1a, 0xfe, 0x06, 0x59, 0xd1, 0x87,
+	0x83, 0x43, 0xa1, 0xe3, 0x0d, 0x3f, 0x39, 0x36, 0x4d, 0x70, 0x9d, 0x9c, 0x2b, 0x1c, 0x02, 0x7e,
+	0x04, 0x45, 0x67, 0x30, 0xfd, 0x12, 0xd9, 0x34, 0xc5, 0x1d, 0x32, 0xf7, 0xed, 0x30, 0x95, 0xeb,
+	0x9a, 0x96, 0x85, 0x86, 0xca, 0xe5, 0x4e, 0xab, 0x79, 0x65, 0x9e, 0xa9, 0xfc, 0x05, 0x67, 0x9a,
+	0xa5, 0x33, 0x49, 0xaf, 0xa3, 0x17, 0x0a, 0x13, 0xe5, 0x95, 0x4a, 0xab, 0xee, 0x88, 0x40, 0x2c,
+	0xa1, 0x33, 0x6d, 0xe2, 0x9e, 0xd8, 0x13, 0x23, 0xbc, 0x65, 0x24, 0xce, 0x53, 0x85, 0xa9, 0xe4,
+	0xf2, 0x9f, 0xd5, 0xf3, 0xa3, 0x1e, 0x27, 0x13, 0x24, 0xd2, 0x2c, 0x08, 0xcb, 0xa6, 0x10, 0x93,
+	0xe2, 0x01, 0xa1, 0x7f, 0xf5, 0x9c, 0xe1, 0x80, 0xd0, 0xe1, 0x9d, 0xd9, 0xa9, 0xe3, 0xff, 0x03,
+	0x7a, 0xe5, 0x7f, 0xf6, 0xf3, 0x1c, 0x8f, 0x3a, 0x48, 0x00, 0x00,
 }
@@ -5,17 +5,44 @@
 package types
 
 type LotteryCreateTx struct {
-	PurBlockNum  int64 `json:"purBlockNum"`
-	DrawBlockNum int64 `json:"drawBlockNum"`
-	Fee          int64 `json:"fee"`
+	PurBlockNum        int64    `json:"purBlockNum"`
+	DrawBlockNum       int64    `json:"drawBlockNum"`
+	OpPurchaseLimit    int64    `json:"opPurchaseLimit"`
+	CreatorFeeRatio    int64    `json:"creatorFeeRatio"`
+	PrizeRatio         []int64  `json:"prizeRatio"`
+	MaxRounds          int64    `json:"maxRounds"`
+	RevealBlockNum     int64    `json:"revealBlockNum"`
+	RevealTimeout      int64    `json:"revealTimeout"`
+	TimeoutRefund      bool     `json:"timeoutRefund"`
+	RolloverToBuyers   bool     `json:"rolloverToBuyers"`
+	TokenSymbol        string   `json:"tokenSymbol"`
+	DrawDeadlineBlocks int64    `json:"drawDeadlineBlocks"`
+	DrawRewardRatio    int64    `json:"drawRewardRatio"`
+	MinPurchaseNum     int64    `json:"minPurchaseNum"`
+	MinSalesAmount     int64    `json:"minSalesAmount"`
+	MaxWaitBlocks      int64    `json:"maxWaitBlocks"`
+	RefundBelowMin     bool     `json:"refundBelowMin"`
+	Drawers            []string `json:"drawers"`
+	ReclaimBlockNum    int64    `json:"reclaimBlockNum"`
+	Blacklist          []string `json:"blacklist"`
+	MaxBuyPerTx        int64    `json:"maxBuyPerTx"`
+	CommissionRatio    int64    `json:"commissionRatio"`
+	ForbidSelfDealing  bool     `json:"forbidSelfDealing"`
+	AssetExec          string   `json:"assetExec"`
+	Weighted           bool     `json:"weighted"`
+	PayoutMaturity     int64    `json:"payoutMaturity"`
+	Fee                int64    `json:"fee"`
 }
 
 type LotteryBuyTx struct {
-	LotteryId string `json:"lotteryId"`
-	Amount    int64  `json:"amount"`
-	Number    int64  `json:"number"`
-	Way       int64  `json:"way"`
-	Fee       int64  `json:"fee"`
+	LotteryId  string            `json:"lotteryId"`
+	Amount     int64             `json:"amount"`
+	Number     int64             `json:"number"`
+	Way        int64             `json:"way"`
+	Items      []*LotteryBuyItem `json:"items"`
+	CommitHash string            `json:"commitHash"`
+	AgentAddr  string            `json:"agentAddr"`
+	Fee        int64             `json:"fee"`
 }
 
 type LotteryDrawTx struct {
@@ -27,3 +54,60 @@ type LotteryCloseTx struct {
 	LotteryId string `json:"lotteryId"`
 	Fee       int64  `json:"fee"`
 }
+
+type LotteryCommitTx struct {
+	LotteryId string `json:"lotteryId"`
+	Hash      string `json:"hash"`
+	Fee       int64  `json:"fee"`
+}
+
+type LotteryRevealTx struct {
+	LotteryId string `json:"lotteryId"`
+	Secret    string `json:"secret"`
+	Fee       int64  `json:"fee"`
+}
+
+type LotteryModifyTx struct {
+	LotteryId     string   `json:"lotteryId"`
+	AddDrawers    []string `json:"addDrawers"`
+	RemoveDrawers []string `json:"removeDrawers"`
+	Fee           int64    `json:"fee"`
+}
+
+type LotteryTransferTx struct {
+	LotteryId string `json:"lotteryId"`
+	To        string `json:"to"`
+	Fee       int64  `json:"fee"`
+}
+
+type LotteryReclaimTx struct {
+	LotteryId string `json:"lotteryId"`
+	Fee       int64  `json:"fee"`
+}
+
+type LotteryClaimTx struct {
+	LotteryId string `json:"lotteryId"`
+	Round     int64  `json:"round"`
+	Fee       int64  `json:"fee"`
+}
+
+type LotteryTransferTicketTx struct {
+	LotteryId string `json:"lotteryId"`
+	Index     int64  `json:"index"`
+	To        string `json:"to"`
+	Fee       int64  `json:"fee"`
+}
+
+type LotteryBlacklistTx struct {
+	LotteryId string   `json:"lotteryId"`
+	Add       []string `json:"add"`
+	Remove    []string `json:"remove"`
+	Fee       int64    `json:"fee"`
+}
+
+type LotteryRevealNumberTx struct {
+	LotteryId string `json:"lotteryId"`
+	Number    int64  `json:"number"`
+	Nonce     string `json:"nonce"`
+	Fee       int64  `json:"fee"`
+}
@@ -11,12 +11,35 @@ const (
 	LotteryActionShow
 	LotteryActionDraw
 	LotteryActionClose
+	LotteryActionCommit
+	LotteryActionReveal
+	LotteryActionRevealNumber
+	LotteryActionModify
+	LotteryActionTransfer
+	LotteryActionReclaim
+	LotteryActionBlacklist
+	LotteryActionClaim
+	LotteryActionTransferTicket
 
 	//log for lottery
-	TyLogLotteryCreate = 801
-	TyLogLotteryBuy    = 802
-	TyLogLotteryDraw   = 803
-	TyLogLotteryClose  = 804
+	TyLogLotteryCreate       = 801
+	TyLogLotteryBuy          = 802
+	TyLogLotteryDraw         = 803
+	TyLogLotteryClose        = 804
+	TyLogLotteryFee          = 805
+	TyLogLotteryRefund       = 806
+	TyLogLotteryCommit       = 807
+	TyLogLotteryRevealNumber = 808
+	TyLogLotteryDrawReward   = 809
+	TyLogLotteryModify       = 810
+	TyLogLotteryTransfer     = 811
+	TyLogLotteryReclaim      = 812
+	TyLogLotteryBlacklist    = 813
+	TyLogLotteryDrawEmpty    = 814
+	TyLogLotteryPayoutLock   = 815
+	TyLogLotteryClaim        = 816
+	//购买记录转给其他地址, TyLogLotteryTransfer 已经用于管理地址的移交
+	TyLogLotteryTicketTransfer = 817
 )
 
 const (
@@ -24,9 +47,41 @@ const (
 )
 
 //Lottery status
+//每一轮从第一笔购买开始进入LotteryPurchase, 开始的高度记为h, 购买期为[h, h+purBlockNum],
+//购买期结束之后的购买返回ErrLotteryPurchasePeriodExpired, 不会顺延到下一轮.
+//从h+drawBlockNum 开始可以开奖, 开奖之后的下一个区块开始的购买属于下一轮
 const (
 	LotteryCreated = 1 + iota
 	LotteryPurchase
 	LotteryDrawed
 	LotteryClosed
+	LotteryCommitted
 )
+
+//LotteryRoundEmpty 轮次信息中没有购买, 到期直接跳过的轮次. 只用在轮次信息中, 不是彩票的状态
+const LotteryRoundEmpty = 101
+
+//购买记录的开奖结果, 开奖时标记本轮所有的购买记录
+const (
+	LotteryBuyPending = iota
+	LotteryBuyWon
+	LotteryBuyLost
+)
+
+//开奖号码的推导方式
+const (
+	LotteryDrawByBlock = iota + 1
+	LotteryDrawByReveal
+)
+
+//LotteryDrawAlgoVersion 由seed 计算开奖号码和选出中奖号码的算法版本, 算法改变时加1, 验证时按记录的版本重新计算
+const LotteryDrawAlgoVersion = 1
+
+//排行榜的排名方式: 累计的购买金额(addrspent), 累计的中奖金额(addrwon)
+const (
+	LotteryBoardSpent = iota + 1
+	LotteryBoardWinnings
+)
+
+//LotteryLuckyNumMol 开奖号码的取值范围 [0, LotteryLuckyNumMol)
+const LotteryLuckyNumMol = 100000